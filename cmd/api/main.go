@@ -19,7 +19,7 @@ func main() {
 	log := config.NewLogger()
 	validator := config.NewValidator()
 	app := echo.New()
-	s3Client := config.NewS3Client()
+	storage := config.NewStorage(log)
 	pg := config.NewDatabase(log)
 	defer pg.Pool.Close()
 
@@ -28,7 +28,7 @@ func main() {
 		DB:        pg,
 		Log:       log,
 		Validator: validator,
-		S3Client:  s3Client,
+		Storage:   storage,
 	})
 
 	PORT := os.Getenv("PORT")
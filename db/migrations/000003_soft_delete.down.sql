@@ -0,0 +1,18 @@
+-- Trashed rows cannot be represented without deleted_at
+DELETE FROM employees WHERE deleted_at IS NOT NULL;
+DELETE FROM departments WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS departments_deleted_at_idx;
+DROP INDEX IF EXISTS employees_deleted_at_idx;
+
+DROP INDEX IF EXISTS employees_identity_number_per_user;
+ALTER TABLE employees
+    ADD CONSTRAINT employees_identity_number_per_user UNIQUE (user_id, identity_number);
+
+ALTER TABLE employees DROP CONSTRAINT employees_department_id_fkey;
+ALTER TABLE employees
+    ADD CONSTRAINT employees_department_id_fkey
+    FOREIGN KEY (department_id) REFERENCES departments(id) ON DELETE CASCADE;
+
+ALTER TABLE departments DROP COLUMN deleted_at;
+ALTER TABLE employees DROP COLUMN deleted_at;
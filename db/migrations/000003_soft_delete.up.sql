@@ -0,0 +1,18 @@
+-- Soft delete columns
+ALTER TABLE employees ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE departments ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Deleting a department must never take its employees with it
+ALTER TABLE employees DROP CONSTRAINT employees_department_id_fkey;
+ALTER TABLE employees
+    ADD CONSTRAINT employees_department_id_fkey
+    FOREIGN KEY (department_id) REFERENCES departments(id) ON DELETE RESTRICT;
+
+-- Identity numbers only need to be unique among employees that are not in the trash
+ALTER TABLE employees DROP CONSTRAINT employees_identity_number_per_user;
+CREATE UNIQUE INDEX employees_identity_number_per_user
+    ON employees (user_id, identity_number)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX employees_deleted_at_idx ON employees (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX departments_deleted_at_idx ON departments (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS employees_manager_id_idx;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS employees_manager_not_self;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS employees_manager_id_fkey;
+ALTER TABLE employees DROP COLUMN IF EXISTS manager_id;
@@ -0,0 +1,9 @@
+-- Reporting lines
+ALTER TABLE employees ADD COLUMN manager_id BIGINT;
+ALTER TABLE employees
+    ADD CONSTRAINT employees_manager_id_fkey
+    FOREIGN KEY (manager_id) REFERENCES employees(id) ON DELETE SET NULL;
+ALTER TABLE employees
+    ADD CONSTRAINT employees_manager_not_self CHECK (manager_id <> id);
+
+CREATE INDEX employees_manager_id_idx ON employees (manager_id);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS departments_name_trgm_idx;
+DROP INDEX IF EXISTS employees_job_title_trgm_idx;
+DROP INDEX IF EXISTS employees_identity_number_trgm_idx;
+DROP INDEX IF EXISTS employees_name_trgm_idx;
+DROP INDEX IF EXISTS departments_search_vector_idx;
+DROP INDEX IF EXISTS employees_search_vector_idx;
+
+ALTER TABLE departments DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE employees DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE employees DROP COLUMN IF EXISTS job_title;
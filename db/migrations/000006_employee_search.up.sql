@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Free text job title, searchable alongside the other employee fields
+ALTER TABLE employees ADD COLUMN job_title VARCHAR(255);
+
+-- Full text search vectors
+ALTER TABLE employees ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(identity_number, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(job_title, '')), 'B')
+) STORED;
+ALTER TABLE departments ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'C')
+) STORED;
+
+CREATE INDEX employees_search_vector_idx ON employees USING GIN (search_vector);
+CREATE INDEX departments_search_vector_idx ON departments USING GIN (search_vector);
+
+-- Trigram indexes for fuzzy matching and for the existing ILIKE filters
+CREATE INDEX employees_name_trgm_idx ON employees USING GIN (name gin_trgm_ops);
+CREATE INDEX employees_identity_number_trgm_idx ON employees USING GIN (identity_number gin_trgm_ops);
+CREATE INDEX employees_job_title_trgm_idx ON employees USING GIN (job_title gin_trgm_ops);
+CREATE INDEX departments_name_trgm_idx ON departments USING GIN (name gin_trgm_ops);
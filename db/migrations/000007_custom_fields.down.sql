@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS employees_custom_fields_idx;
+ALTER TABLE employees DROP COLUMN IF EXISTS custom_fields;
+
+-- Drop tables
+DROP TABLE IF EXISTS custom_fields CASCADE;
+
+-- DROP ENUM
+DROP TYPE IF EXISTS enum_custom_field_type CASCADE;
@@ -0,0 +1,20 @@
+-- Create enum
+CREATE TYPE enum_custom_field_type AS ENUM ('text', 'number', 'boolean', 'date', 'enum');
+
+-- Create table custom_fields
+CREATE TABLE custom_fields (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    key VARCHAR(64) NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    type enum_custom_field_type NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    validation_regex TEXT,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT custom_fields_key_per_user UNIQUE (user_id, key)
+);
+
+-- Custom field values per employee, keyed by custom_fields.key
+ALTER TABLE employees ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+CREATE INDEX employees_custom_fields_idx ON employees USING GIN (custom_fields jsonb_path_ops);
@@ -0,0 +1,10 @@
+-- Drop tables
+DROP TABLE IF EXISTS employee_status_transitions CASCADE;
+
+DROP INDEX IF EXISTS employees_status_idx;
+ALTER TABLE employees DROP COLUMN IF EXISTS status_effective_date;
+ALTER TABLE employees DROP COLUMN IF EXISTS status;
+
+-- DROP ENUM
+DROP TYPE IF EXISTS enum_termination_type CASCADE;
+DROP TYPE IF EXISTS enum_employee_status CASCADE;
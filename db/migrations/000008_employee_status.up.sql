@@ -0,0 +1,35 @@
+-- Create enum
+CREATE TYPE enum_employee_status AS ENUM ('candidate', 'onboarding', 'active', 'on_leave', 'suspended', 'terminated');
+CREATE TYPE enum_termination_type AS ENUM ('resignation', 'dismissal', 'end_of_contract', 'retirement', 'death', 'other');
+
+-- Current status of every employee, existing employees are active
+ALTER TABLE employees ADD COLUMN status enum_employee_status NOT NULL DEFAULT 'active';
+ALTER TABLE employees ADD COLUMN status_effective_date DATE NOT NULL DEFAULT CURRENT_DATE;
+CREATE INDEX employees_status_idx ON employees (user_id, status);
+
+-- Create table employee_status_transitions
+CREATE TABLE employee_status_transitions (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL,
+    from_status enum_employee_status,
+    to_status enum_employee_status NOT NULL,
+    effective_date DATE NOT NULL,
+    reason TEXT,
+    termination_type enum_termination_type,
+    last_working_date DATE,
+    eligible_for_rehire BOOLEAN,
+    changed_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    applied_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE,
+    FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX employee_status_transitions_employee_idx ON employee_status_transitions (employee_id, created_at);
+CREATE INDEX employee_status_transitions_pending_idx ON employee_status_transitions (effective_date)
+    WHERE applied_at IS NULL AND cancelled_at IS NULL;
+
+-- Existing employees start their history as active
+INSERT INTO employee_status_transitions (employee_id, to_status, effective_date, applied_at)
+SELECT id, 'active', CURRENT_DATE, NOW() FROM employees;
@@ -0,0 +1,8 @@
+-- Drop tables
+DROP TABLE IF EXISTS employee_checklist_tasks CASCADE;
+DROP TABLE IF EXISTS employee_checklists CASCADE;
+DROP TABLE IF EXISTS checklist_template_tasks CASCADE;
+DROP TABLE IF EXISTS checklist_templates CASCADE;
+
+-- DROP ENUM
+DROP TYPE IF EXISTS enum_checklist_type CASCADE;
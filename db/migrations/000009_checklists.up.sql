@@ -0,0 +1,59 @@
+-- Create enum
+CREATE TYPE enum_checklist_type AS ENUM ('onboarding', 'offboarding');
+
+-- Create table checklist_templates
+CREATE TABLE checklist_templates (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    type enum_checklist_type NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX checklist_templates_user_type_idx ON checklist_templates (user_id, type);
+
+-- Create table checklist_template_tasks
+CREATE TABLE checklist_template_tasks (
+    id BIGSERIAL PRIMARY KEY,
+    template_id BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    assignee_role VARCHAR(64) NOT NULL,
+    due_offset_days INT NOT NULL DEFAULT 0,
+    position INT NOT NULL,
+    FOREIGN KEY (template_id) REFERENCES checklist_templates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX checklist_template_tasks_template_idx ON checklist_template_tasks (template_id, position);
+
+-- Checklists instantiated for an employee, copied from a template so later
+-- template edits do not change running checklists
+CREATE TABLE employee_checklists (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL,
+    template_id BIGINT,
+    name VARCHAR(255) NOT NULL,
+    type enum_checklist_type NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE,
+    FOREIGN KEY (template_id) REFERENCES checklist_templates(id) ON DELETE SET NULL
+);
+
+CREATE INDEX employee_checklists_employee_idx ON employee_checklists (employee_id);
+
+CREATE TABLE employee_checklist_tasks (
+    id BIGSERIAL PRIMARY KEY,
+    checklist_id BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    assignee_role VARCHAR(64) NOT NULL,
+    due_date DATE NOT NULL,
+    position INT NOT NULL,
+    completed_at TIMESTAMPTZ,
+    completed_by BIGINT,
+    FOREIGN KEY (checklist_id) REFERENCES employee_checklists(id) ON DELETE CASCADE,
+    FOREIGN KEY (completed_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX employee_checklist_tasks_checklist_idx ON employee_checklist_tasks (checklist_id, position);
+CREATE INDEX employee_checklist_tasks_open_idx ON employee_checklist_tasks (due_date)
+    WHERE completed_at IS NULL;
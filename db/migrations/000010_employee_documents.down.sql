@@ -0,0 +1,5 @@
+-- Drop tables
+DROP TABLE IF EXISTS employee_documents CASCADE;
+
+-- DROP ENUM
+DROP TYPE IF EXISTS enum_document_category CASCADE;
@@ -0,0 +1,22 @@
+-- Create enum
+CREATE TYPE enum_document_category AS ENUM ('contract', 'identity', 'certificate', 'tax', 'medical', 'other');
+
+-- Create table employee_documents
+CREATE TABLE employee_documents (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL,
+    category enum_document_category NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    file_key TEXT NOT NULL,
+    content_type VARCHAR(127) NOT NULL,
+    size BIGINT NOT NULL,
+    expires_at DATE,
+    uploaded_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE,
+    FOREIGN KEY (uploaded_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX employee_documents_employee_idx ON employee_documents (employee_id, category);
+CREATE INDEX employee_documents_expires_at_idx ON employee_documents (expires_at)
+    WHERE expires_at IS NOT NULL;
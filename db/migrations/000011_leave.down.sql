@@ -0,0 +1,9 @@
+-- Drop tables
+DROP TABLE IF EXISTS leave_requests CASCADE;
+DROP TABLE IF EXISTS holidays CASCADE;
+DROP TABLE IF EXISTS leave_entitlements CASCADE;
+DROP TABLE IF EXISTS leave_types CASCADE;
+
+-- DROP ENUM
+DROP TYPE IF EXISTS enum_leave_status CASCADE;
+DROP TYPE IF EXISTS enum_leave_accrual CASCADE;
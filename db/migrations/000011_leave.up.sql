@@ -0,0 +1,64 @@
+-- Create enum
+CREATE TYPE enum_leave_accrual AS ENUM ('yearly', 'monthly');
+CREATE TYPE enum_leave_status AS ENUM ('pending', 'approved', 'rejected', 'cancelled');
+
+-- Create table leave_types
+CREATE TABLE leave_types (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    is_paid BOOLEAN NOT NULL DEFAULT TRUE,
+    requires_balance BOOLEAN NOT NULL DEFAULT TRUE,
+    yearly_entitlement INT NOT NULL DEFAULT 0,
+    accrual enum_leave_accrual NOT NULL DEFAULT 'yearly',
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT leave_types_code_per_user UNIQUE (user_id, code)
+);
+
+-- Per employee override of the yearly entitlement of a leave type
+CREATE TABLE leave_entitlements (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL,
+    leave_type_id BIGINT NOT NULL,
+    year INT NOT NULL,
+    days INT NOT NULL,
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE,
+    FOREIGN KEY (leave_type_id) REFERENCES leave_types(id) ON DELETE CASCADE,
+    CONSTRAINT leave_entitlements_per_year UNIQUE (employee_id, leave_type_id, year)
+);
+
+-- Create table holidays
+CREATE TABLE holidays (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT holidays_date_per_user UNIQUE (user_id, date)
+);
+
+-- Create table leave_requests
+CREATE TABLE leave_requests (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL,
+    leave_type_id BIGINT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    days INT NOT NULL,
+    reason TEXT,
+    status enum_leave_status NOT NULL DEFAULT 'pending',
+    approver_id BIGINT,
+    reviewed_by BIGINT,
+    review_note TEXT,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE,
+    FOREIGN KEY (leave_type_id) REFERENCES leave_types(id) ON DELETE RESTRICT,
+    FOREIGN KEY (approver_id) REFERENCES employees(id) ON DELETE SET NULL,
+    FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT leave_requests_dates CHECK (start_date <= end_date)
+);
+
+CREATE INDEX leave_requests_employee_idx ON leave_requests (employee_id, start_date);
+CREATE INDEX leave_requests_status_idx ON leave_requests (status, start_date);
@@ -0,0 +1,10 @@
+-- Drop tables
+DROP TABLE IF EXISTS attendance_events CASCADE;
+
+ALTER TABLE employees DROP COLUMN IF EXISTS work_schedule_id;
+
+DROP TABLE IF EXISTS work_schedules CASCADE;
+DROP TABLE IF EXISTS office_locations CASCADE;
+
+-- DROP ENUM
+DROP TYPE IF EXISTS enum_attendance_event_type CASCADE;
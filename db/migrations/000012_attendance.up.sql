@@ -0,0 +1,51 @@
+-- Create enum
+CREATE TYPE enum_attendance_event_type AS ENUM ('clock_in', 'clock_out');
+
+-- Create table office_locations, used as geofences for clock events
+CREATE TABLE office_locations (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    radius_meters INT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX office_locations_user_idx ON office_locations (user_id);
+
+-- Create table work_schedules
+CREATE TABLE work_schedules (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    work_days INT[] NOT NULL DEFAULT '{1,2,3,4,5}',
+    late_tolerance_minutes INT NOT NULL DEFAULT 0,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT work_schedules_times CHECK (start_time < end_time)
+);
+
+CREATE UNIQUE INDEX work_schedules_default_per_user ON work_schedules (user_id) WHERE is_default;
+
+-- Employees without a schedule follow the default schedule of the organization
+ALTER TABLE employees ADD COLUMN work_schedule_id BIGINT REFERENCES work_schedules(id) ON DELETE SET NULL;
+
+-- Create table attendance_events
+CREATE TABLE attendance_events (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL,
+    type enum_attendance_event_type NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    office_location_id BIGINT,
+    distance_meters DOUBLE PRECISION,
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE,
+    FOREIGN KEY (office_location_id) REFERENCES office_locations(id) ON DELETE SET NULL
+);
+
+CREATE INDEX attendance_events_employee_idx ON attendance_events (employee_id, occurred_at);
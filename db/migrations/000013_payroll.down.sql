@@ -0,0 +1,9 @@
+-- Drop tables
+DROP TABLE IF EXISTS payroll_items CASCADE;
+DROP TABLE IF EXISTS payroll_runs CASCADE;
+DROP TABLE IF EXISTS salary_components CASCADE;
+DROP TABLE IF EXISTS salary_structures CASCADE;
+
+-- DROP ENUM
+DROP TYPE IF EXISTS enum_payroll_run_status CASCADE;
+DROP TYPE IF EXISTS enum_salary_component_type CASCADE;
@@ -0,0 +1,63 @@
+-- Create enum
+CREATE TYPE enum_salary_component_type AS ENUM ('earning', 'deduction');
+CREATE TYPE enum_payroll_run_status AS ENUM ('draft', 'locked');
+
+-- Create table salary_structures, the monthly base salary of an employee
+CREATE TABLE salary_structures (
+    employee_id BIGINT PRIMARY KEY,
+    base_salary BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE,
+    CONSTRAINT salary_structures_base_salary CHECK (base_salary >= 0)
+);
+
+-- Create table salary_components, recurring monthly allowances and deductions
+CREATE TABLE salary_components (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    type enum_salary_component_type NOT NULL,
+    amount BIGINT NOT NULL,
+    is_taxable BOOLEAN NOT NULL DEFAULT TRUE,
+    position INT NOT NULL,
+    FOREIGN KEY (employee_id) REFERENCES salary_structures(employee_id) ON DELETE CASCADE,
+    CONSTRAINT salary_components_code_per_employee UNIQUE (employee_id, code),
+    CONSTRAINT salary_components_amount CHECK (amount >= 0)
+);
+
+-- Create table payroll_runs
+CREATE TABLE payroll_runs (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    period DATE NOT NULL,
+    status enum_payroll_run_status NOT NULL DEFAULT 'draft',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    calculated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_at TIMESTAMPTZ,
+    locked_by BIGINT,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (locked_by) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT payroll_runs_period_per_user UNIQUE (user_id, period)
+);
+
+-- Create table payroll_items, a snapshot of every employee in a run so later
+-- changes to the employee or their salary do not alter it
+CREATE TABLE payroll_items (
+    id BIGSERIAL PRIMARY KEY,
+    payroll_run_id BIGINT NOT NULL,
+    employee_id BIGINT,
+    identity_number VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    department_name VARCHAR(255) NOT NULL,
+    job_title VARCHAR(255),
+    base_salary BIGINT NOT NULL,
+    gross BIGINT NOT NULL,
+    total_deductions BIGINT NOT NULL,
+    net BIGINT NOT NULL,
+    lines JSONB NOT NULL DEFAULT '[]',
+    FOREIGN KEY (payroll_run_id) REFERENCES payroll_runs(id) ON DELETE CASCADE,
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE SET NULL
+);
+
+CREATE INDEX payroll_items_run_idx ON payroll_items (payroll_run_id, identity_number);
@@ -0,0 +1,6 @@
+-- Drop columns
+ALTER TABLE salary_structures DROP CONSTRAINT IF EXISTS salary_structures_jkk_risk_class;
+ALTER TABLE salary_structures DROP CONSTRAINT IF EXISTS salary_structures_ptkp_status;
+ALTER TABLE salary_structures DROP COLUMN IF EXISTS jkk_risk_class;
+ALTER TABLE salary_structures DROP COLUMN IF EXISTS is_bpjs_enrolled;
+ALTER TABLE salary_structures DROP COLUMN IF EXISTS ptkp_status;
@@ -0,0 +1,8 @@
+-- Tax and BPJS profile of the employee, used by the statutory payroll rules
+ALTER TABLE salary_structures ADD COLUMN ptkp_status VARCHAR(8) NOT NULL DEFAULT 'TK/0';
+ALTER TABLE salary_structures ADD COLUMN is_bpjs_enrolled BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE salary_structures ADD COLUMN jkk_risk_class SMALLINT NOT NULL DEFAULT 1;
+ALTER TABLE salary_structures ADD CONSTRAINT salary_structures_ptkp_status
+    CHECK (ptkp_status IN ('TK/0', 'TK/1', 'TK/2', 'TK/3', 'K/0', 'K/1', 'K/2', 'K/3'));
+ALTER TABLE salary_structures ADD CONSTRAINT salary_structures_jkk_risk_class
+    CHECK (jkk_risk_class BETWEEN 1 AND 5);
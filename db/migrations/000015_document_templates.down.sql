@@ -0,0 +1,12 @@
+-- Drop tables
+DROP TABLE IF EXISTS document_templates;
+
+-- DROP ENUM
+DROP TYPE IF EXISTS enum_document_template_kind;
+
+DELETE FROM employee_documents WHERE category = 'payslip';
+ALTER TYPE enum_document_category RENAME TO enum_document_category_old;
+CREATE TYPE enum_document_category AS ENUM ('contract', 'identity', 'certificate', 'tax', 'medical', 'other');
+ALTER TABLE employee_documents
+    ALTER COLUMN category TYPE enum_document_category USING category::text::enum_document_category;
+DROP TYPE enum_document_category_old;
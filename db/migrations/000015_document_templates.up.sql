@@ -0,0 +1,19 @@
+-- Create enum
+CREATE TYPE enum_document_template_kind AS ENUM ('payslip', 'employment_certificate', 'offer_letter');
+
+ALTER TYPE enum_document_category ADD VALUE 'payslip';
+
+-- Create table document_templates
+CREATE TABLE document_templates (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    kind enum_document_template_kind NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX document_templates_user_kind_idx ON document_templates (user_id, kind);
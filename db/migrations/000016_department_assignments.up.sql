@@ -0,0 +1,38 @@
+-- Create table employee_department_assignments
+CREATE TABLE employee_department_assignments (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id BIGINT NOT NULL,
+    from_department_id BIGINT,
+    to_department_id BIGINT,
+    effective_date DATE NOT NULL,
+    reason TEXT,
+    changed_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    applied_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE,
+    FOREIGN KEY (from_department_id) REFERENCES departments(id) ON DELETE SET NULL,
+    FOREIGN KEY (to_department_id) REFERENCES departments(id) ON DELETE SET NULL,
+    FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX employee_department_assignments_employee_idx ON employee_department_assignments (employee_id, effective_date);
+CREATE INDEX employee_department_assignments_pending_idx ON employee_department_assignments (effective_date)
+    WHERE applied_at IS NULL AND cancelled_at IS NULL;
+
+-- Existing employees start their history in their current department, from
+-- the start of their status history
+INSERT INTO employee_department_assignments (employee_id, to_department_id, effective_date, applied_at)
+SELECT
+    employees.id,
+    employees.department_id,
+    COALESCE(
+        (
+            SELECT MIN(employee_status_transitions.effective_date)
+            FROM employee_status_transitions
+            WHERE employee_status_transitions.employee_id = employees.id
+        ),
+        CURRENT_DATE
+    ),
+    NOW()
+FROM employees;
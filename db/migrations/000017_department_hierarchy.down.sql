@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS departments_parent_id_idx;
+ALTER TABLE departments DROP CONSTRAINT IF EXISTS departments_parent_not_self;
+ALTER TABLE departments DROP CONSTRAINT IF EXISTS departments_parent_id_fkey;
+ALTER TABLE departments DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,9 @@
+-- Department hierarchy
+ALTER TABLE departments ADD COLUMN parent_id BIGINT;
+ALTER TABLE departments
+    ADD CONSTRAINT departments_parent_id_fkey
+    FOREIGN KEY (parent_id) REFERENCES departments(id) ON DELETE SET NULL;
+ALTER TABLE departments
+    ADD CONSTRAINT departments_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX departments_parent_id_idx ON departments (parent_id);
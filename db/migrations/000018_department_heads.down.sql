@@ -0,0 +1,3 @@
+-- Drop tables
+DROP TABLE IF EXISTS manager_logins CASCADE;
+DROP TABLE IF EXISTS department_heads CASCADE;
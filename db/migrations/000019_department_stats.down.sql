@@ -0,0 +1 @@
+DROP INDEX IF EXISTS employees_department_id_idx;
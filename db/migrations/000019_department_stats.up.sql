@@ -0,0 +1,2 @@
+-- Department stats, filters and manager scopes all look employees up by department
+CREATE INDEX employees_department_id_idx ON employees (department_id) WHERE deleted_at IS NULL;
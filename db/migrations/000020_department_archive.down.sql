@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS departments_archived_at_idx;
+ALTER TABLE departments DROP COLUMN IF EXISTS archived_at;
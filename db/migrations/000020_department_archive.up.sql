@@ -0,0 +1,4 @@
+-- Archived departments stay resolvable but no longer take new employees
+ALTER TABLE departments ADD COLUMN archived_at TIMESTAMPTZ;
+
+CREATE INDEX departments_archived_at_idx ON departments (archived_at) WHERE archived_at IS NOT NULL;
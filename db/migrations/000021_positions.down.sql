@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS employees_position_id_idx;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS employees_position_id_fkey;
+ALTER TABLE employees DROP COLUMN IF EXISTS position_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS positions CASCADE;
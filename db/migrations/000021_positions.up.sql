@@ -0,0 +1,27 @@
+-- Create table positions, the budgeted seats of a department
+CREATE TABLE positions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    department_id BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    grade VARCHAR(33),
+    budgeted_count INT NOT NULL,
+    salary_min BIGINT,
+    salary_max BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (department_id) REFERENCES departments(id) ON DELETE CASCADE,
+    CONSTRAINT positions_budgeted_count CHECK (budgeted_count >= 0),
+    CONSTRAINT positions_salary_band CHECK (salary_min >= 0 AND salary_max >= salary_min)
+);
+
+CREATE INDEX positions_department_id_idx ON positions (department_id);
+
+-- Employees fill the seats of a position
+ALTER TABLE employees ADD COLUMN position_id BIGINT;
+ALTER TABLE employees
+    ADD CONSTRAINT employees_position_id_fkey
+    FOREIGN KEY (position_id) REFERENCES positions(id) ON DELETE SET NULL;
+
+CREATE INDEX employees_position_id_idx ON employees (position_id) WHERE deleted_at IS NULL;
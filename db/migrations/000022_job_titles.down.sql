@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS employees_job_title_id_idx;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS employees_job_title_id_fkey;
+ALTER TABLE employees DROP COLUMN IF EXISTS job_title_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS job_titles CASCADE;
@@ -0,0 +1,24 @@
+-- Create table job_titles, the organization-wide catalog of job titles
+CREATE TABLE job_titles (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    level INT NOT NULL DEFAULT 1,
+    grade VARCHAR(33),
+    salary_min BIGINT,
+    salary_max BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT job_titles_name_level_per_user UNIQUE (user_id, name, level),
+    CONSTRAINT job_titles_level CHECK (level >= 1),
+    CONSTRAINT job_titles_salary_band CHECK (salary_min >= 0 AND salary_max >= salary_min)
+);
+
+-- Employees may hold a title of the catalog, job_title keeps its name
+ALTER TABLE employees ADD COLUMN job_title_id BIGINT;
+ALTER TABLE employees
+    ADD CONSTRAINT employees_job_title_id_fkey
+    FOREIGN KEY (job_title_id) REFERENCES job_titles(id) ON DELETE SET NULL;
+
+CREATE INDEX employees_job_title_id_idx ON employees (job_title_id) WHERE deleted_at IS NULL;
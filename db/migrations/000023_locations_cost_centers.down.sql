@@ -0,0 +1,15 @@
+ALTER TABLE payroll_items DROP CONSTRAINT IF EXISTS payroll_items_cost_center_id_fkey;
+ALTER TABLE payroll_items DROP CONSTRAINT IF EXISTS payroll_items_location_id_fkey;
+ALTER TABLE payroll_items DROP COLUMN IF EXISTS cost_center_id;
+ALTER TABLE payroll_items DROP COLUMN IF EXISTS location_id;
+
+DROP INDEX IF EXISTS employees_cost_center_id_idx;
+DROP INDEX IF EXISTS employees_location_id_idx;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS employees_cost_center_id_fkey;
+ALTER TABLE employees DROP CONSTRAINT IF EXISTS employees_location_id_fkey;
+ALTER TABLE employees DROP COLUMN IF EXISTS cost_center_id;
+ALTER TABLE employees DROP COLUMN IF EXISTS location_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS cost_centers CASCADE;
+DROP TABLE IF EXISTS locations CASCADE;
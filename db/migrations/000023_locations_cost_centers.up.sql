@@ -0,0 +1,56 @@
+-- Create table locations, the offices of the organization
+CREATE TABLE locations (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    address TEXT,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta',
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    radius_meters INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT locations_name_per_user UNIQUE (user_id, name),
+    CONSTRAINT locations_geofence CHECK (
+        (latitude IS NULL AND longitude IS NULL AND radius_meters IS NULL)
+        OR (latitude IS NOT NULL AND longitude IS NOT NULL AND radius_meters > 0)
+    )
+);
+
+-- Create table cost_centers
+CREATE TABLE cost_centers (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT cost_centers_code_per_user UNIQUE (user_id, code)
+);
+
+-- Employees work at a location and are charged to a cost center, both cut
+-- across departments
+ALTER TABLE employees ADD COLUMN location_id BIGINT;
+ALTER TABLE employees ADD COLUMN cost_center_id BIGINT;
+ALTER TABLE employees
+    ADD CONSTRAINT employees_location_id_fkey
+    FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE SET NULL;
+ALTER TABLE employees
+    ADD CONSTRAINT employees_cost_center_id_fkey
+    FOREIGN KEY (cost_center_id) REFERENCES cost_centers(id) ON DELETE SET NULL;
+
+CREATE INDEX employees_location_id_idx ON employees (location_id) WHERE deleted_at IS NULL;
+CREATE INDEX employees_cost_center_id_idx ON employees (cost_center_id) WHERE deleted_at IS NULL;
+
+-- Payroll items keep the location and cost center of the run, so moving an
+-- employee later does not move the cost of past runs
+ALTER TABLE payroll_items ADD COLUMN location_id BIGINT;
+ALTER TABLE payroll_items ADD COLUMN cost_center_id BIGINT;
+ALTER TABLE payroll_items
+    ADD CONSTRAINT payroll_items_location_id_fkey
+    FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE SET NULL;
+ALTER TABLE payroll_items
+    ADD CONSTRAINT payroll_items_cost_center_id_fkey
+    FOREIGN KEY (cost_center_id) REFERENCES cost_centers(id) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+-- Drop tables
+DROP TABLE IF EXISTS file_uploads CASCADE;
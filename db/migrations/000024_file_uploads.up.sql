@@ -0,0 +1,21 @@
+-- Create table file_uploads, files uploaded straight to a private staging key
+-- of the storage with a presigned URL. An upload is only accepted once
+-- completed, which copies it to its public key. Uploads left pending past
+-- their expiry are purged along with the file, completed ones have their
+-- staging key cleared once it can no longer be uploaded to
+CREATE TABLE file_uploads (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    file_key TEXT NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    staging_deleted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT file_uploads_file_key UNIQUE (file_key),
+    CONSTRAINT file_uploads_size CHECK (size > 0)
+);
+
+CREATE INDEX file_uploads_staging_idx ON file_uploads (expires_at) WHERE staging_deleted_at IS NULL;
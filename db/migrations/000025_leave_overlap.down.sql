@@ -0,0 +1 @@
+ALTER TABLE leave_requests DROP CONSTRAINT IF EXISTS leave_requests_no_overlap;
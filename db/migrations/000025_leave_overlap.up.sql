@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- An employee cannot have two pending or approved requests on the same day,
+-- even when they are filed at the same time
+ALTER TABLE leave_requests ADD CONSTRAINT leave_requests_no_overlap EXCLUDE USING gist (
+    employee_id WITH =,
+    daterange(start_date, end_date, '[]') WITH &&
+) WHERE (status IN ('pending', 'approved'));
@@ -0,0 +1,28 @@
+-- Recreate table office_locations from the locations with a geofence
+CREATE TABLE office_locations (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    radius_meters INT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX office_locations_user_idx ON office_locations (user_id);
+
+INSERT INTO office_locations(id, user_id, name, latitude, longitude, radius_meters)
+SELECT id, user_id, name, latitude, longitude, radius_meters
+FROM locations
+WHERE latitude IS NOT NULL;
+
+SELECT setval(pg_get_serial_sequence('office_locations', 'id'), COALESCE(MAX(id), 0) + 1, false)
+FROM office_locations;
+
+ALTER TABLE attendance_events DROP CONSTRAINT IF EXISTS attendance_events_office_location_id_fkey;
+UPDATE attendance_events
+SET office_location_id = NULL
+WHERE office_location_id NOT IN (SELECT id FROM office_locations);
+ALTER TABLE attendance_events
+    ADD CONSTRAINT attendance_events_office_location_id_fkey
+    FOREIGN KEY (office_location_id) REFERENCES office_locations(id) ON DELETE SET NULL;
@@ -0,0 +1,38 @@
+-- Office locations become locations with a geofence, so that attendance and
+-- the rest of the organization share one list of offices
+ALTER TABLE locations ADD COLUMN office_location_id BIGINT;
+
+-- A name already taken by a location, or by another office location of the
+-- same user, gets the id of the office location appended
+INSERT INTO locations(user_id, name, latitude, longitude, radius_meters, office_location_id)
+SELECT
+    office_locations.user_id,
+    CASE
+        WHEN EXISTS (
+            SELECT 1
+            FROM locations
+            WHERE
+                locations.user_id = office_locations.user_id
+                AND locations.name = office_locations.name
+        ) OR COUNT(*) OVER (PARTITION BY office_locations.user_id, office_locations.name) > 1
+        THEN LEFT(office_locations.name, 230) || ' #' || office_locations.id
+        ELSE office_locations.name
+    END,
+    office_locations.latitude,
+    office_locations.longitude,
+    office_locations.radius_meters,
+    office_locations.id
+FROM office_locations;
+
+-- Clock events point at the location that took over their office location
+ALTER TABLE attendance_events DROP CONSTRAINT IF EXISTS attendance_events_office_location_id_fkey;
+UPDATE attendance_events
+SET office_location_id = locations.id
+FROM locations
+WHERE locations.office_location_id = attendance_events.office_location_id;
+ALTER TABLE attendance_events
+    ADD CONSTRAINT attendance_events_office_location_id_fkey
+    FOREIGN KEY (office_location_id) REFERENCES locations(id) ON DELETE SET NULL;
+
+ALTER TABLE locations DROP COLUMN office_location_id;
+DROP TABLE office_locations;
@@ -0,0 +1,8 @@
+ALTER TABLE employee_department_assignments DROP CONSTRAINT IF EXISTS employee_department_assignments_changed_by_employee_id_fkey;
+ALTER TABLE employee_department_assignments DROP COLUMN IF EXISTS changed_by_employee_id;
+
+ALTER TABLE employee_status_transitions DROP CONSTRAINT IF EXISTS employee_status_transitions_changed_by_employee_id_fkey;
+ALTER TABLE employee_status_transitions DROP COLUMN IF EXISTS changed_by_employee_id;
+
+ALTER TABLE employee_history DROP CONSTRAINT IF EXISTS employee_history_changed_by_employee_id_fkey;
+ALTER TABLE employee_history DROP COLUMN IF EXISTS changed_by_employee_id;
@@ -0,0 +1,16 @@
+-- Changes made from a manager login keep the department head who made them,
+-- changed_by stays the user owning the account
+ALTER TABLE employee_history ADD COLUMN changed_by_employee_id BIGINT;
+ALTER TABLE employee_history
+    ADD CONSTRAINT employee_history_changed_by_employee_id_fkey
+    FOREIGN KEY (changed_by_employee_id) REFERENCES employees(id) ON DELETE SET NULL;
+
+ALTER TABLE employee_status_transitions ADD COLUMN changed_by_employee_id BIGINT;
+ALTER TABLE employee_status_transitions
+    ADD CONSTRAINT employee_status_transitions_changed_by_employee_id_fkey
+    FOREIGN KEY (changed_by_employee_id) REFERENCES employees(id) ON DELETE SET NULL;
+
+ALTER TABLE employee_department_assignments ADD COLUMN changed_by_employee_id BIGINT;
+ALTER TABLE employee_department_assignments
+    ADD CONSTRAINT employee_department_assignments_changed_by_employee_id_fkey
+    FOREIGN KEY (changed_by_employee_id) REFERENCES employees(id) ON DELETE SET NULL;
@@ -0,0 +1,10 @@
+-- Reviews made by the account owner for an employee who has a manager stand
+-- in for that manager. Until now every review came from the owner, so the
+-- ones naming an approver are such overrides.
+ALTER TABLE leave_requests ADD COLUMN is_owner_override BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE leave_requests
+SET is_owner_override = TRUE
+WHERE
+    reviewed_at IS NOT NULL
+    AND approver_id IS NOT NULL;
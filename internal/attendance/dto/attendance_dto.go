@@ -0,0 +1,155 @@
+package dto
+
+import "time"
+
+type EventType string
+
+const (
+	EventTypeClockIn  EventType = "clock_in"
+	EventTypeClockOut EventType = "clock_out"
+)
+
+type DayStatus string
+
+const (
+	DayStatusPresent DayStatus = "present"
+	DayStatusAbsent  DayStatus = "absent"
+	DayStatusOnLeave DayStatus = "on_leave"
+	DayStatusHoliday DayStatus = "holiday"
+	DayStatusOff     DayStatus = "off"
+	// DayStatusPending is today before the end of the workday without a
+	// clock-in yet.
+	DayStatusPending DayStatus = "pending"
+)
+
+// OfficeLocation is a location with a geofence, which clock events are
+// matched against.
+type OfficeLocation struct {
+	OfficeLocationId string  `json:"officeLocationId"`
+	Name             string  `json:"name"`
+	Latitude         float64 `json:"latitude"`
+	Longitude        float64 `json:"longitude"`
+	RadiusMeters     int     `json:"radiusMeters"`
+}
+
+// WorkSchedule describes the working hours of an employee. WorkDays holds ISO
+// weekdays, 1 for Monday through 7 for Sunday.
+type WorkSchedule struct {
+	WorkScheduleId       string `json:"workScheduleId"`
+	Name                 string `json:"name"`
+	StartTime            string `json:"startTime"`
+	EndTime              string `json:"endTime"`
+	WorkDays             []int  `json:"workDays"`
+	LateToleranceMinutes int    `json:"lateToleranceMinutes"`
+	Timezone             string `json:"timezone"`
+	IsDefault            bool   `json:"isDefault"`
+}
+
+type CreateWorkSchedulePayload struct {
+	Name                 string `json:"name" validate:"required,min=1,max=255"`
+	StartTime            string `json:"startTime" validate:"required,datetime=15:04"`
+	EndTime              string `json:"endTime" validate:"required,datetime=15:04"`
+	WorkDays             []int  `json:"workDays" validate:"required,min=1,max=7,unique,dive,min=1,max=7"`
+	LateToleranceMinutes int    `json:"lateToleranceMinutes" validate:"min=0,max=240"`
+	Timezone             string `json:"timezone" validate:"required,timezone"`
+	IsDefault            bool   `json:"isDefault"`
+}
+
+type PatchWorkSchedulePayload struct {
+	Name                 *string `json:"name" validate:"omitempty,min=1,max=255"`
+	StartTime            *string `json:"startTime" validate:"omitempty,datetime=15:04"`
+	EndTime              *string `json:"endTime" validate:"omitempty,datetime=15:04"`
+	WorkDays             *[]int  `json:"workDays" validate:"omitempty,min=1,max=7,unique,dive,min=1,max=7"`
+	LateToleranceMinutes *int    `json:"lateToleranceMinutes" validate:"omitempty,min=0,max=240"`
+	Timezone             *string `json:"timezone" validate:"omitempty,timezone"`
+	IsDefault            *bool   `json:"isDefault"`
+}
+
+type WorkSchedulePathParam struct {
+	WorkScheduleId int `param:"workScheduleId" validate:"required,min=1"`
+}
+
+type AssignWorkSchedulePayload struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	WorkScheduleId *int   `json:"workScheduleId" validate:"omitempty,min=1"`
+}
+
+type AttendanceEvent struct {
+	AttendanceEventId string    `json:"attendanceEventId"`
+	EmployeeId        int64     `json:"-"`
+	Type              EventType `json:"type"`
+	OccurredAt        time.Time `json:"occurredAt"`
+	Latitude          *float64  `json:"latitude"`
+	Longitude         *float64  `json:"longitude"`
+	OfficeLocationId  string    `json:"officeLocationId"`
+	DistanceMeters    *float64  `json:"distanceMeters"`
+}
+
+type ClockPayload struct {
+	IdentityNumber   string   `param:"identityNumber" validate:"required"`
+	Latitude         *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude        *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+	OfficeLocationId int      `json:"-"`
+	DistanceMeters   *float64 `json:"-"`
+}
+
+type DailySummary struct {
+	Date              string            `json:"date"`
+	Status            DayStatus         `json:"status"`
+	ClockIn           *time.Time        `json:"clockIn"`
+	ClockOut          *time.Time        `json:"clockOut"`
+	WorkedMinutes     int               `json:"workedMinutes"`
+	IsLate            bool              `json:"isLate"`
+	LateMinutes       int               `json:"lateMinutes"`
+	IsEarlyLeave      bool              `json:"isEarlyLeave"`
+	EarlyLeaveMinutes int               `json:"earlyLeaveMinutes"`
+	Events            []AttendanceEvent `json:"events"`
+}
+
+type GetAttendanceParams struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	From           string `query:"from" validate:"required,datetime=2006-01-02"`
+	To             string `query:"to" validate:"required,datetime=2006-01-02"`
+}
+
+type EmployeeAttendanceReport struct {
+	IdentityNumber string `json:"identityNumber"`
+	Name           string `json:"name"`
+	WorkingDays    int    `json:"workingDays"`
+	Present        int    `json:"present"`
+	Late           int    `json:"late"`
+	EarlyLeave     int    `json:"earlyLeave"`
+	Absent         int    `json:"absent"`
+	OnLeave        int    `json:"onLeave"`
+	Holidays       int    `json:"holidays"`
+	WorkedMinutes  int    `json:"workedMinutes"`
+}
+
+type DepartmentAttendanceReport struct {
+	DepartmentId string                     `json:"departmentId"`
+	Month        string                     `json:"month"`
+	Employees    []EmployeeAttendanceReport `json:"employees"`
+}
+
+type GetDepartmentReportParams struct {
+	DepartmentId int    `param:"departmentId" validate:"required,min=1"`
+	Month        string `query:"month" validate:"required,datetime=2006-01"`
+}
+
+// AttendanceEmployee is an employee together with the id of the schedule
+// their attendance is measured against: their own, or else the default one of
+// the organization. WorkScheduleId is empty when there is neither.
+type AttendanceEmployee struct {
+	EmployeeId     int64
+	IdentityNumber string
+	Name           string
+	Status         string
+	WorkScheduleId string
+}
+
+// LeaveRange is an approved leave of an employee, both dates inclusive.
+type LeaveRange struct {
+	EmployeeId int64
+	StartDate  string
+	EndDate    string
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/attendance/dto"
+	"ps-gogo-manajer/internal/attendance/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type AttendanceHandler struct {
+	attendanceUsecase usecase.AttendanceUsecase
+	validator         *validator.Validate
+}
+
+func NewAttendanceHandler(attendanceUsecase usecase.AttendanceUsecase, validator *validator.Validate) *AttendanceHandler {
+	return &AttendanceHandler{
+		attendanceUsecase: attendanceUsecase,
+		validator:         validator,
+	}
+}
+
+// bind binds the request into payload and validates it, wrapping any failure
+// as a bad request.
+func (h AttendanceHandler) bind(ctx echo.Context, payload any) error {
+	if err := ctx.Bind(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+// bindPathParam binds only the path parameters, for routes whose body is
+// bound into a separate payload.
+func (h AttendanceHandler) bindPathParam(ctx echo.Context, pathParam any) error {
+	if err := (&echo.DefaultBinder{}).BindPathParams(ctx, pathParam); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (h AttendanceHandler) CreateWorkSchedule(ctx echo.Context) error {
+	var payload dto.CreateWorkSchedulePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	schedule, err := h.attendanceUsecase.CreateWorkSchedule(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, schedule)
+}
+
+func (h AttendanceHandler) GetListWorkSchedule(ctx echo.Context) error {
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	schedules, err := h.attendanceUsecase.GetListWorkSchedule(ctx.Request().Context(), userData.Id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, schedules)
+}
+
+func (h AttendanceHandler) UpdateWorkSchedule(ctx echo.Context) error {
+	var pathParam dto.WorkSchedulePathParam
+	if err := h.bindPathParam(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.PatchWorkSchedulePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	schedule, err := h.attendanceUsecase.UpdateWorkSchedule(ctx.Request().Context(), userData.Id, pathParam.WorkScheduleId, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, schedule)
+}
+
+func (h AttendanceHandler) DeleteWorkSchedule(ctx echo.Context) error {
+	var pathParam dto.WorkSchedulePathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	err := h.attendanceUsecase.DeleteWorkSchedule(ctx.Request().Context(), userData.Id, pathParam.WorkScheduleId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h AttendanceHandler) AssignWorkSchedule(ctx echo.Context) error {
+	var payload dto.AssignWorkSchedulePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	err := h.attendanceUsecase.AssignWorkSchedule(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "updated",
+	})
+}
+
+func (h AttendanceHandler) ClockIn(ctx echo.Context) error {
+	var payload dto.ClockPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	event, err := h.attendanceUsecase.ClockIn(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, event)
+}
+
+func (h AttendanceHandler) ClockOut(ctx echo.Context) error {
+	var payload dto.ClockPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	event, err := h.attendanceUsecase.ClockOut(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, event)
+}
+
+func (h AttendanceHandler) GetAttendance(ctx echo.Context) error {
+	var payload dto.GetAttendanceParams
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	summaries, err := h.attendanceUsecase.GetAttendance(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, summaries)
+}
+
+func (h AttendanceHandler) GetDepartmentReport(ctx echo.Context) error {
+	var payload dto.GetDepartmentReportParams
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	report, err := h.attendanceUsecase.GetDepartmentReport(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,620 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/attendance/dto"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// LastEventValidator checks a new clock event against the latest event of
+// the employee, read while the employee is locked. The event is nil when the
+// employee never clocked.
+type LastEventValidator func(lastEvent *dto.AttendanceEvent) error
+
+type AttendanceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAttendanceRepository(pool *pgxpool.Pool) *AttendanceRepository {
+	return &AttendanceRepository{pool: pool}
+}
+
+const (
+	officeLocationColumns = `
+		id,
+		name,
+		latitude,
+		longitude,
+		radius_meters`
+	workScheduleColumns = `
+		id,
+		name,
+		to_char(start_time, 'HH24:MI'),
+		to_char(end_time, 'HH24:MI'),
+		work_days,
+		late_tolerance_minutes,
+		timezone,
+		is_default`
+	attendanceEventColumns = `
+		id,
+		employee_id,
+		type,
+		occurred_at,
+		latitude,
+		longitude,
+		office_location_id,
+		distance_meters`
+	// attendanceEmployeeColumns is the column list scanned by
+	// scanAttendanceEmployee, selected from employees.
+	attendanceEmployeeColumns = `
+		employees.id,
+		employees.identity_number,
+		employees.name,
+		employees.status,
+		COALESCE(
+			employees.work_schedule_id,
+			(
+				SELECT id
+				FROM work_schedules
+				WHERE
+					user_id = employees.user_id
+					AND is_default
+			)
+		)`
+
+	queryCheckIfDepartmentExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM departments
+		WHERE
+			user_id = @userID
+			AND id = @departmentID
+			AND deleted_at IS NULL
+	) is_exists;`
+
+	// Office locations are the locations with a geofence.
+	queryGetListOfficeLocation = `
+	SELECT` + officeLocationColumns + `
+	FROM locations
+	WHERE
+		user_id = @userID
+		AND latitude IS NOT NULL
+	ORDER BY id;`
+
+	queryUnsetDefaultWorkSchedule = `
+	UPDATE work_schedules
+	SET is_default = FALSE
+	WHERE
+		user_id = @userID
+		AND is_default
+		AND id <> @workScheduleID;`
+	queryCreateWorkSchedule = `
+	INSERT INTO work_schedules(user_id, name, start_time, end_time, work_days, late_tolerance_minutes, timezone, is_default)
+	VALUES (@userID, @name, @startTime::time, @endTime::time, @workDays, @lateToleranceMinutes, @timezone, @isDefault)
+	RETURNING` + workScheduleColumns + `;`
+	queryGetListWorkSchedule = `
+	SELECT` + workScheduleColumns + `
+	FROM work_schedules
+	WHERE user_id = @userID
+	ORDER BY id;`
+	queryGetWorkSchedule = `
+	SELECT` + workScheduleColumns + `
+	FROM work_schedules
+	WHERE
+		user_id = @userID
+		AND id = @workScheduleID;`
+	queryUpdateWorkSchedule = `
+	UPDATE work_schedules
+	SET
+		name = COALESCE(@name, name),
+		start_time = COALESCE(@startTime::time, start_time),
+		end_time = COALESCE(@endTime::time, end_time),
+		work_days = COALESCE(@workDays, work_days),
+		late_tolerance_minutes = COALESCE(@lateToleranceMinutes, late_tolerance_minutes),
+		timezone = COALESCE(@timezone, timezone),
+		is_default = COALESCE(@isDefault, is_default)
+	WHERE
+		user_id = @userID
+		AND id = @workScheduleID
+	RETURNING` + workScheduleColumns + `;`
+	queryDeleteWorkSchedule = `
+	DELETE FROM work_schedules
+	WHERE
+		user_id = @userID
+		AND id = @workScheduleID;`
+	queryAssignWorkSchedule = `
+	UPDATE employees
+	SET work_schedule_id = @workScheduleID
+	WHERE
+		user_id = @userID
+		AND identity_number = @identityNumber
+		AND deleted_at IS NULL;`
+
+	queryGetAttendanceEmployee = `
+	SELECT` + attendanceEmployeeColumns + `
+	FROM employees
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL;`
+	queryGetDepartmentAttendanceEmployees = `
+	SELECT` + attendanceEmployeeColumns + `
+	FROM employees
+	WHERE
+		employees.user_id = @userID
+		AND employees.department_id = @departmentID
+		AND employees.deleted_at IS NULL
+		AND employees.status <> 'terminated'
+	ORDER BY employees.name;`
+
+	// Clock events of an employee are recorded one at a time, so that a
+	// double submit cannot clock in twice.
+	queryLockAttendanceEmployee = `
+	SELECT id
+	FROM employees
+	WHERE id = @employeeID
+	FOR UPDATE;`
+	queryGetLastAttendanceEvent = `
+	SELECT` + attendanceEventColumns + `
+	FROM attendance_events
+	WHERE employee_id = @employeeID
+	ORDER BY occurred_at DESC, id DESC
+	LIMIT 1;`
+	queryCreateAttendanceEvent = `
+	INSERT INTO attendance_events(employee_id, type, latitude, longitude, office_location_id, distance_meters)
+	VALUES (@employeeID, @type, @latitude, @longitude, NULLIF(@officeLocationID, 0), @distanceMeters)
+	RETURNING` + attendanceEventColumns + `;`
+	queryGetAttendanceEvents = `
+	SELECT` + attendanceEventColumns + `
+	FROM attendance_events
+	WHERE
+		employee_id = ANY(@employeeIDs)
+		AND occurred_at >= @from
+		AND occurred_at < @to
+	ORDER BY occurred_at, id;`
+
+	queryGetHolidayDates = `
+	SELECT date::text
+	FROM holidays
+	WHERE
+		user_id = @userID
+		AND date BETWEEN @from::date AND @to::date
+	ORDER BY date;`
+	queryGetApprovedLeave = `
+	SELECT employee_id, start_date::text, end_date::text
+	FROM leave_requests
+	WHERE
+		employee_id = ANY(@employeeIDs)
+		AND status = 'approved'
+		AND start_date <= @to::date
+		AND end_date >= @from::date;`
+)
+
+func scanOfficeLocation(row pgx.Row) (*dto.OfficeLocation, error) {
+	var location dto.OfficeLocation
+	err := row.Scan(
+		&location.OfficeLocationId,
+		&location.Name,
+		&location.Latitude,
+		&location.Longitude,
+		&location.RadiusMeters,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+func scanWorkSchedule(row pgx.Row) (*dto.WorkSchedule, error) {
+	var schedule dto.WorkSchedule
+	err := row.Scan(
+		&schedule.WorkScheduleId,
+		&schedule.Name,
+		&schedule.StartTime,
+		&schedule.EndTime,
+		&schedule.WorkDays,
+		&schedule.LateToleranceMinutes,
+		&schedule.Timezone,
+		&schedule.IsDefault,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func scanAttendanceEvent(row pgx.Row) (*dto.AttendanceEvent, error) {
+	var event dto.AttendanceEvent
+	officeLocationID := new(pgtype.Text)
+
+	err := row.Scan(
+		&event.AttendanceEventId,
+		&event.EmployeeId,
+		&event.Type,
+		&event.OccurredAt,
+		&event.Latitude,
+		&event.Longitude,
+		officeLocationID,
+		&event.DistanceMeters,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.OfficeLocationId = officeLocationID.String
+	return &event, nil
+}
+
+func scanAttendanceEmployee(row pgx.Row) (*dto.AttendanceEmployee, error) {
+	var employee dto.AttendanceEmployee
+	workScheduleID := new(pgtype.Text)
+
+	err := row.Scan(
+		&employee.EmployeeId,
+		&employee.IdentityNumber,
+		&employee.Name,
+		&employee.Status,
+		workScheduleID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	employee.WorkScheduleId = workScheduleID.String
+	return &employee, nil
+}
+
+func (r *AttendanceRepository) CheckIfDepartmentExists(ctx context.Context, userID int, departmentID int) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"departmentID": departmentID,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCheckIfDepartmentExists, args).Scan(&isExists); err != nil {
+		return false, errors.Wrap(err, "failed to check department")
+	}
+
+	return isExists, nil
+}
+
+func (r *AttendanceRepository) GetListOfficeLocation(ctx context.Context, userID int) ([]dto.OfficeLocation, error) {
+	locations := make([]dto.OfficeLocation, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListOfficeLocation, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list office location")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		location, err := scanOfficeLocation(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		locations = append(locations, *location)
+	}
+
+	return locations, nil
+}
+
+func (r *AttendanceRepository) CreateWorkSchedule(ctx context.Context, userID int, payload *dto.CreateWorkSchedulePayload) (*dto.WorkSchedule, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if payload.IsDefault {
+		args := pgx.NamedArgs{
+			"userID":         userID,
+			"workScheduleID": 0,
+		}
+		if _, err := tx.Exec(ctx, queryUnsetDefaultWorkSchedule, args); err != nil {
+			return nil, errors.Wrap(err, "failed to unset default work schedule")
+		}
+	}
+
+	args := pgx.NamedArgs{
+		"userID":               userID,
+		"name":                 payload.Name,
+		"startTime":            payload.StartTime,
+		"endTime":              payload.EndTime,
+		"workDays":             payload.WorkDays,
+		"lateToleranceMinutes": payload.LateToleranceMinutes,
+		"timezone":             payload.Timezone,
+		"isDefault":            payload.IsDefault,
+	}
+
+	schedule, err := scanWorkSchedule(tx.QueryRow(ctx, queryCreateWorkSchedule, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create work schedule")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return schedule, nil
+}
+
+func (r *AttendanceRepository) GetListWorkSchedule(ctx context.Context, userID int) ([]dto.WorkSchedule, error) {
+	schedules := make([]dto.WorkSchedule, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListWorkSchedule, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list work schedule")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		schedule, err := scanWorkSchedule(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		schedules = append(schedules, *schedule)
+	}
+
+	return schedules, nil
+}
+
+func (r *AttendanceRepository) GetWorkSchedule(ctx context.Context, userID int, workScheduleID int) (*dto.WorkSchedule, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"workScheduleID": workScheduleID,
+	}
+
+	schedule, err := scanWorkSchedule(r.pool.QueryRow(ctx, queryGetWorkSchedule, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get work schedule")
+	}
+
+	return schedule, nil
+}
+
+func (r *AttendanceRepository) UpdateWorkSchedule(ctx context.Context, userID int, workScheduleID int, payload *dto.PatchWorkSchedulePayload) (*dto.WorkSchedule, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if payload.IsDefault != nil && *payload.IsDefault {
+		args := pgx.NamedArgs{
+			"userID":         userID,
+			"workScheduleID": workScheduleID,
+		}
+		if _, err := tx.Exec(ctx, queryUnsetDefaultWorkSchedule, args); err != nil {
+			return nil, errors.Wrap(err, "failed to unset default work schedule")
+		}
+	}
+
+	args := pgx.NamedArgs{
+		"userID":               userID,
+		"workScheduleID":       workScheduleID,
+		"name":                 payload.Name,
+		"startTime":            payload.StartTime,
+		"endTime":              payload.EndTime,
+		"workDays":             payload.WorkDays,
+		"lateToleranceMinutes": payload.LateToleranceMinutes,
+		"timezone":             payload.Timezone,
+		"isDefault":            payload.IsDefault,
+	}
+
+	schedule, err := scanWorkSchedule(tx.QueryRow(ctx, queryUpdateWorkSchedule, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update work schedule")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return schedule, nil
+}
+
+func (r *AttendanceRepository) DeleteWorkSchedule(ctx context.Context, userID int, workScheduleID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"workScheduleID": workScheduleID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeleteWorkSchedule, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete work schedule")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// AssignWorkSchedule sets the schedule of an employee, a nil schedule puts
+// them back on the default one. It reports whether the employee exists.
+func (r *AttendanceRepository) AssignWorkSchedule(ctx context.Context, userID int, identityNumber string, workScheduleID *int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+		"workScheduleID": workScheduleID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryAssignWorkSchedule, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to assign work schedule")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *AttendanceRepository) GetAttendanceEmployee(ctx context.Context, userID int, identityNumber string) (*dto.AttendanceEmployee, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+
+	employee, err := scanAttendanceEmployee(r.pool.QueryRow(ctx, queryGetAttendanceEmployee, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get employee")
+	}
+
+	return employee, nil
+}
+
+// GetDepartmentAttendanceEmployees returns the employees of a department who
+// have not been terminated.
+func (r *AttendanceRepository) GetDepartmentAttendanceEmployees(ctx context.Context, userID int, departmentID int) ([]dto.AttendanceEmployee, error) {
+	employees := make([]dto.AttendanceEmployee, 0)
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"departmentID": departmentID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetDepartmentAttendanceEmployees, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get department employees")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		employee, err := scanAttendanceEmployee(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		employees = append(employees, *employee)
+	}
+
+	return employees, nil
+}
+
+// CreateAttendanceEvent records the event once validate accepts the latest
+// event of the employee, both under the employee lock.
+func (r *AttendanceRepository) CreateAttendanceEvent(ctx context.Context, employeeID int64, eventType dto.EventType, payload *dto.ClockPayload, validate LastEventValidator) (*dto.AttendanceEvent, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"employeeID":       employeeID,
+		"type":             eventType,
+		"latitude":         payload.Latitude,
+		"longitude":        payload.Longitude,
+		"officeLocationID": payload.OfficeLocationId,
+		"distanceMeters":   payload.DistanceMeters,
+	}
+
+	if _, err := tx.Exec(ctx, queryLockAttendanceEmployee, args); err != nil {
+		return nil, errors.Wrap(err, "failed to lock employee")
+	}
+
+	lastEvent, err := scanAttendanceEvent(tx.QueryRow(ctx, queryGetLastAttendanceEvent, args))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.Wrap(err, "failed to get last attendance event")
+	}
+
+	if err := validate(lastEvent); err != nil {
+		return nil, err
+	}
+
+	event, err := scanAttendanceEvent(tx.QueryRow(ctx, queryCreateAttendanceEvent, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create attendance event")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit attendance event")
+	}
+
+	return event, nil
+}
+
+// GetAttendanceEvents returns the events of the given employees that occurred
+// in [from, to), oldest first.
+func (r *AttendanceRepository) GetAttendanceEvents(ctx context.Context, employeeIDs []int64, from time.Time, to time.Time) ([]dto.AttendanceEvent, error) {
+	events := make([]dto.AttendanceEvent, 0)
+	args := pgx.NamedArgs{
+		"employeeIDs": employeeIDs,
+		"from":        from,
+		"to":          to,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetAttendanceEvents, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attendance events")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		event, err := scanAttendanceEvent(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		events = append(events, *event)
+	}
+
+	return events, nil
+}
+
+func (r *AttendanceRepository) GetHolidayDates(ctx context.Context, userID int, from string, to string) ([]string, error) {
+	dates := make([]string, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"from":   from,
+		"to":     to,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetHolidayDates, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get holidays")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		dates = append(dates, date)
+	}
+
+	return dates, nil
+}
+
+// GetApprovedLeave returns the approved leave of the given employees that
+// overlaps the dates from and to, both inclusive.
+func (r *AttendanceRepository) GetApprovedLeave(ctx context.Context, employeeIDs []int64, from string, to string) ([]dto.LeaveRange, error) {
+	leaves := make([]dto.LeaveRange, 0)
+	args := pgx.NamedArgs{
+		"employeeIDs": employeeIDs,
+		"from":        from,
+		"to":          to,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetApprovedLeave, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get approved leave")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var leave dto.LeaveRange
+		if err := rows.Scan(&leave.EmployeeId, &leave.StartDate, &leave.EndDate); err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		leaves = append(leaves, leave)
+	}
+
+	return leaves, nil
+}
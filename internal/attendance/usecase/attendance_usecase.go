@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"ps-gogo-manajer/internal/attendance/dto"
+	"ps-gogo-manajer/internal/attendance/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DATE_LAYOUT  = "2006-01-02"
+	MONTH_LAYOUT = "2006-01"
+
+	// MAX_RANGE_DAYS bounds the range of the attendance summaries.
+	MAX_RANGE_DAYS = 92
+
+	// MAX_SHIFT_DURATION is how long a clock-in stays open. An employee who
+	// forgot to clock out can clock in again afterwards.
+	MAX_SHIFT_DURATION = 24 * time.Hour
+
+	EARTH_RADIUS_METERS = 6371000
+)
+
+// defaultWorkSchedule is used for organizations without a default schedule.
+var defaultWorkSchedule = dto.WorkSchedule{
+	Name:      "Default",
+	StartTime: "08:00",
+	EndTime:   "17:00",
+	WorkDays:  []int{1, 2, 3, 4, 5},
+	Timezone:  "Asia/Jakarta",
+}
+
+type AttendanceUsecase struct {
+	attendanceRepo repository.AttendanceRepository
+}
+
+func NewAttendanceUsecase(attendanceRepo repository.AttendanceRepository) *AttendanceUsecase {
+	return &AttendanceUsecase{
+		attendanceRepo: attendanceRepo,
+	}
+}
+
+func (u *AttendanceUsecase) CreateWorkSchedule(ctx context.Context, userID int, payload *dto.CreateWorkSchedulePayload) (*dto.WorkSchedule, error) {
+	if payload.StartTime >= payload.EndTime {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "startTime must be before endTime")
+	}
+
+	return u.attendanceRepo.CreateWorkSchedule(ctx, userID, payload)
+}
+
+func (u *AttendanceUsecase) GetListWorkSchedule(ctx context.Context, userID int) ([]dto.WorkSchedule, error) {
+	return u.attendanceRepo.GetListWorkSchedule(ctx, userID)
+}
+
+func (u *AttendanceUsecase) UpdateWorkSchedule(ctx context.Context, userID int, workScheduleID int, payload *dto.PatchWorkSchedulePayload) (*dto.WorkSchedule, error) {
+	schedule, err := u.getWorkSchedule(ctx, userID, workScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime, endTime := schedule.StartTime, schedule.EndTime
+	if payload.StartTime != nil {
+		startTime = *payload.StartTime
+	}
+	if payload.EndTime != nil {
+		endTime = *payload.EndTime
+	}
+
+	if startTime >= endTime {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "startTime must be before endTime")
+	}
+
+	return u.attendanceRepo.UpdateWorkSchedule(ctx, userID, workScheduleID, payload)
+}
+
+func (u *AttendanceUsecase) DeleteWorkSchedule(ctx context.Context, userID int, workScheduleID int) error {
+	isDeleted, err := u.attendanceRepo.DeleteWorkSchedule(ctx, userID, workScheduleID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return errors.Wrap(customErrors.ErrNotFound, "work schedule not found")
+	}
+
+	return nil
+}
+
+func (u *AttendanceUsecase) AssignWorkSchedule(ctx context.Context, userID int, payload *dto.AssignWorkSchedulePayload) error {
+	if payload.WorkScheduleId != nil {
+		if _, err := u.getWorkSchedule(ctx, userID, *payload.WorkScheduleId); err != nil {
+			return err
+		}
+	}
+
+	isUpdated, err := u.attendanceRepo.AssignWorkSchedule(ctx, userID, payload.IdentityNumber, payload.WorkScheduleId)
+	if err != nil {
+		return err
+	}
+
+	if !isUpdated {
+		return errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	return nil
+}
+
+func (u *AttendanceUsecase) ClockIn(ctx context.Context, userID int, payload *dto.ClockPayload) (*dto.AttendanceEvent, error) {
+	return u.clock(ctx, userID, dto.EventTypeClockIn, payload)
+}
+
+func (u *AttendanceUsecase) ClockOut(ctx context.Context, userID int, payload *dto.ClockPayload) (*dto.AttendanceEvent, error) {
+	return u.clock(ctx, userID, dto.EventTypeClockOut, payload)
+}
+
+func (u *AttendanceUsecase) clock(ctx context.Context, userID int, eventType dto.EventType, payload *dto.ClockPayload) (*dto.AttendanceEvent, error) {
+	employee, err := u.getAttendanceEmployee(ctx, userID, payload.IdentityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if employee.Status == "terminated" {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "employee is terminated")
+	}
+
+	if err := u.checkGeofence(ctx, userID, payload); err != nil {
+		return nil, err
+	}
+
+	return u.attendanceRepo.CreateAttendanceEvent(ctx, employee.EmployeeId, eventType, payload, func(lastEvent *dto.AttendanceEvent) error {
+		isClockedIn := lastEvent != nil &&
+			lastEvent.Type == dto.EventTypeClockIn &&
+			time.Since(lastEvent.OccurredAt) < MAX_SHIFT_DURATION
+		if eventType == dto.EventTypeClockIn && isClockedIn {
+			return errors.Wrap(customErrors.ErrConflict, "employee is already clocked in")
+		}
+		if eventType == dto.EventTypeClockOut && !isClockedIn {
+			return errors.Wrap(customErrors.ErrConflict, "employee is not clocked in")
+		}
+		return nil
+	})
+}
+
+// checkGeofence matches the coordinates of a clock event with the closest
+// location whose geofence contains them, and rejects them when no geofence
+// does. Events without coordinates, or in organizations without a geofence,
+// are accepted as they are.
+func (u *AttendanceUsecase) checkGeofence(ctx context.Context, userID int, payload *dto.ClockPayload) error {
+	if payload.Latitude == nil || payload.Longitude == nil {
+		return nil
+	}
+
+	locations, err := u.attendanceRepo.GetListOfficeLocation(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(locations) == 0 {
+		return nil
+	}
+
+	// * Geofences can differ in size, so the nearest location is not always the one containing the point
+	var nearest, matched *dto.OfficeLocation
+	nearestDistance, matchedDistance := math.Inf(1), math.Inf(1)
+	for i := range locations {
+		distance := haversineDistance(*payload.Latitude, *payload.Longitude, locations[i].Latitude, locations[i].Longitude)
+		if distance < nearestDistance {
+			nearest, nearestDistance = &locations[i], distance
+		}
+		if distance <= float64(locations[i].RadiusMeters) && distance < matchedDistance {
+			matched, matchedDistance = &locations[i], distance
+		}
+	}
+
+	if matched == nil {
+		return errors.Wrapf(customErrors.ErrBadRequest, "outside of every office location, nearest is %s at %.0f meters", nearest.Name, nearestDistance)
+	}
+
+	payload.OfficeLocationId, _ = strconv.Atoi(matched.OfficeLocationId)
+	payload.DistanceMeters = &matchedDistance
+	return nil
+}
+
+// haversineDistance returns the great-circle distance in meters between two
+// coordinates.
+func haversineDistance(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * EARTH_RADIUS_METERS * math.Asin(math.Sqrt(a))
+}
+
+func (u *AttendanceUsecase) GetAttendance(ctx context.Context, userID int, params *dto.GetAttendanceParams) ([]dto.DailySummary, error) {
+	from, _ := time.Parse(DATE_LAYOUT, params.From)
+	to, _ := time.Parse(DATE_LAYOUT, params.To)
+	if to.Before(from) {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "to is before from")
+	}
+
+	if to.Sub(from) > MAX_RANGE_DAYS*24*time.Hour {
+		return nil, errors.Wrapf(customErrors.ErrBadRequest, "attendance range is limited to %d days", MAX_RANGE_DAYS)
+	}
+
+	employee, err := u.getAttendanceEmployee(ctx, userID, params.IdentityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := u.getSchedules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := u.summarize(ctx, userID, []dto.AttendanceEmployee{*employee}, schedules, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return summaries[employee.EmployeeId], nil
+}
+
+func (u *AttendanceUsecase) GetDepartmentReport(ctx context.Context, userID int, params *dto.GetDepartmentReportParams) (*dto.DepartmentAttendanceReport, error) {
+	from, _ := time.Parse(MONTH_LAYOUT, params.Month)
+	to := from.AddDate(0, 1, -1)
+
+	isDepartmentExists, err := u.attendanceRepo.CheckIfDepartmentExists(ctx, userID, params.DepartmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDepartmentExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "department not found")
+	}
+
+	employees, err := u.attendanceRepo.GetDepartmentAttendanceEmployees(ctx, userID, params.DepartmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := u.getSchedules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := u.summarize(ctx, userID, employees, schedules, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := dto.DepartmentAttendanceReport{
+		DepartmentId: strconv.Itoa(params.DepartmentId),
+		Month:        params.Month,
+		Employees:    make([]dto.EmployeeAttendanceReport, 0, len(employees)),
+	}
+	for _, employee := range employees {
+		report.Employees = append(report.Employees, tally(employee, summaries[employee.EmployeeId]))
+	}
+
+	return &report, nil
+}
+
+func tally(employee dto.AttendanceEmployee, summaries []dto.DailySummary) dto.EmployeeAttendanceReport {
+	report := dto.EmployeeAttendanceReport{
+		IdentityNumber: employee.IdentityNumber,
+		Name:           employee.Name,
+	}
+
+	for _, summary := range summaries {
+		report.WorkedMinutes += summary.WorkedMinutes
+		switch summary.Status {
+		case dto.DayStatusPresent:
+			report.WorkingDays++
+			report.Present++
+			if summary.IsLate {
+				report.Late++
+			}
+			if summary.IsEarlyLeave {
+				report.EarlyLeave++
+			}
+		case dto.DayStatusAbsent:
+			report.WorkingDays++
+			report.Absent++
+		case dto.DayStatusPending:
+			report.WorkingDays++
+		case dto.DayStatusOnLeave:
+			report.OnLeave++
+		case dto.DayStatusHoliday:
+			report.Holidays++
+		}
+	}
+
+	return report
+}
+
+// getSchedules returns the work schedules of the organization by id.
+func (u *AttendanceUsecase) getSchedules(ctx context.Context, userID int) (map[string]dto.WorkSchedule, error) {
+	schedules, err := u.attendanceRepo.GetListWorkSchedule(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedulesByID := make(map[string]dto.WorkSchedule, len(schedules))
+	for _, schedule := range schedules {
+		schedulesByID[schedule.WorkScheduleId] = schedule
+	}
+
+	return schedulesByID, nil
+}
+
+func (u *AttendanceUsecase) getWorkSchedule(ctx context.Context, userID int, workScheduleID int) (*dto.WorkSchedule, error) {
+	schedule, err := u.attendanceRepo.GetWorkSchedule(ctx, userID, workScheduleID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "work schedule not found")
+		}
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (u *AttendanceUsecase) getAttendanceEmployee(ctx context.Context, userID int, identityNumber string) (*dto.AttendanceEmployee, error) {
+	employee, err := u.attendanceRepo.GetAttendanceEmployee(ctx, userID, identityNumber)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+		}
+		return nil, err
+	}
+
+	return employee, nil
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/attendance/dto"
+	"time"
+	// Schedules carry IANA timezones, which must resolve without a system
+	// zoneinfo database in the container.
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+)
+
+// workDay is one day of a work schedule in the schedule's timezone.
+type workDay struct {
+	start, end time.Time
+	tolerance  time.Duration
+	isWorkDay  bool
+}
+
+func newWorkDay(schedule dto.WorkSchedule, location *time.Location, date time.Time) workDay {
+	at := func(clock string) time.Time {
+		t, _ := time.Parse("15:04", clock)
+		return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, location)
+	}
+
+	isoWeekday := int(date.Weekday())
+	if isoWeekday == 0 {
+		isoWeekday = 7
+	}
+
+	day := workDay{
+		start:     at(schedule.StartTime),
+		end:       at(schedule.EndTime),
+		tolerance: time.Duration(schedule.LateToleranceMinutes) * time.Minute,
+	}
+	for _, weekday := range schedule.WorkDays {
+		if weekday == isoWeekday {
+			day.isWorkDay = true
+		}
+	}
+
+	return day
+}
+
+// summarize computes the daily summaries of every employee from from to to,
+// both inclusive, stopping at today in the timezone of their schedule.
+func (u *AttendanceUsecase) summarize(ctx context.Context, userID int, employees []dto.AttendanceEmployee, schedules map[string]dto.WorkSchedule, from time.Time, to time.Time) (map[int64][]dto.DailySummary, error) {
+	summaries := make(map[int64][]dto.DailySummary, len(employees))
+	if len(employees) == 0 {
+		return summaries, nil
+	}
+
+	employeeIDs := make([]int64, 0, len(employees))
+	for _, employee := range employees {
+		employeeIDs = append(employeeIDs, employee.EmployeeId)
+	}
+
+	fromDate, toDate := from.Format(DATE_LAYOUT), to.Format(DATE_LAYOUT)
+	holidays, err := u.attendanceRepo.GetHolidayDates(ctx, userID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	isHoliday := make(map[string]bool, len(holidays))
+	for _, date := range holidays {
+		isHoliday[date] = true
+	}
+
+	leaves, err := u.attendanceRepo.GetApprovedLeave(ctx, employeeIDs, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	isOnLeave := make(map[int64]map[string]bool)
+	for _, leave := range leaves {
+		if isOnLeave[leave.EmployeeId] == nil {
+			isOnLeave[leave.EmployeeId] = make(map[string]bool)
+		}
+		startDate, _ := time.Parse(DATE_LAYOUT, leave.StartDate)
+		endDate, _ := time.Parse(DATE_LAYOUT, leave.EndDate)
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			isOnLeave[leave.EmployeeId][date.Format(DATE_LAYOUT)] = true
+		}
+	}
+
+	// Widened by a day on both sides so every timezone's days are covered,
+	// events are bucketed into days per employee below.
+	events, err := u.attendanceRepo.GetAttendanceEvents(ctx, employeeIDs, from.AddDate(0, 0, -1), to.AddDate(0, 0, 2))
+	if err != nil {
+		return nil, err
+	}
+
+	eventsByEmployee := make(map[int64][]dto.AttendanceEvent, len(employees))
+	for _, event := range events {
+		eventsByEmployee[event.EmployeeId] = append(eventsByEmployee[event.EmployeeId], event)
+	}
+
+	now := time.Now()
+	for _, employee := range employees {
+		schedule, ok := schedules[employee.WorkScheduleId]
+		if !ok {
+			schedule = defaultWorkSchedule
+		}
+
+		location, err := time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load timezone %s", schedule.Timezone)
+		}
+
+		today := now.In(location).Format(DATE_LAYOUT)
+		employeeSummaries := make([]dto.DailySummary, 0)
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			summary := dto.DailySummary{Date: date.Format(DATE_LAYOUT)}
+			if summary.Date > today {
+				break
+			}
+
+			day := newWorkDay(schedule, location, date)
+			summary.Events = dayEvents(eventsByEmployee[employee.EmployeeId], day.start)
+			summarizeEvents(&summary)
+
+			switch {
+			case isHoliday[summary.Date]:
+				summary.Status = dto.DayStatusHoliday
+			case isOnLeave[employee.EmployeeId][summary.Date]:
+				summary.Status = dto.DayStatusOnLeave
+			case !day.isWorkDay:
+				summary.Status = dto.DayStatusOff
+			case summary.ClockIn != nil:
+				summary.Status = dto.DayStatusPresent
+				measure(&summary, day)
+			case summary.Date == today && now.Before(day.end):
+				summary.Status = dto.DayStatusPending
+			default:
+				summary.Status = dto.DayStatusAbsent
+			}
+
+			employeeSummaries = append(employeeSummaries, summary)
+		}
+
+		summaries[employee.EmployeeId] = employeeSummaries
+	}
+
+	return summaries, nil
+}
+
+// dayEvents returns the events that occurred on the local day of dayTime.
+func dayEvents(events []dto.AttendanceEvent, dayTime time.Time) []dto.AttendanceEvent {
+	dayStart := time.Date(dayTime.Year(), dayTime.Month(), dayTime.Day(), 0, 0, 0, 0, dayTime.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	result := make([]dto.AttendanceEvent, 0)
+	for _, event := range events {
+		if !event.OccurredAt.Before(dayStart) && event.OccurredAt.Before(dayEnd) {
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
+
+// summarizeEvents sets the first clock-in, the last clock-out and the minutes
+// worked between matching clock-ins and clock-outs of the day.
+func summarizeEvents(summary *dto.DailySummary) {
+	var openedAt *time.Time
+	for i := range summary.Events {
+		event := &summary.Events[i]
+		switch event.Type {
+		case dto.EventTypeClockIn:
+			if summary.ClockIn == nil {
+				summary.ClockIn = &event.OccurredAt
+			}
+			if openedAt == nil {
+				openedAt = &event.OccurredAt
+			}
+		case dto.EventTypeClockOut:
+			summary.ClockOut = &event.OccurredAt
+			if openedAt != nil {
+				summary.WorkedMinutes += int(event.OccurredAt.Sub(*openedAt).Minutes())
+				openedAt = nil
+			}
+		}
+	}
+
+	// A clock-in after the last clock-out means the employee is still at work.
+	if openedAt != nil {
+		summary.ClockOut = nil
+	}
+}
+
+// measure flags a present day as late or early leave against the schedule.
+// Lateness is counted from the start of the day once past the tolerance.
+func measure(summary *dto.DailySummary, day workDay) {
+	if summary.ClockIn.After(day.start.Add(day.tolerance)) {
+		summary.IsLate = true
+		summary.LateMinutes = int(summary.ClockIn.Sub(day.start).Minutes())
+	}
+
+	if summary.ClockOut != nil && summary.ClockOut.Before(day.end) {
+		summary.IsEarlyLeave = true
+		summary.EarlyLeaveMinutes = int(day.end.Sub(*summary.ClockOut).Minutes())
+	}
+}
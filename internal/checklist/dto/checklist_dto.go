@@ -0,0 +1,97 @@
+package dto
+
+import "time"
+
+type ChecklistType string
+
+const (
+	ChecklistTypeOnboarding  ChecklistType = "onboarding"
+	ChecklistTypeOffboarding ChecklistType = "offboarding"
+)
+
+type TemplateTask struct {
+	TaskId        string `json:"taskId"`
+	Title         string `json:"title"`
+	AssigneeRole  string `json:"assigneeRole"`
+	DueOffsetDays int    `json:"dueOffsetDays"`
+}
+
+type ChecklistTemplate struct {
+	TemplateId string         `json:"templateId"`
+	Name       string         `json:"name"`
+	Type       ChecklistType  `json:"type"`
+	Tasks      []TemplateTask `json:"tasks"`
+}
+
+// TemplateTaskPayload describes a task of a template. DueOffsetDays counts
+// from the hire date for onboarding and from the termination effective date
+// for offboarding, and may be negative.
+type TemplateTaskPayload struct {
+	Title         string `json:"title" validate:"required,max=255"`
+	AssigneeRole  string `json:"assigneeRole" validate:"required,max=64"`
+	DueOffsetDays int    `json:"dueOffsetDays" validate:"min=-365,max=365"`
+}
+
+type CreateTemplatePayload struct {
+	Name  string                `json:"name" validate:"required,min=1,max=255"`
+	Type  ChecklistType         `json:"type" validate:"required,oneof=onboarding offboarding"`
+	Tasks []TemplateTaskPayload `json:"tasks" validate:"required,min=1,dive"`
+}
+
+// PatchTemplatePayload replaces the whole task list when Tasks is set. Only
+// checklists instantiated afterwards see the change.
+type PatchTemplatePayload struct {
+	Name  *string                `json:"name" validate:"omitempty,min=1,max=255"`
+	Tasks *[]TemplateTaskPayload `json:"tasks" validate:"omitempty,min=1,dive"`
+}
+
+type TemplatePathParam struct {
+	TemplateId int `param:"templateId" validate:"required,min=1"`
+}
+
+type ChecklistTask struct {
+	TaskId       string     `json:"taskId"`
+	Title        string     `json:"title"`
+	AssigneeRole string     `json:"assigneeRole"`
+	DueDate      string     `json:"dueDate"`
+	CompletedAt  *time.Time `json:"completedAt"`
+	CompletedBy  string     `json:"completedBy"`
+	IsOverdue    bool       `json:"isOverdue"`
+}
+
+type ChecklistProgress struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Overdue   int `json:"overdue"`
+}
+
+type EmployeeChecklist struct {
+	ChecklistId string            `json:"checklistId"`
+	Name        string            `json:"name"`
+	Type        ChecklistType     `json:"type"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	Progress    ChecklistProgress `json:"progress"`
+	Tasks       []ChecklistTask   `json:"tasks"`
+}
+
+type CompleteTaskPathParam struct {
+	ChecklistId int `param:"checklistId" validate:"required,min=1"`
+	TaskId      int `param:"taskId" validate:"required,min=1"`
+}
+
+type OverdueTask struct {
+	ChecklistTask
+	ChecklistId            string        `json:"checklistId"`
+	ChecklistName          string        `json:"checklistName"`
+	Type                   ChecklistType `json:"type"`
+	EmployeeIdentityNumber string        `json:"employeeIdentityNumber"`
+	EmployeeName           string        `json:"employeeName"`
+	DaysOverdue            int           `json:"daysOverdue"`
+}
+
+type GetOverdueTaskParams struct {
+	AssigneeRole string `query:"assigneeRole" validate:"omitempty,max=64"`
+	Type         string `query:"type" validate:"omitempty,oneof=onboarding offboarding"`
+	Limit        int
+	Offset       int
+}
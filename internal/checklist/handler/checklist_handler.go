@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/checklist/dto"
+	"ps-gogo-manajer/internal/checklist/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	customValidators "ps-gogo-manajer/pkg/custom-validators"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type ChecklistHandler struct {
+	checklistUsecase usecase.ChecklistUsecase
+	validator        *validator.Validate
+}
+
+const (
+	DEFAULT_LIMIT  = 5
+	DEFAULT_OFFSET = 0
+)
+
+func NewChecklistHandler(checklistUsecase usecase.ChecklistUsecase, validator *validator.Validate) *ChecklistHandler {
+	return &ChecklistHandler{
+		checklistUsecase: checklistUsecase,
+		validator:        validator,
+	}
+}
+
+func (h ChecklistHandler) CreateTemplate(ctx echo.Context) error {
+	var payload dto.CreateTemplatePayload
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	template, err := h.checklistUsecase.CreateTemplate(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, template)
+}
+
+func (h ChecklistHandler) GetListTemplate(ctx echo.Context) error {
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	templates, err := h.checklistUsecase.GetListTemplate(ctx.Request().Context(), userData.Id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, templates)
+}
+
+func (h ChecklistHandler) UpdateTemplate(ctx echo.Context) error {
+	var pathParam dto.TemplatePathParam
+	if err := (&echo.DefaultBinder{}).BindPathParams(ctx, &pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.PatchTemplatePayload
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	template, err := h.checklistUsecase.UpdateTemplate(ctx.Request().Context(), userData.Id, pathParam.TemplateId, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, template)
+}
+
+func (h ChecklistHandler) DeleteTemplate(ctx echo.Context) error {
+	var pathParam dto.TemplatePathParam
+	if err := ctx.Bind(&pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	err := h.checklistUsecase.DeleteTemplate(ctx.Request().Context(), userData.Id, pathParam.TemplateId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h ChecklistHandler) GetEmployeeChecklists(ctx echo.Context) error {
+	identityNumber := ctx.Param("identityNumber")
+	if identityNumber == "" {
+		err := errors.Wrap(customErrors.ErrBadRequest, "identity number required")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	checklists, err := h.checklistUsecase.GetEmployeeChecklists(ctx.Request().Context(), userData.Id, identityNumber)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, checklists)
+}
+
+func (h ChecklistHandler) CompleteTask(ctx echo.Context) error {
+	var pathParam dto.CompleteTaskPathParam
+	if err := ctx.Bind(&pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	task, err := h.checklistUsecase.CompleteTask(ctx.Request().Context(), userData.Id, pathParam.ChecklistId, pathParam.TaskId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, task)
+}
+
+func (h ChecklistHandler) GetOverdueTasks(ctx echo.Context) error {
+	limitStr := ctx.QueryParam("limit")
+	offsetStr := ctx.QueryParam("offset")
+
+	payload := dto.GetOverdueTaskParams{
+		Limit:  customValidators.ParseLimitOffset(limitStr, DEFAULT_LIMIT),
+		Offset: customValidators.ParseLimitOffset(offsetStr, DEFAULT_OFFSET),
+	}
+
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	tasks, err := h.checklistUsecase.GetOverdueTasks(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, tasks)
+}
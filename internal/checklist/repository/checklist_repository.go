@@ -0,0 +1,495 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/checklist/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type ChecklistRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewChecklistRepository(pool *pgxpool.Pool) *ChecklistRepository {
+	return &ChecklistRepository{pool: pool}
+}
+
+const (
+	checklistTaskColumns = `
+		employee_checklist_tasks.id,
+		employee_checklist_tasks.title,
+		employee_checklist_tasks.assignee_role,
+		employee_checklist_tasks.due_date::text,
+		employee_checklist_tasks.completed_at,
+		users.email,
+		employee_checklist_tasks.completed_at IS NULL
+			AND employee_checklist_tasks.due_date < CURRENT_DATE`
+
+	queryCheckIfEmployeeExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+	) is_exists;`
+	queryCreateTemplate = `
+	INSERT INTO checklist_templates(user_id, name, type)
+	VALUES (@userID, @name, @type)
+	RETURNING id;`
+	queryInsertTemplateTask = `
+	INSERT INTO checklist_template_tasks(template_id, title, assignee_role, due_offset_days, position)
+	VALUES (@templateID, @title, @assigneeRole, @dueOffsetDays, @position);`
+	queryDeleteTemplateTasks = `
+	DELETE FROM checklist_template_tasks
+	WHERE template_id = @templateID;`
+	queryGetListTemplate = `
+	SELECT
+		checklist_templates.id,
+		checklist_templates.name,
+		checklist_templates.type,
+		checklist_template_tasks.id,
+		checklist_template_tasks.title,
+		checklist_template_tasks.assignee_role,
+		checklist_template_tasks.due_offset_days
+	FROM checklist_templates
+	LEFT JOIN checklist_template_tasks ON checklist_template_tasks.template_id = checklist_templates.id
+	WHERE
+		checklist_templates.user_id = @userID
+		AND (NULLIF(@templateID, 0) IS NULL OR checklist_templates.id = NULLIF(@templateID, 0)::bigint)
+	ORDER BY checklist_templates.id, checklist_template_tasks.position;`
+	queryUpdateTemplate = `
+	UPDATE checklist_templates
+	SET name = COALESCE(@name, name)
+	WHERE
+		user_id = @userID
+		AND id = @templateID
+	RETURNING id;`
+	queryDeleteTemplate = `
+	DELETE FROM checklist_templates
+	WHERE
+		user_id = @userID
+		AND id = @templateID;`
+	// Every template of the given type becomes a checklist of its own, with
+	// due dates counted from the anchor date.
+	queryInstantiateChecklists = `
+	WITH
+	employee AS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+	),
+	checklists AS (
+		INSERT INTO employee_checklists(employee_id, template_id, name, type)
+		SELECT employee.id, checklist_templates.id, checklist_templates.name, checklist_templates.type
+		FROM checklist_templates
+		CROSS JOIN employee
+		WHERE
+			checklist_templates.user_id = @userID
+			AND checklist_templates.type = @type::enum_checklist_type
+		RETURNING id, template_id
+	)
+	INSERT INTO employee_checklist_tasks(checklist_id, title, assignee_role, due_date, position)
+	SELECT
+		checklists.id,
+		checklist_template_tasks.title,
+		checklist_template_tasks.assignee_role,
+		@anchorDate::date + checklist_template_tasks.due_offset_days,
+		checklist_template_tasks.position
+	FROM checklists
+	JOIN checklist_template_tasks ON checklist_template_tasks.template_id = checklists.template_id;`
+	queryGetEmployeeChecklists = `
+	SELECT
+		employee_checklists.id,
+		employee_checklists.name,
+		employee_checklists.type,
+		employee_checklists.created_at,` + checklistTaskColumns + `
+	FROM employee_checklists
+	JOIN employees ON employees.id = employee_checklists.employee_id
+	JOIN employee_checklist_tasks ON employee_checklist_tasks.checklist_id = employee_checklists.id
+	LEFT JOIN users ON users.id = employee_checklist_tasks.completed_by
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL
+	ORDER BY employee_checklists.created_at DESC, employee_checklists.id DESC, employee_checklist_tasks.position;`
+	queryCompleteTask = `
+	WITH completed AS (
+		UPDATE employee_checklist_tasks
+		SET
+			completed_at = COALESCE(completed_at, NOW()),
+			completed_by = COALESCE(completed_by, @completedBy)
+		FROM employee_checklists
+		JOIN employees ON employees.id = employee_checklists.employee_id
+		WHERE
+			employee_checklist_tasks.id = @taskID
+			AND employee_checklist_tasks.checklist_id = @checklistID
+			AND employee_checklists.id = employee_checklist_tasks.checklist_id
+			AND employees.user_id = @userID
+			AND employees.deleted_at IS NULL
+		RETURNING employee_checklist_tasks.*
+	)
+	SELECT` + checklistTaskColumns + `
+	FROM completed employee_checklist_tasks
+	LEFT JOIN users ON users.id = employee_checklist_tasks.completed_by;`
+	queryGetOverdueTasks = `
+	SELECT` + checklistTaskColumns + `,
+		employee_checklists.id,
+		employee_checklists.name,
+		employee_checklists.type,
+		employees.identity_number,
+		employees.name,
+		CURRENT_DATE - employee_checklist_tasks.due_date
+	FROM employee_checklist_tasks
+	JOIN employee_checklists ON employee_checklists.id = employee_checklist_tasks.checklist_id
+	JOIN employees ON employees.id = employee_checklists.employee_id
+	LEFT JOIN users ON users.id = employee_checklist_tasks.completed_by
+	WHERE
+		employees.user_id = @userID
+		AND employees.deleted_at IS NULL
+		AND employee_checklist_tasks.completed_at IS NULL
+		AND employee_checklist_tasks.due_date < CURRENT_DATE
+		AND (NULLIF(@assigneeRole, '') IS NULL OR employee_checklist_tasks.assignee_role = NULLIF(@assigneeRole, ''))
+		AND (NULLIF(@type, '') IS NULL OR employee_checklists.type = NULLIF(@type, '')::enum_checklist_type)
+	ORDER BY employee_checklist_tasks.due_date, employee_checklist_tasks.id
+	OFFSET @offset
+	LIMIT @limit;`
+)
+
+// scanChecklistTask scans a row selected with checklistTaskColumns. Any extra
+// destinations are scanned after it, in order.
+func scanChecklistTask(row pgx.Row, dest ...any) (*dto.ChecklistTask, error) {
+	var task dto.ChecklistTask
+	completedBy := new(pgtype.Text)
+
+	dest = append([]any{
+		&task.TaskId,
+		&task.Title,
+		&task.AssigneeRole,
+		&task.DueDate,
+		&task.CompletedAt,
+		completedBy,
+		&task.IsOverdue,
+	}, dest...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	task.CompletedBy = completedBy.String
+	return &task, nil
+}
+
+func (r *ChecklistRepository) CheckIfEmployeeExists(ctx context.Context, userID int, identityNumber string) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryCheckIfEmployeeExists, args).Scan(&isExists)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check employee")
+	}
+
+	return isExists, nil
+}
+
+func (r *ChecklistRepository) CreateTemplate(ctx context.Context, userID int, payload *dto.CreateTemplatePayload) (*dto.ChecklistTemplate, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var templateID int
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"name":   payload.Name,
+		"type":   payload.Type,
+	}
+	if err := tx.QueryRow(ctx, queryCreateTemplate, args).Scan(&templateID); err != nil {
+		return nil, errors.Wrap(err, "failed to create checklist template")
+	}
+
+	if err := r.insertTemplateTasks(ctx, tx, templateID, payload.Tasks); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit checklist template")
+	}
+
+	return r.GetTemplate(ctx, userID, templateID)
+}
+
+func (r *ChecklistRepository) insertTemplateTasks(ctx context.Context, tx pgx.Tx, templateID int, tasks []dto.TemplateTaskPayload) error {
+	batch := &pgx.Batch{}
+	for position, task := range tasks {
+		batch.Queue(queryInsertTemplateTask, pgx.NamedArgs{
+			"templateID":    templateID,
+			"title":         task.Title,
+			"assigneeRole":  task.AssigneeRole,
+			"dueOffsetDays": task.DueOffsetDays,
+			"position":      position,
+		})
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return errors.Wrap(err, "failed to create checklist template tasks")
+	}
+
+	return nil
+}
+
+func (r *ChecklistRepository) GetListTemplate(ctx context.Context, userID int) ([]dto.ChecklistTemplate, error) {
+	return r.getTemplates(ctx, userID, 0)
+}
+
+func (r *ChecklistRepository) GetTemplate(ctx context.Context, userID int, templateID int) (*dto.ChecklistTemplate, error) {
+	templates, err := r.getTemplates(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templates) == 0 {
+		return nil, errors.Wrap(pgx.ErrNoRows, "failed to get checklist template")
+	}
+
+	return &templates[0], nil
+}
+
+func (r *ChecklistRepository) getTemplates(ctx context.Context, userID int, templateID int) ([]dto.ChecklistTemplate, error) {
+	templates := make([]dto.ChecklistTemplate, 0)
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"templateID": templateID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListTemplate, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get checklist templates")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var template dto.ChecklistTemplate
+		taskID := new(pgtype.Text)
+		title := new(pgtype.Text)
+		assigneeRole := new(pgtype.Text)
+		dueOffsetDays := new(pgtype.Int4)
+
+		err := rows.Scan(
+			&template.TemplateId,
+			&template.Name,
+			&template.Type,
+			taskID,
+			title,
+			assigneeRole,
+			dueOffsetDays,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		last := len(templates) - 1
+		if last < 0 || templates[last].TemplateId != template.TemplateId {
+			template.Tasks = make([]dto.TemplateTask, 0)
+			templates = append(templates, template)
+			last++
+		}
+
+		if taskID.Valid {
+			templates[last].Tasks = append(templates[last].Tasks, dto.TemplateTask{
+				TaskId:        taskID.String,
+				Title:         title.String,
+				AssigneeRole:  assigneeRole.String,
+				DueOffsetDays: int(dueOffsetDays.Int32),
+			})
+		}
+	}
+
+	return templates, nil
+}
+
+func (r *ChecklistRepository) UpdateTemplate(ctx context.Context, userID int, templateID int, payload *dto.PatchTemplatePayload) (*dto.ChecklistTemplate, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"templateID": templateID,
+		"name":       payload.Name,
+	}
+	if err := tx.QueryRow(ctx, queryUpdateTemplate, args).Scan(&templateID); err != nil {
+		return nil, errors.Wrap(err, "failed to update checklist template")
+	}
+
+	if payload.Tasks != nil {
+		if _, err := tx.Exec(ctx, queryDeleteTemplateTasks, args); err != nil {
+			return nil, errors.Wrap(err, "failed to replace checklist template tasks")
+		}
+
+		if err := r.insertTemplateTasks(ctx, tx, templateID, *payload.Tasks); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit checklist template")
+	}
+
+	return r.GetTemplate(ctx, userID, templateID)
+}
+
+func (r *ChecklistRepository) DeleteTemplate(ctx context.Context, userID int, templateID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"templateID": templateID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeleteTemplate, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete checklist template")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// InstantiateChecklists gives the employee a checklist for every template of
+// the given type, with due dates counted from anchorDate (YYYY-MM-DD). It
+// runs in the transaction of the employee change that calls for it.
+func (r *ChecklistRepository) InstantiateChecklists(ctx context.Context, tx pgx.Tx, userID int, identityNumber string, checklistType dto.ChecklistType, anchorDate string) error {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+		"type":           checklistType,
+		"anchorDate":     anchorDate,
+	}
+
+	if _, err := tx.Exec(ctx, queryInstantiateChecklists, args); err != nil {
+		return errors.Wrap(err, "failed to create employee checklists")
+	}
+
+	return nil
+}
+
+func (r *ChecklistRepository) GetEmployeeChecklists(ctx context.Context, userID int, identityNumber string) ([]dto.EmployeeChecklist, error) {
+	checklists := make([]dto.EmployeeChecklist, 0)
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetEmployeeChecklists, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get employee checklists")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var checklist dto.EmployeeChecklist
+		var task dto.ChecklistTask
+		completedBy := new(pgtype.Text)
+
+		err := rows.Scan(
+			&checklist.ChecklistId,
+			&checklist.Name,
+			&checklist.Type,
+			&checklist.CreatedAt,
+			&task.TaskId,
+			&task.Title,
+			&task.AssigneeRole,
+			&task.DueDate,
+			&task.CompletedAt,
+			completedBy,
+			&task.IsOverdue,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		task.CompletedBy = completedBy.String
+
+		last := len(checklists) - 1
+		if last < 0 || checklists[last].ChecklistId != checklist.ChecklistId {
+			checklist.Tasks = make([]dto.ChecklistTask, 0)
+			checklists = append(checklists, checklist)
+			last++
+		}
+
+		checklists[last].Tasks = append(checklists[last].Tasks, task)
+		checklists[last].Progress.Total++
+		if task.CompletedAt != nil {
+			checklists[last].Progress.Completed++
+		}
+		if task.IsOverdue {
+			checklists[last].Progress.Overdue++
+		}
+	}
+
+	return checklists, nil
+}
+
+func (r *ChecklistRepository) CompleteTask(ctx context.Context, userID int, checklistID int, taskID int) (*dto.ChecklistTask, error) {
+	args := pgx.NamedArgs{
+		"userID":      userID,
+		"checklistID": checklistID,
+		"taskID":      taskID,
+		"completedBy": userID,
+	}
+
+	task, err := scanChecklistTask(r.pool.QueryRow(ctx, queryCompleteTask, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to complete checklist task")
+	}
+
+	return task, nil
+}
+
+func (r *ChecklistRepository) GetOverdueTasks(ctx context.Context, userID int, params *dto.GetOverdueTaskParams) ([]dto.OverdueTask, error) {
+	tasks := make([]dto.OverdueTask, 0)
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"assigneeRole": params.AssigneeRole,
+		"type":         params.Type,
+		"offset":       params.Offset,
+		"limit":        params.Limit,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetOverdueTasks, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get overdue checklist tasks")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var overdue dto.OverdueTask
+		task, err := scanChecklistTask(
+			rows,
+			&overdue.ChecklistId,
+			&overdue.ChecklistName,
+			&overdue.Type,
+			&overdue.EmployeeIdentityNumber,
+			&overdue.EmployeeName,
+			&overdue.DaysOverdue,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		overdue.ChecklistTask = *task
+		tasks = append(tasks, overdue)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/checklist/dto"
+	"ps-gogo-manajer/internal/checklist/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+
+	"github.com/pkg/errors"
+)
+
+type ChecklistUsecase struct {
+	checklistRepo repository.ChecklistRepository
+}
+
+func NewChecklistUsecase(checklistRepo repository.ChecklistRepository) *ChecklistUsecase {
+	return &ChecklistUsecase{
+		checklistRepo: checklistRepo,
+	}
+}
+
+func (u *ChecklistUsecase) CreateTemplate(ctx context.Context, userID int, payload *dto.CreateTemplatePayload) (*dto.ChecklistTemplate, error) {
+	return u.checklistRepo.CreateTemplate(ctx, userID, payload)
+}
+
+func (u *ChecklistUsecase) GetListTemplate(ctx context.Context, userID int) ([]dto.ChecklistTemplate, error) {
+	return u.checklistRepo.GetListTemplate(ctx, userID)
+}
+
+func (u *ChecklistUsecase) UpdateTemplate(ctx context.Context, userID int, templateID int, payload *dto.PatchTemplatePayload) (*dto.ChecklistTemplate, error) {
+	template, err := u.checklistRepo.UpdateTemplate(ctx, userID, templateID, payload)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "checklist template not found")
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (u *ChecklistUsecase) DeleteTemplate(ctx context.Context, userID int, templateID int) error {
+	isDeleted, err := u.checklistRepo.DeleteTemplate(ctx, userID, templateID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return errors.Wrap(customErrors.ErrNotFound, "checklist template not found")
+	}
+
+	return nil
+}
+
+func (u *ChecklistUsecase) GetEmployeeChecklists(ctx context.Context, userID int, identityNumber string) ([]dto.EmployeeChecklist, error) {
+	// * Validate if employee exists
+	isEmployeeExists, err := u.checklistRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEmployeeExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	return u.checklistRepo.GetEmployeeChecklists(ctx, userID, identityNumber)
+}
+
+func (u *ChecklistUsecase) CompleteTask(ctx context.Context, userID int, checklistID int, taskID int) (*dto.ChecklistTask, error) {
+	task, err := u.checklistRepo.CompleteTask(ctx, userID, checklistID, taskID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "checklist task not found")
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (u *ChecklistUsecase) GetOverdueTasks(ctx context.Context, userID int, params *dto.GetOverdueTaskParams) ([]dto.OverdueTask, error) {
+	return u.checklistRepo.GetOverdueTasks(ctx, userID, params)
+}
@@ -1,55 +1,153 @@
 package config
 
 import (
+	"context"
 	"net/http"
 	"ps-gogo-manajer/db"
+	attendanceHandler "ps-gogo-manajer/internal/attendance/handler"
+	attendanceRepository "ps-gogo-manajer/internal/attendance/repository"
+	attendanceUsecase "ps-gogo-manajer/internal/attendance/usecase"
+	checklistHandler "ps-gogo-manajer/internal/checklist/handler"
+	checklistRepository "ps-gogo-manajer/internal/checklist/repository"
+	checklistUsecase "ps-gogo-manajer/internal/checklist/usecase"
+	costCenterHandler "ps-gogo-manajer/internal/costcenter/handler"
+	costCenterRepository "ps-gogo-manajer/internal/costcenter/repository"
+	costCenterUsecase "ps-gogo-manajer/internal/costcenter/usecase"
+	customFieldHandler "ps-gogo-manajer/internal/customfield/handler"
+	customFieldRepository "ps-gogo-manajer/internal/customfield/repository"
+	customFieldUsecase "ps-gogo-manajer/internal/customfield/usecase"
+	docTemplateHandler "ps-gogo-manajer/internal/doctemplate/handler"
+	docTemplateRepository "ps-gogo-manajer/internal/doctemplate/repository"
+	docTemplateUsecase "ps-gogo-manajer/internal/doctemplate/usecase"
+	documentHandler "ps-gogo-manajer/internal/document/handler"
+	documentRepository "ps-gogo-manajer/internal/document/repository"
+	documentUsecase "ps-gogo-manajer/internal/document/usecase"
 	employeeHandler "ps-gogo-manajer/internal/employee/handler"
 	employeeRepository "ps-gogo-manajer/internal/employee/repository"
 	employeeUsecase "ps-gogo-manajer/internal/employee/usecase"
+	jobTitleHandler "ps-gogo-manajer/internal/jobtitle/handler"
+	jobTitleRepository "ps-gogo-manajer/internal/jobtitle/repository"
+	jobTitleUsecase "ps-gogo-manajer/internal/jobtitle/usecase"
+	leaveHandler "ps-gogo-manajer/internal/leave/handler"
+	leaveRepository "ps-gogo-manajer/internal/leave/repository"
+	leaveUsecase "ps-gogo-manajer/internal/leave/usecase"
+	locationHandler "ps-gogo-manajer/internal/location/handler"
+	locationRepository "ps-gogo-manajer/internal/location/repository"
+	locationUsecase "ps-gogo-manajer/internal/location/usecase"
+	payrollHandler "ps-gogo-manajer/internal/payroll/handler"
+	payrollRepository "ps-gogo-manajer/internal/payroll/repository"
+	payrollUsecase "ps-gogo-manajer/internal/payroll/usecase"
+	positionHandler "ps-gogo-manajer/internal/position/handler"
+	positionRepository "ps-gogo-manajer/internal/position/repository"
+	positionUsecase "ps-gogo-manajer/internal/position/usecase"
 
 	departmentHandler "ps-gogo-manajer/internal/department/handler"
 	departmentRepository "ps-gogo-manajer/internal/department/repository"
 	departmentUsecase "ps-gogo-manajer/internal/department/usecase"
 	fileHandler "ps-gogo-manajer/internal/files/handler"
+	fileRepository "ps-gogo-manajer/internal/files/repository"
+	"ps-gogo-manajer/internal/files/storage"
 	fileUsecase "ps-gogo-manajer/internal/files/usecase"
 	auth "ps-gogo-manajer/internal/middleware"
 	"ps-gogo-manajer/internal/routes"
+	"ps-gogo-manajer/internal/scheduler"
+	trashHandler "ps-gogo-manajer/internal/trash/handler"
+	trashRepository "ps-gogo-manajer/internal/trash/repository"
+	trashUsecase "ps-gogo-manajer/internal/trash/usecase"
 	userHandler "ps-gogo-manajer/internal/user/handler"
 	userRepository "ps-gogo-manajer/internal/user/repository"
 	userUsecase "ps-gogo-manajer/internal/user/usecase"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
 )
 
+const DEFAULT_TRASH_RETENTION_DAYS = 30
+
 type BootstrapConfig struct {
 	App       *echo.Echo
 	DB        *db.Postgres
 	Log       *logrus.Logger
 	Validator *validator.Validate
-	S3Client  *s3.Client
+	Storage   storage.Storage
 }
 
 func Bootstrap(config *BootstrapConfig) {
-	employeeRepo := employeeRepository.NewEmployeeRepository(config.DB.Pool)
-	employeeUseCase := employeeUsecase.NewEmployeeUsecase(*employeeRepo)
+	customFieldRepo := customFieldRepository.NewCustomFieldRepository(config.DB.Pool)
+	customFieldUseCase := customFieldUsecase.NewCustomFieldUsecase(*customFieldRepo)
+	customFieldHandler := customFieldHandler.NewCustomFieldHandler(*customFieldUseCase, config.Validator)
+
+	checklistRepo := checklistRepository.NewChecklistRepository(config.DB.Pool)
+	checklistUseCase := checklistUsecase.NewChecklistUsecase(*checklistRepo)
+	checklistHandler := checklistHandler.NewChecklistHandler(*checklistUseCase, config.Validator)
+
+	employeeRepo := employeeRepository.NewEmployeeRepository(config.DB.Pool, checklistRepo)
+	employeeUseCase := employeeUsecase.NewEmployeeUsecase(*employeeRepo, *customFieldUseCase)
 	employeeHandler := employeeHandler.NewEmployeeHandler(*employeeUseCase, config.Validator)
 
 	userRepo := userRepository.NewUserRepository(config.DB.Pool)
 	userUseCase := userUsecase.NewUserUseCase(*userRepo)
 	userHandler := userHandler.NewUserHandler(*userUseCase, config.Validator)
 
-	fileUsecase := fileUsecase.NewFileUseCase(config.S3Client)
-	fileHandler := fileHandler.NewFileHandler(fileUsecase, config.Log)
+	fileRepo := fileRepository.NewFileRepository(config.DB.Pool)
+	fileUsecase := fileUsecase.NewFileUseCase(*fileRepo, config.Storage, config.Log)
+	fileHandler := fileHandler.NewFileHandler(fileUsecase, config.Validator, config.Log)
+
+	documentRepo := documentRepository.NewDocumentRepository(config.DB.Pool)
+	documentUseCase := documentUsecase.NewDocumentUsecase(*documentRepo, fileUsecase, config.Log)
+	documentHandler := documentHandler.NewDocumentHandler(*documentUseCase, config.Validator)
 
 	//department variable
 	departmentRepo := departmentRepository.NewDepartmentRepository(config.DB.Pool)
 	departmentUsecase := departmentUsecase.NewDepartmentUsecases(*departmentRepo)
-	departmentHandler := departmentHandler.NewDepartmentHandler(*departmentUsecase,config.Validator)
+	departmentHandler := departmentHandler.NewDepartmentHandler(*departmentUsecase, config.Validator)
+
+	leaveRepo := leaveRepository.NewLeaveRepository(config.DB.Pool)
+	leaveUseCase := leaveUsecase.NewLeaveUsecase(*leaveRepo)
+	leaveHandler := leaveHandler.NewLeaveHandler(*leaveUseCase, config.Validator)
+
+	attendanceRepo := attendanceRepository.NewAttendanceRepository(config.DB.Pool)
+	attendanceUseCase := attendanceUsecase.NewAttendanceUsecase(*attendanceRepo)
+	attendanceHandler := attendanceHandler.NewAttendanceHandler(*attendanceUseCase, config.Validator)
+
+	payrollRepo := payrollRepository.NewPayrollRepository(config.DB.Pool)
+	payrollUseCase := payrollUsecase.NewPayrollUsecase(*payrollRepo, payrollUsecase.DefaultRules())
+	payrollHandler := payrollHandler.NewPayrollHandler(*payrollUseCase, config.Validator)
+
+	docTemplateRepo := docTemplateRepository.NewDocumentTemplateRepository(config.DB.Pool)
+	docTemplateUseCase := docTemplateUsecase.NewDocumentTemplateUsecase(*docTemplateRepo, *payrollUseCase, *documentUseCase, fileUsecase, config.Log)
+	docTemplateHandler := docTemplateHandler.NewDocumentTemplateHandler(*docTemplateUseCase, config.Validator)
+
+	positionRepo := positionRepository.NewPositionRepository(config.DB.Pool)
+	positionUseCase := positionUsecase.NewPositionUsecase(*positionRepo)
+	positionHandler := positionHandler.NewPositionHandler(*positionUseCase, config.Validator)
+
+	jobTitleRepo := jobTitleRepository.NewJobTitleRepository(config.DB.Pool)
+	jobTitleUseCase := jobTitleUsecase.NewJobTitleUsecase(*jobTitleRepo)
+	jobTitleHandler := jobTitleHandler.NewJobTitleHandler(*jobTitleUseCase, config.Validator)
+
+	locationRepo := locationRepository.NewLocationRepository(config.DB.Pool)
+	locationUseCase := locationUsecase.NewLocationUsecase(*locationRepo)
+	locationHandler := locationHandler.NewLocationHandler(*locationUseCase, config.Validator)
+
+	costCenterRepo := costCenterRepository.NewCostCenterRepository(config.DB.Pool)
+	costCenterUseCase := costCenterUsecase.NewCostCenterUsecase(*costCenterRepo)
+	costCenterHandler := costCenterHandler.NewCostCenterHandler(*costCenterUseCase, config.Validator)
+
+	trashRepo := trashRepository.NewTrashRepository(config.DB.Pool)
+	trashUseCase := trashUsecase.NewTrashUsecase(*trashRepo, getEnvInt("TRASH_RETENTION_DAYS", DEFAULT_TRASH_RETENTION_DAYS), config.Log)
+	trashHandler := trashHandler.NewTrashHandler(*trashUseCase, config.Validator)
+
+	// * Background jobs
+	jobs := scheduler.NewScheduler(config.Log)
+	jobs.Register("purge-expired-trash", time.Hour, trashUseCase.PurgeExpired)
+	jobs.Register("purge-expired-uploads", time.Hour, fileUsecase.PurgeExpiredUploads)
+	jobs.Register("apply-employee-status-transitions", time.Hour, employeeUseCase.ApplyDueTransitions)
+	jobs.Register("apply-department-transfers", time.Hour, employeeUseCase.ApplyDueTransfers)
+	jobs.Start(context.Background())
 
 	// * Middleware
 	config.App.Use(middleware.CORSWithConfig(middleware.CORSConfig{
@@ -57,6 +155,7 @@ func Bootstrap(config *BootstrapConfig) {
 		AllowMethods: []string{
 			http.MethodGet,
 			http.MethodPost,
+			http.MethodPut,
 			http.MethodPatch,
 			http.MethodDelete,
 			http.MethodOptions,
@@ -69,15 +168,28 @@ func Bootstrap(config *BootstrapConfig) {
 		Timeout:      30 * time.Second,
 	}))
 	authMiddleware := auth.Auth()
+	managerAuthMiddleware := auth.ManagerAuth()
 
 	routes := routes.RouteConfig{
-		App:             config.App,
-		S3Client:        config.S3Client,
-		EmployeeHandler: employeeHandler,
-		UserHandler:     userHandler,
-		AuthMiddleware:  authMiddleware,
-		FileHandler:     fileHandler,
-		DepartmentHandler : departmentHandler,
+		App:                   config.App,
+		EmployeeHandler:       employeeHandler,
+		UserHandler:           userHandler,
+		AuthMiddleware:        authMiddleware,
+		ManagerAuthMiddleware: managerAuthMiddleware,
+		FileHandler:           fileHandler,
+		DepartmentHandler:     departmentHandler,
+		TrashHandler:          trashHandler,
+		CustomFieldHandler:    customFieldHandler,
+		ChecklistHandler:      checklistHandler,
+		DocumentHandler:       documentHandler,
+		LeaveHandler:          leaveHandler,
+		AttendanceHandler:     attendanceHandler,
+		PayrollHandler:        payrollHandler,
+		DocTemplateHandler:    docTemplateHandler,
+		PositionHandler:       positionHandler,
+		JobTitleHandler:       jobTitleHandler,
+		LocationHandler:       locationHandler,
+		CostCenterHandler:     costCenterHandler,
 	}
 
 	routes.SetupRoutes()
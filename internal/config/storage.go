@@ -0,0 +1,106 @@
+package config
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"ps-gogo-manajer/internal/files/storage"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	AWSConfig "github.com/aws/aws-sdk-go-v2/config"
+	AWSCredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	STORAGE_DRIVER_S3    = "s3"
+	STORAGE_DRIVER_LOCAL = "local"
+
+	DEFAULT_STORAGE_LOCAL_DIR = "./storage"
+)
+
+// NewStorage sets up the storage picked by STORAGE_DRIVER, S3 unless told
+// otherwise. It reads the environment when called, so after .env is loaded.
+func NewStorage(log *logrus.Logger) storage.Storage {
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case STORAGE_DRIVER_LOCAL:
+		return newLocalStorage(log)
+	case STORAGE_DRIVER_S3, "":
+		return newS3Storage(log)
+	default:
+		log.Fatalf("unknown storage driver %q", driver)
+		return nil
+	}
+}
+
+// newS3Storage connects to AWS S3, or to an S3 compatible service such as
+// MinIO when S3_ENDPOINT is set.
+func newS3Storage(log *logrus.Logger) storage.Storage {
+	usePathStyle, _ := strconv.ParseBool(os.Getenv("S3_USE_PATH_STYLE"))
+	s3Config := storage.S3Config{
+		Region:       os.Getenv("S3_REGION"),
+		Bucket:       os.Getenv("S3_BUCKET_NAME"),
+		AccessKey:    os.Getenv("S3_ID"),
+		SecretKey:    os.Getenv("S3_SECRET_KEY"),
+		Endpoint:     os.Getenv("S3_ENDPOINT"),
+		UsePathStyle: usePathStyle,
+		PublicURL:    os.Getenv("S3_PUBLIC_URL"),
+	}
+
+	if s3Config.Bucket == "" {
+		log.Fatal("S3_BUCKET_NAME is required for the s3 storage")
+	}
+
+	if s3Config.Endpoint != "" {
+		endpoint, err := url.Parse(s3Config.Endpoint)
+		if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+			log.Fatalf("S3_ENDPOINT %q is not a valid URL", s3Config.Endpoint)
+		}
+	}
+
+	config, err := AWSConfig.LoadDefaultConfig(
+		context.TODO(),
+		AWSConfig.WithRegion(s3Config.Region),
+		AWSConfig.WithCredentialsProvider(
+			AWSCredentials.NewStaticCredentialsProvider(
+				s3Config.AccessKey,
+				s3Config.SecretKey,
+				""),
+		),
+	)
+	if err != nil {
+		log.Fatal("unable connect to S3 Client", err.Error())
+	}
+
+	client := s3.NewFromConfig(config, func(o *s3.Options) {
+		if s3Config.Endpoint != "" {
+			o.BaseEndpoint = aws.String(s3Config.Endpoint)
+		}
+		o.UsePathStyle = s3Config.UsePathStyle
+	})
+
+	return storage.NewS3(client, s3Config)
+}
+
+// newLocalStorage keeps the files in STORAGE_LOCAL_DIR and serves the public
+// ones from the /v1/file route of this API.
+func newLocalStorage(log *logrus.Logger) storage.Storage {
+	dir := os.Getenv("STORAGE_LOCAL_DIR")
+	if dir == "" {
+		dir = DEFAULT_STORAGE_LOCAL_DIR
+	}
+
+	publicURL := os.Getenv("STORAGE_PUBLIC_URL")
+	if publicURL == "" {
+		publicURL = "http://localhost" + os.Getenv("PORT") + "/v1/file"
+	}
+
+	local, err := storage.NewLocal(dir, publicURL)
+	if err != nil {
+		log.Fatal("unable to set up local storage", err.Error())
+	}
+
+	return local
+}
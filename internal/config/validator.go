@@ -1,14 +1,21 @@
 package config
 
 import (
+	"regexp"
+
 	"github.com/go-playground/validator/v10"
 )
 
+var fieldKeyRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
 func NewValidator() *validator.Validate {
 	validate := validator.New()
 	validate.RegisterValidation("authaction", func(fl validator.FieldLevel) bool {
 		action := fl.Field().String()
 		return action == "login" || action == "create"
 	})
+	validate.RegisterValidation("fieldkey", func(fl validator.FieldLevel) bool {
+		return fieldKeyRegex.MatchString(fl.Field().String())
+	})
 	return validate
 }
@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+type CostCenter struct {
+	CostCenterId  string    `json:"costCenterId"`
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	EmployeeCount int       `json:"employeeCount"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type CreateCostCenterPayload struct {
+	Code string `json:"code" validate:"required,min=1,max=32"`
+	Name string `json:"name" validate:"required,min=1,max=255"`
+}
+
+type PatchCostCenterPayload struct {
+	Code *string `json:"code" validate:"omitempty,min=1,max=32"`
+	Name *string `json:"name" validate:"omitempty,min=1,max=255"`
+}
+
+type GetCostCenterParams struct {
+	Code string `query:"code"`
+	Name string `query:"name"`
+}
+
+type CostCenterPathParam struct {
+	CostCenterId int `param:"costCenterId" validate:"required,min=1"`
+}
+
+// GetBreakdownParams picks the payroll run the payroll cost is taken from,
+// the latest locked run when PayrollRunId is left out.
+type GetBreakdownParams struct {
+	PayrollRunId int `query:"payrollRunId" validate:"omitempty,min=1"`
+}
+
+// CostCenterBreakdown holds the headcount and the payroll cost of every cost
+// center. PayrollRunId and Period are empty when there is no run to take the
+// cost from.
+type CostCenterBreakdown struct {
+	PayrollRunId string           `json:"payrollRunId"`
+	Period       string           `json:"period"`
+	CostCenters  []CostCenterCost `json:"costCenters"`
+}
+
+// CostCenterCost is one row of the breakdown. The last row, with an empty
+// CostCenterId, holds the employees without a cost center. PayrollCost is
+// the gross pay plus the contributions paid by the employer.
+type CostCenterCost struct {
+	CostCenterId string `json:"costCenterId"`
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Headcount    int    `json:"headcount"`
+	PayrollCost  int64  `json:"payrollCost"`
+}
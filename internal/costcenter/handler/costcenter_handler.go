@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/costcenter/dto"
+	"ps-gogo-manajer/internal/costcenter/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type CostCenterHandler struct {
+	costCenterUsecase usecase.CostCenterUsecase
+	validator         *validator.Validate
+}
+
+func NewCostCenterHandler(costCenterUsecase usecase.CostCenterUsecase, validator *validator.Validate) *CostCenterHandler {
+	return &CostCenterHandler{
+		costCenterUsecase: costCenterUsecase,
+		validator:         validator,
+	}
+}
+
+// bind binds the request into payload and validates it, wrapping any failure
+// as a bad request.
+func (h CostCenterHandler) bind(ctx echo.Context, payload any) error {
+	if err := ctx.Bind(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (h CostCenterHandler) bindPathParam(ctx echo.Context) (*dto.CostCenterPathParam, error) {
+	var pathParam dto.CostCenterPathParam
+	if err := (&echo.DefaultBinder{}).BindPathParams(ctx, &pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return &pathParam, nil
+}
+
+func (h CostCenterHandler) CreateCostCenter(ctx echo.Context) error {
+	var payload dto.CreateCostCenterPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	costCenter, err := h.costCenterUsecase.CreateCostCenter(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, costCenter)
+}
+
+func (h CostCenterHandler) GetListCostCenter(ctx echo.Context) error {
+	var params dto.GetCostCenterParams
+	if err := h.bind(ctx, &params); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	costCenters, err := h.costCenterUsecase.GetListCostCenter(ctx.Request().Context(), userData.Id, &params)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, costCenters)
+}
+
+func (h CostCenterHandler) GetCostCenter(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	costCenter, err := h.costCenterUsecase.GetCostCenter(ctx.Request().Context(), userData.Id, pathParam.CostCenterId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, costCenter)
+}
+
+func (h CostCenterHandler) UpdateCostCenter(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.PatchCostCenterPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	costCenter, err := h.costCenterUsecase.UpdateCostCenter(ctx.Request().Context(), userData.Id, pathParam.CostCenterId, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, costCenter)
+}
+
+func (h CostCenterHandler) DeleteCostCenter(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	if err := h.costCenterUsecase.DeleteCostCenter(ctx.Request().Context(), userData.Id, pathParam.CostCenterId); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h CostCenterHandler) GetCostCenterBreakdown(ctx echo.Context) error {
+	var params dto.GetBreakdownParams
+	if err := h.bind(ctx, &params); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	breakdown, err := h.costCenterUsecase.GetCostCenterBreakdown(ctx.Request().Context(), userData.Id, &params)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, breakdown)
+}
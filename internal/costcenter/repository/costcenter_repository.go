@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/costcenter/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type CostCenterRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCostCenterRepository(pool *pgxpool.Pool) *CostCenterRepository {
+	return &CostCenterRepository{pool: pool}
+}
+
+const (
+	costCenterColumns = `
+		cost_centers.id,
+		cost_centers.code,
+		cost_centers.name,
+		(
+			SELECT COUNT(*)
+			FROM employees
+			WHERE
+				employees.cost_center_id = cost_centers.id
+				AND employees.deleted_at IS NULL
+				AND employees.status <> 'terminated'
+		),
+		cost_centers.created_at,
+		cost_centers.updated_at`
+
+	queryCreateCostCenter = `
+	INSERT INTO cost_centers(user_id, code, name)
+	VALUES (@userID, @code, @name)
+	RETURNING` + costCenterColumns + `;`
+	queryGetCostCenter = `
+	SELECT` + costCenterColumns + `
+	FROM cost_centers
+	WHERE
+		user_id = @userID
+		AND id = @costCenterID;`
+	queryGetListCostCenter = `
+	SELECT` + costCenterColumns + `
+	FROM cost_centers
+	WHERE
+		user_id = @userID
+		AND (NULLIF(@code, '') IS NULL OR code ILIKE NULLIF(@code, '') || '%')
+		AND (NULLIF(@name, '') IS NULL OR name ILIKE '%' || NULLIF(@name, '') || '%')
+	ORDER BY code, id;`
+	queryUpdateCostCenter = `
+	UPDATE cost_centers
+	SET
+		code = COALESCE(@code, code),
+		name = COALESCE(@name, name),
+		updated_at = NOW()
+	WHERE
+		user_id = @userID
+		AND id = @costCenterID
+	RETURNING` + costCenterColumns + `;`
+	queryDeleteCostCenter = `
+	DELETE FROM cost_centers
+	WHERE
+		user_id = @userID
+		AND id = @costCenterID;`
+	queryCheckIfCostCenterExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM cost_centers
+		WHERE
+			user_id = @userID
+			AND code = @code
+			AND id <> @costCenterID
+	) is_exists;`
+	// The run is the one asked for, or the latest locked run of the user.
+	queryGetBreakdownRun = `
+	SELECT
+		id,
+		to_char(period, 'YYYY-MM')
+	FROM payroll_runs
+	WHERE
+		user_id = @userID
+		AND CASE
+			WHEN @payrollRunID::bigint = 0 THEN status = 'locked'
+			ELSE id = @payrollRunID::bigint
+		END
+	ORDER BY period DESC
+	LIMIT 1;`
+	// Payroll items keep the cost center of the employee at the time of the
+	// run, so the cost of a past run stays where it was charged.
+	queryGetCostCenterBreakdown = `
+	WITH
+	headcount AS (
+		SELECT cost_center_id, COUNT(*) headcount
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND deleted_at IS NULL
+			AND status <> 'terminated'
+		GROUP BY cost_center_id
+	),
+	cost AS (
+		SELECT
+			payroll_items.cost_center_id,
+			SUM(
+				payroll_items.gross + COALESCE((
+					SELECT SUM(line.amount)
+					FROM jsonb_to_recordset(payroll_items.lines) AS line(type TEXT, amount BIGINT)
+					WHERE line.type = 'employer_contribution'
+				), 0)
+			)::bigint payroll_cost
+		FROM payroll_items
+		JOIN payroll_runs ON payroll_runs.id = payroll_items.payroll_run_id
+		WHERE
+			payroll_runs.user_id = @userID
+			AND payroll_runs.id = @payrollRunID::bigint
+		GROUP BY payroll_items.cost_center_id
+	)
+	SELECT cost_center_id, code, name, headcount, payroll_cost
+	FROM (
+		SELECT
+			cost_centers.id::text cost_center_id,
+			cost_centers.code,
+			cost_centers.name,
+			COALESCE(headcount.headcount, 0) headcount,
+			COALESCE(cost.payroll_cost, 0) payroll_cost
+		FROM cost_centers
+		LEFT JOIN headcount ON headcount.cost_center_id = cost_centers.id
+		LEFT JOIN cost ON cost.cost_center_id = cost_centers.id
+		WHERE cost_centers.user_id = @userID
+		UNION ALL
+		SELECT
+			NULL,
+			NULL,
+			NULL,
+			COALESCE((SELECT headcount FROM headcount WHERE cost_center_id IS NULL), 0),
+			COALESCE((SELECT payroll_cost FROM cost WHERE cost_center_id IS NULL), 0)
+	) breakdown
+	ORDER BY cost_center_id IS NULL, code;`
+)
+
+func scanCostCenter(row pgx.Row) (*dto.CostCenter, error) {
+	var costCenter dto.CostCenter
+
+	err := row.Scan(
+		&costCenter.CostCenterId,
+		&costCenter.Code,
+		&costCenter.Name,
+		&costCenter.EmployeeCount,
+		&costCenter.CreatedAt,
+		&costCenter.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &costCenter, nil
+}
+
+func (r *CostCenterRepository) CreateCostCenter(ctx context.Context, userID int, payload *dto.CreateCostCenterPayload) (*dto.CostCenter, error) {
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"code":   payload.Code,
+		"name":   payload.Name,
+	}
+
+	costCenter, err := scanCostCenter(r.pool.QueryRow(ctx, queryCreateCostCenter, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cost center")
+	}
+
+	return costCenter, nil
+}
+
+func (r *CostCenterRepository) GetCostCenter(ctx context.Context, userID int, costCenterID int) (*dto.CostCenter, error) {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"costCenterID": costCenterID,
+	}
+
+	costCenter, err := scanCostCenter(r.pool.QueryRow(ctx, queryGetCostCenter, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cost center")
+	}
+
+	return costCenter, nil
+}
+
+func (r *CostCenterRepository) GetListCostCenter(ctx context.Context, userID int, params *dto.GetCostCenterParams) ([]dto.CostCenter, error) {
+	costCenters := make([]dto.CostCenter, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"code":   params.Code,
+		"name":   params.Name,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListCostCenter, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list cost center")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		costCenter, err := scanCostCenter(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		costCenters = append(costCenters, *costCenter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get list cost center")
+	}
+
+	return costCenters, nil
+}
+
+func (r *CostCenterRepository) UpdateCostCenter(ctx context.Context, userID int, costCenterID int, payload *dto.PatchCostCenterPayload) (*dto.CostCenter, error) {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"costCenterID": costCenterID,
+		"code":         payload.Code,
+		"name":         payload.Name,
+	}
+
+	costCenter, err := scanCostCenter(r.pool.QueryRow(ctx, queryUpdateCostCenter, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update cost center")
+	}
+
+	return costCenter, nil
+}
+
+func (r *CostCenterRepository) DeleteCostCenter(ctx context.Context, userID int, costCenterID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"costCenterID": costCenterID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeleteCostCenter, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete cost center")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// CheckIfCostCenterExists reports whether another cost center than
+// costCenterID has the code.
+func (r *CostCenterRepository) CheckIfCostCenterExists(ctx context.Context, userID int, code string, costCenterID int) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"code":         code,
+		"costCenterID": costCenterID,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCheckIfCostCenterExists, args).Scan(&isExists); err != nil {
+		return false, errors.Wrap(err, "failed to check is cost center exists")
+	}
+
+	return isExists, nil
+}
+
+// GetBreakdownRun returns the id and the period of the payroll run, the
+// latest locked run when payrollRunID is 0.
+func (r *CostCenterRepository) GetBreakdownRun(ctx context.Context, userID int, payrollRunID int) (int, string, error) {
+	var runID int
+	var period string
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"payrollRunID": payrollRunID,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryGetBreakdownRun, args).Scan(&runID, &period); err != nil {
+		return 0, "", errors.Wrap(err, "failed to get payroll run")
+	}
+
+	return runID, period, nil
+}
+
+func (r *CostCenterRepository) GetCostCenterBreakdown(ctx context.Context, userID int, payrollRunID int) ([]dto.CostCenterCost, error) {
+	costCenters := make([]dto.CostCenterCost, 0)
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"payrollRunID": payrollRunID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetCostCenterBreakdown, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cost center breakdown")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var costCenter dto.CostCenterCost
+		costCenterID := new(pgtype.Text)
+		code := new(pgtype.Text)
+		name := new(pgtype.Text)
+
+		if err := rows.Scan(costCenterID, code, name, &costCenter.Headcount, &costCenter.PayrollCost); err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		costCenter.CostCenterId = costCenterID.String
+		costCenter.Code = code.String
+		costCenter.Name = name.String
+		costCenters = append(costCenters, costCenter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get cost center breakdown")
+	}
+
+	return costCenters, nil
+}
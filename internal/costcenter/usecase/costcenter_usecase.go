@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/costcenter/dto"
+	"ps-gogo-manajer/internal/costcenter/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+type CostCenterUsecase struct {
+	costCenterRepo repository.CostCenterRepository
+}
+
+func NewCostCenterUsecase(costCenterRepo repository.CostCenterRepository) *CostCenterUsecase {
+	return &CostCenterUsecase{
+		costCenterRepo: costCenterRepo,
+	}
+}
+
+func (u *CostCenterUsecase) CreateCostCenter(ctx context.Context, userID int, payload *dto.CreateCostCenterPayload) (*dto.CostCenter, error) {
+	if err := u.validateUnique(ctx, userID, payload.Code, 0); err != nil {
+		return nil, err
+	}
+
+	return u.costCenterRepo.CreateCostCenter(ctx, userID, payload)
+}
+
+func (u *CostCenterUsecase) GetListCostCenter(ctx context.Context, userID int, params *dto.GetCostCenterParams) ([]dto.CostCenter, error) {
+	return u.costCenterRepo.GetListCostCenter(ctx, userID, params)
+}
+
+func (u *CostCenterUsecase) GetCostCenter(ctx context.Context, userID int, costCenterID int) (*dto.CostCenter, error) {
+	costCenter, err := u.costCenterRepo.GetCostCenter(ctx, userID, costCenterID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "cost center not found")
+		}
+		return nil, err
+	}
+
+	return costCenter, nil
+}
+
+func (u *CostCenterUsecase) UpdateCostCenter(ctx context.Context, userID int, costCenterID int, payload *dto.PatchCostCenterPayload) (*dto.CostCenter, error) {
+	costCenter, err := u.GetCostCenter(ctx, userID, costCenterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Code != nil && *payload.Code != costCenter.Code {
+		if err := u.validateUnique(ctx, userID, *payload.Code, costCenterID); err != nil {
+			return nil, err
+		}
+	}
+
+	costCenter, err = u.costCenterRepo.UpdateCostCenter(ctx, userID, costCenterID, payload)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "cost center not found")
+		}
+		return nil, err
+	}
+
+	return costCenter, nil
+}
+
+// DeleteCostCenter removes a cost center nobody is charged to anymore.
+// Terminated employees and past payroll runs lose the cost center.
+func (u *CostCenterUsecase) DeleteCostCenter(ctx context.Context, userID int, costCenterID int) error {
+	costCenter, err := u.GetCostCenter(ctx, userID, costCenterID)
+	if err != nil {
+		return err
+	}
+
+	if costCenter.EmployeeCount > 0 {
+		return errors.Wrap(customErrors.ErrConflict, "still containing employee")
+	}
+
+	isDeleted, err := u.costCenterRepo.DeleteCostCenter(ctx, userID, costCenterID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return errors.Wrap(customErrors.ErrNotFound, "cost center not found")
+	}
+
+	return nil
+}
+
+// GetCostCenterBreakdown returns the headcount of every cost center along
+// with the payroll cost of the run asked for, or of the latest locked run.
+func (u *CostCenterUsecase) GetCostCenterBreakdown(ctx context.Context, userID int, params *dto.GetBreakdownParams) (*dto.CostCenterBreakdown, error) {
+	var breakdown dto.CostCenterBreakdown
+	runID, period, err := u.costCenterRepo.GetBreakdownRun(ctx, userID, params.PayrollRunId)
+	if err != nil {
+		if !errors.Is(err, customErrors.ErrNotFound) {
+			return nil, err
+		}
+		// * Without any locked run yet the breakdown only holds the headcount
+		if params.PayrollRunId != 0 {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "payroll run not found")
+		}
+	}
+
+	if runID != 0 {
+		breakdown.PayrollRunId = strconv.Itoa(runID)
+		breakdown.Period = period
+	}
+
+	breakdown.CostCenters, err = u.costCenterRepo.GetCostCenterBreakdown(ctx, userID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &breakdown, nil
+}
+
+func (u *CostCenterUsecase) validateUnique(ctx context.Context, userID int, code string, costCenterID int) error {
+	isExists, err := u.costCenterRepo.CheckIfCostCenterExists(ctx, userID, code, costCenterID)
+	if err != nil {
+		return err
+	}
+
+	if isExists {
+		return errors.Wrap(customErrors.ErrConflict, "cost center code already exists")
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package dto
+
+type FieldType string
+
+const (
+	FieldTypeText    FieldType = "text"
+	FieldTypeNumber  FieldType = "number"
+	FieldTypeBoolean FieldType = "boolean"
+	FieldTypeDate    FieldType = "date"
+	FieldTypeEnum    FieldType = "enum"
+)
+
+type CustomField struct {
+	CustomFieldId   string    `json:"customFieldId"`
+	Key             string    `json:"key"`
+	Label           string    `json:"label"`
+	Type            FieldType `json:"type"`
+	Required        bool      `json:"required"`
+	Options         []string  `json:"options"`
+	ValidationRegex string    `json:"validationRegex"`
+}
+
+type CreateCustomFieldPayload struct {
+	Key             string    `json:"key" validate:"required,max=64,fieldkey"`
+	Label           string    `json:"label" validate:"required,min=1,max=255"`
+	Type            FieldType `json:"type" validate:"required,oneof=text number boolean date enum"`
+	Required        bool      `json:"required"`
+	Options         []string  `json:"options" validate:"required_if=Type enum,omitempty,min=1,dive,required,max=255"`
+	ValidationRegex string    `json:"validationRegex" validate:"omitempty,max=255"`
+}
+
+type PatchCustomFieldPayload struct {
+	Label           *string   `json:"label" validate:"omitempty,min=1,max=255"`
+	Required        *bool     `json:"required"`
+	Options         *[]string `json:"options" validate:"omitempty,min=1,dive,required,max=255"`
+	ValidationRegex *string   `json:"validationRegex" validate:"omitempty,max=255"`
+}
+
+type CustomFieldPathParam struct {
+	CustomFieldId int `param:"customFieldId" validate:"required,min=1"`
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/customfield/dto"
+	"ps-gogo-manajer/internal/customfield/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type CustomFieldHandler struct {
+	customFieldUsecase usecase.CustomFieldUsecase
+	validator          *validator.Validate
+}
+
+func NewCustomFieldHandler(customFieldUsecase usecase.CustomFieldUsecase, validator *validator.Validate) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		customFieldUsecase: customFieldUsecase,
+		validator:          validator,
+	}
+}
+
+func (h CustomFieldHandler) CreateCustomField(ctx echo.Context) error {
+	var payload dto.CreateCustomFieldPayload
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	field, err := h.customFieldUsecase.CreateCustomField(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, field)
+}
+
+func (h CustomFieldHandler) GetListCustomField(ctx echo.Context) error {
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	fields, err := h.customFieldUsecase.GetListCustomField(ctx.Request().Context(), userData.Id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, fields)
+}
+
+func (h CustomFieldHandler) UpdateCustomField(ctx echo.Context) error {
+	var pathParam dto.CustomFieldPathParam
+	if err := (&echo.DefaultBinder{}).BindPathParams(ctx, &pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.PatchCustomFieldPayload
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	field, err := h.customFieldUsecase.UpdateCustomField(ctx.Request().Context(), userData.Id, pathParam.CustomFieldId, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, field)
+}
+
+func (h CustomFieldHandler) DeleteCustomField(ctx echo.Context) error {
+	var pathParam dto.CustomFieldPathParam
+	if err := ctx.Bind(&pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	err := h.customFieldUsecase.DeleteCustomField(ctx.Request().Context(), userData.Id, pathParam.CustomFieldId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/customfield/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type CustomFieldRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCustomFieldRepository(pool *pgxpool.Pool) *CustomFieldRepository {
+	return &CustomFieldRepository{pool: pool}
+}
+
+const (
+	customFieldColumns = `
+		id,
+		key,
+		label,
+		type,
+		required,
+		options,
+		validation_regex`
+
+	queryCreateCustomField = `
+	INSERT INTO custom_fields(user_id, key, label, type, required, options, validation_regex)
+	VALUES (@userID, @key, @label, @type, @required, @options, NULLIF(@validationRegex, ''))
+	RETURNING` + customFieldColumns + `;`
+	queryGetListCustomField = `
+	SELECT` + customFieldColumns + `
+	FROM custom_fields
+	WHERE user_id = @userID
+	ORDER BY id;`
+	queryGetCustomField = `
+	SELECT` + customFieldColumns + `
+	FROM custom_fields
+	WHERE
+		user_id = @userID
+		AND id = @id;`
+	queryUpdateCustomField = `
+	UPDATE custom_fields
+	SET
+		label = COALESCE(@label, label),
+		required = COALESCE(@required, required),
+		options = COALESCE(@options, options),
+		validation_regex = CASE
+			WHEN @validationRegex::text IS NULL THEN validation_regex
+			ELSE NULLIF(@validationRegex::text, '')
+		END
+	WHERE
+		user_id = @userID
+		AND id = @id
+	RETURNING` + customFieldColumns + `;`
+	queryDeleteCustomField = `
+	DELETE FROM custom_fields
+	WHERE
+		user_id = @userID
+		AND id = @id
+	RETURNING key;`
+	queryRemoveCustomFieldValues = `
+	UPDATE employees
+	SET custom_fields = custom_fields - @key::text
+	WHERE
+		user_id = @userID
+		AND custom_fields ? @key::text;`
+)
+
+func scanCustomField(row pgx.Row) (*dto.CustomField, error) {
+	var field dto.CustomField
+	validationRegex := new(pgtype.Text)
+
+	err := row.Scan(
+		&field.CustomFieldId,
+		&field.Key,
+		&field.Label,
+		&field.Type,
+		&field.Required,
+		&field.Options,
+		validationRegex,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	field.ValidationRegex = validationRegex.String
+	return &field, nil
+}
+
+func (r *CustomFieldRepository) CreateCustomField(ctx context.Context, userID int, payload *dto.CreateCustomFieldPayload) (*dto.CustomField, error) {
+	options := payload.Options
+	if options == nil {
+		options = make([]string, 0)
+	}
+
+	args := pgx.NamedArgs{
+		"userID":          userID,
+		"key":             payload.Key,
+		"label":           payload.Label,
+		"type":            payload.Type,
+		"required":        payload.Required,
+		"options":         options,
+		"validationRegex": payload.ValidationRegex,
+	}
+
+	field, err := scanCustomField(r.pool.QueryRow(ctx, queryCreateCustomField, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create custom field")
+	}
+
+	return field, nil
+}
+
+func (r *CustomFieldRepository) GetListCustomField(ctx context.Context, userID int) ([]dto.CustomField, error) {
+	fields := make([]dto.CustomField, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListCustomField, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list custom field")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		field, err := scanCustomField(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		fields = append(fields, *field)
+	}
+
+	return fields, nil
+}
+
+func (r *CustomFieldRepository) GetCustomField(ctx context.Context, userID int, id int) (*dto.CustomField, error) {
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"id":     id,
+	}
+
+	field, err := scanCustomField(r.pool.QueryRow(ctx, queryGetCustomField, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get custom field")
+	}
+
+	return field, nil
+}
+
+func (r *CustomFieldRepository) UpdateCustomField(ctx context.Context, userID int, id int, payload *dto.PatchCustomFieldPayload) (*dto.CustomField, error) {
+	args := pgx.NamedArgs{
+		"userID":          userID,
+		"id":              id,
+		"label":           payload.Label,
+		"required":        payload.Required,
+		"options":         payload.Options,
+		"validationRegex": payload.ValidationRegex,
+	}
+
+	field, err := scanCustomField(r.pool.QueryRow(ctx, queryUpdateCustomField, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update custom field")
+	}
+
+	return field, nil
+}
+
+// DeleteCustomField removes the definition together with every value stored
+// for it on employees.
+func (r *CustomFieldRepository) DeleteCustomField(ctx context.Context, userID int, id int) error {
+	var key string
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"id":     id,
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, queryDeleteCustomField, args).Scan(&key); err != nil {
+		return errors.Wrap(err, "failed to delete custom field")
+	}
+
+	args["key"] = key
+	if _, err := tx.Exec(ctx, queryRemoveCustomFieldValues, args); err != nil {
+		return errors.Wrap(err, "failed to remove custom field values")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to commit custom field delete")
+	}
+
+	return nil
+}
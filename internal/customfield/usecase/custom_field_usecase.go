@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"ps-gogo-manajer/internal/customfield/dto"
+	"ps-gogo-manajer/internal/customfield/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const DATE_LAYOUT = "2006-01-02"
+
+type CustomFieldUsecase struct {
+	customFieldRepo repository.CustomFieldRepository
+}
+
+func NewCustomFieldUsecase(customFieldRepo repository.CustomFieldRepository) *CustomFieldUsecase {
+	return &CustomFieldUsecase{
+		customFieldRepo: customFieldRepo,
+	}
+}
+
+func (u *CustomFieldUsecase) CreateCustomField(ctx context.Context, userID int, payload *dto.CreateCustomFieldPayload) (*dto.CustomField, error) {
+	if err := validateDefinition(payload.Type, payload.Options, payload.ValidationRegex); err != nil {
+		return nil, err
+	}
+
+	fields, err := u.customFieldRepo.GetListCustomField(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		if field.Key == payload.Key {
+			return nil, errors.Wrap(customErrors.ErrConflict, "custom field key already exists")
+		}
+	}
+
+	return u.customFieldRepo.CreateCustomField(ctx, userID, payload)
+}
+
+func (u *CustomFieldUsecase) GetListCustomField(ctx context.Context, userID int) ([]dto.CustomField, error) {
+	return u.customFieldRepo.GetListCustomField(ctx, userID)
+}
+
+func (u *CustomFieldUsecase) UpdateCustomField(ctx context.Context, userID int, id int, payload *dto.PatchCustomFieldPayload) (*dto.CustomField, error) {
+	field, err := u.customFieldRepo.GetCustomField(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "custom field not found")
+		}
+		return nil, err
+	}
+
+	options := field.Options
+	if payload.Options != nil {
+		options = *payload.Options
+	}
+
+	validationRegex := field.ValidationRegex
+	if payload.ValidationRegex != nil {
+		validationRegex = *payload.ValidationRegex
+	}
+
+	if err := validateDefinition(field.Type, options, validationRegex); err != nil {
+		return nil, err
+	}
+
+	return u.customFieldRepo.UpdateCustomField(ctx, userID, id, payload)
+}
+
+func (u *CustomFieldUsecase) DeleteCustomField(ctx context.Context, userID int, id int) error {
+	err := u.customFieldRepo.DeleteCustomField(ctx, userID, id)
+	if errors.Is(err, customErrors.ErrNotFound) {
+		return errors.Wrap(customErrors.ErrNotFound, "custom field not found")
+	}
+
+	return err
+}
+
+// ValidateValues checks custom field values sent for an employee against the
+// organization's definitions. A nil value removes the field. When isCreate is
+// set every required field has to be present.
+func (u *CustomFieldUsecase) ValidateValues(ctx context.Context, userID int, values map[string]any, isCreate bool) error {
+	if len(values) == 0 && !isCreate {
+		return nil
+	}
+
+	fields, err := u.customFieldRepo.GetListCustomField(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	definitions := make(map[string]dto.CustomField, len(fields))
+	for _, field := range fields {
+		definitions[field.Key] = field
+		if _, isSet := values[field.Key]; isCreate && field.Required && !isSet {
+			return errors.Wrap(customErrors.ErrBadRequest, fmt.Sprintf("custom field %s is required", field.Key))
+		}
+	}
+
+	for key, value := range values {
+		field, ok := definitions[key]
+		if !ok {
+			return errors.Wrap(customErrors.ErrBadRequest, fmt.Sprintf("unknown custom field %s", key))
+		}
+
+		if value == nil {
+			if field.Required {
+				return errors.Wrap(customErrors.ErrBadRequest, fmt.Sprintf("custom field %s is required", key))
+			}
+			continue
+		}
+
+		if err := validateValue(&field, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseFilters converts the raw string filters of the employee list into
+// typed values, so they can be matched against the stored JSON.
+func (u *CustomFieldUsecase) ParseFilters(ctx context.Context, userID int, filters map[string]string) (map[string]any, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	fields, err := u.customFieldRepo.GetListCustomField(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := make(map[string]any, len(filters))
+	for key, raw := range filters {
+		idx := slices.IndexFunc(fields, func(field dto.CustomField) bool { return field.Key == key })
+		if idx < 0 {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, fmt.Sprintf("unknown custom field %s", key))
+		}
+
+		value, err := parseFilterValue(fields[idx].Type, raw)
+		if err != nil {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, fmt.Sprintf("invalid filter for custom field %s", key))
+		}
+		parsed[key] = value
+	}
+
+	return parsed, nil
+}
+
+func validateDefinition(fieldType dto.FieldType, options []string, validationRegex string) error {
+	if fieldType == dto.FieldTypeEnum && len(options) == 0 {
+		return errors.Wrap(customErrors.ErrBadRequest, "enum custom field requires options")
+	}
+
+	if fieldType != dto.FieldTypeEnum && len(options) > 0 {
+		return errors.Wrap(customErrors.ErrBadRequest, "options are only allowed for enum custom field")
+	}
+
+	if validationRegex != "" {
+		if fieldType != dto.FieldTypeText {
+			return errors.Wrap(customErrors.ErrBadRequest, "validation regex is only allowed for text custom field")
+		}
+		if _, err := regexp.Compile(validationRegex); err != nil {
+			return errors.Wrap(customErrors.ErrBadRequest, "invalid validation regex")
+		}
+	}
+
+	return nil
+}
+
+func validateValue(field *dto.CustomField, value any) error {
+	invalid := errors.Wrap(customErrors.ErrBadRequest, fmt.Sprintf("invalid value for custom field %s", field.Key))
+
+	switch field.Type {
+	case dto.FieldTypeText:
+		str, ok := value.(string)
+		if !ok {
+			return invalid
+		}
+		if field.ValidationRegex != "" {
+			re, err := regexp.Compile(field.ValidationRegex)
+			if err != nil || !re.MatchString(str) {
+				return invalid
+			}
+		}
+	case dto.FieldTypeNumber:
+		if _, ok := value.(float64); !ok {
+			return invalid
+		}
+	case dto.FieldTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return invalid
+		}
+	case dto.FieldTypeDate:
+		str, ok := value.(string)
+		if !ok {
+			return invalid
+		}
+		if _, err := time.Parse(DATE_LAYOUT, str); err != nil {
+			return invalid
+		}
+	case dto.FieldTypeEnum:
+		str, ok := value.(string)
+		if !ok || !slices.Contains(field.Options, str) {
+			return invalid
+		}
+	}
+
+	return nil
+}
+
+func parseFilterValue(fieldType dto.FieldType, raw string) (any, error) {
+	switch fieldType {
+	case dto.FieldTypeNumber:
+		return strconv.ParseFloat(raw, 64)
+	case dto.FieldTypeBoolean:
+		return strconv.ParseBool(raw)
+	default:
+		return raw, nil
+	}
+}
@@ -1,24 +1,119 @@
 package dto
 
+import "time"
+
 type Department struct {
+	DepartmentId string          `json:"departmentId"`
+	Name         string          `json:"name"`
+	ParentId     *string         `json:"parentId"`
+	Path         []DepartmentRef `json:"path"`
+	// ArchivedAt is set on departments that no longer take new employees.
+	ArchivedAt *time.Time `json:"archivedAt"`
+	// Stats is only filled in when asked for on the list, and always on the
+	// detail endpoint.
+	Stats *DepartmentStats `json:"stats,omitempty"`
+}
+
+// DepartmentStats describes the employees of a department, leaving out
+// candidates and terminated employees. Tenure counts from the first applied
+// move into onboarding or active.
+type DepartmentStats struct {
+	EmployeeCount     int         `json:"employeeCount"`
+	GenderSplit       GenderSplit `json:"genderSplit"`
+	AverageTenureDays *int        `json:"averageTenureDays"`
+	MostRecentHire    *RecentHire `json:"mostRecentHire"`
+}
+
+type GenderSplit struct {
+	Male        int `json:"male"`
+	Female      int `json:"female"`
+	Unspecified int `json:"unspecified"`
+}
+
+type RecentHire struct {
+	IdentityNumber string `json:"identityNumber"`
+	Name           string `json:"name"`
+	HireDate       string `json:"hireDate"`
+}
+
+// DepartmentRef is one step of a department's breadcrumb path, which runs
+// from the top-level department down to the department itself.
+type DepartmentRef struct {
 	DepartmentId string `json:"departmentId"`
 	Name         string `json:"name"`
 }
 
 type CreateDepartmentPayload struct {
-	Name string `json:"name" validate:"required,min=4,max=33"`
+	Name     string `json:"name" validate:"required,min=4,max=33"`
+	ParentId string `json:"parentId" validate:"omitempty,number"`
+}
+
+// MoveDepartmentPayload puts a department under another one, or back at the
+// top level when ParentId is empty.
+type MoveDepartmentPayload struct {
+	ParentId string `json:"parentId" validate:"omitempty,number"`
 }
 
 type GetDepartmentListParams struct {
-	Limit  int
-	Offset int
-	Name   string `query:"name"`
+	Limit      int
+	Offset     int
+	Name       string `query:"name"`
+	ParentId   string `query:"parentId" validate:"omitempty,number"`
+	Sort       string `query:"sort"`
+	Pagination string `query:"pagination" validate:"omitempty,oneof=offset cursor"`
+	Cursor     string `query:"cursor"`
+	WithTotal  bool   `query:"withTotal"`
+	WithStats  bool   `query:"withStats"`
+	// IncludeArchived lists archived departments as well, which are left out
+	// by default.
+	IncludeArchived bool `query:"includeArchived"`
+}
+
+// IsCursorPagination reports whether the client asked for the paginated
+// envelope instead of the plain array returned to older clients.
+func (p *GetDepartmentListParams) IsCursorPagination() bool {
+	return p.Pagination == "cursor" || p.Cursor != ""
 }
 
 type PatchDepartmentPayload struct {
 	Name string `json:"name" validate:"required,omitempty,min=4,max=33"`
 }
 
+// MergeDepartmentPayload merges a department into the target department.
+type MergeDepartmentPayload struct {
+	TargetDepartmentId string `json:"targetDepartmentId" validate:"required,number"`
+	Reason             string `json:"reason" validate:"max=255"`
+}
+
+// SplitDepartmentPayload splits the given employees off into a new
+// department next to the one they are in.
+type SplitDepartmentPayload struct {
+	Name            string   `json:"name" validate:"required,min=4,max=33"`
+	IdentityNumbers []string `json:"identityNumbers" validate:"required,min=1,unique,dive,required"`
+	Reason          string   `json:"reason" validate:"max=255"`
+}
+
+// ReorganizationResult is the department left after a merge or split.
+// MovedHeads counts the heads a merge handed over to the target, a head of
+// both departments is not counted.
+type ReorganizationResult struct {
+	Department     Department `json:"department"`
+	MovedEmployees int        `json:"movedEmployees"`
+	MovedHeads     int        `json:"movedHeads,omitempty"`
+}
+
+type DepartmentHead struct {
+	IdentityNumber string    `json:"identityNumber"`
+	Name           string    `json:"name"`
+	JobTitle       string    `json:"jobTitle"`
+	HasLogin       bool      `json:"hasLogin"`
+	AssignedAt     time.Time `json:"assignedAt"`
+}
+
+type AssignHeadPayload struct {
+	IdentityNumber string `json:"identityNumber" validate:"required"`
+}
+
 type UpdateDeletePathParam struct {
 	IdentityNumber string `param:"identityNumber" validate:"required"`
 }
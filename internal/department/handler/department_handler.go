@@ -74,8 +74,22 @@ func (h DepartmentHandler) GetListDepartment(ctx echo.Context) error {
 		return ctx.JSON(response.WriteErrorResponse(err))
 	}
 
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
 	userData := ctx.Get("user").(*jwt.JwtClaim)
 
+	if payload.IsCursorPagination() {
+		page, err := h.departmentUsecase.GetPageDepartment(ctx.Request().Context(), userData.Id, &payload)
+		if err != nil {
+			return ctx.JSON(response.WriteErrorResponse(err))
+		}
+
+		return ctx.JSON(http.StatusOK, page)
+	}
+
 	departments, err := h.departmentUsecase.GetListDepartment(ctx.Request().Context(), userData.Id, &payload)
 	if err != nil {
 		return ctx.JSON(response.WriteErrorResponse(err))
@@ -88,6 +102,22 @@ func (h DepartmentHandler) GetListDepartment(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, &departments)
 }
 
+func (h DepartmentHandler) GetDepartment(ctx echo.Context) error {
+	id, err := departmentIDParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	department, err := h.departmentUsecase.GetDepartment(ctx.Request().Context(), userData.Id, id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, department)
+}
+
 func (h DepartmentHandler) UpdateDepartment(ctx echo.Context) error {
 	departmentId := ctx.Param("departmentId")
 
@@ -151,3 +181,252 @@ func (h DepartmentHandler) DeleteDepartment(ctx echo.Context) error {
 	})
 
 }
+
+func (h DepartmentHandler) RestoreDepartment(ctx echo.Context) error {
+	departmentId := ctx.Param("departmentId")
+
+	if departmentId == "" {
+		err := errors.Wrap(customErrors.ErrBadRequest, "department id required")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	id, err := strconv.Atoi(departmentId)
+	if err != nil {
+		err = errors.Wrap(customErrors.ErrNotFound, "wrong department id")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	department, err := h.departmentUsecase.RestoreDepartment(ctx.Request().Context(), userData.Id, id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, department)
+}
+
+func (h DepartmentHandler) MoveDepartment(ctx echo.Context) error {
+	departmentId := ctx.Param("departmentId")
+
+	if departmentId == "" {
+		err := errors.Wrap(customErrors.ErrBadRequest, "department id required")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.MoveDepartmentPayload
+
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	id, err := strconv.Atoi(departmentId)
+	if err != nil {
+		err = errors.Wrap(customErrors.ErrNotFound, "wrong department id")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	department, err := h.departmentUsecase.MoveDepartment(ctx.Request().Context(), userData.Id, id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, department)
+}
+
+func (h DepartmentHandler) GetDepartmentSubtree(ctx echo.Context) error {
+	departmentId := ctx.Param("departmentId")
+
+	if departmentId == "" {
+		err := errors.Wrap(customErrors.ErrBadRequest, "department id required")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	id, err := strconv.Atoi(departmentId)
+	if err != nil {
+		err = errors.Wrap(customErrors.ErrNotFound, "wrong department id")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	departments, err := h.departmentUsecase.GetDepartmentSubtree(ctx.Request().Context(), userData.Id, id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, departments)
+}
+
+// departmentIDParam parses the departmentId path parameter.
+func departmentIDParam(ctx echo.Context) (int, error) {
+	departmentId := ctx.Param("departmentId")
+
+	if departmentId == "" {
+		return 0, errors.Wrap(customErrors.ErrBadRequest, "department id required")
+	}
+
+	id, err := strconv.Atoi(departmentId)
+	if err != nil {
+		return 0, errors.Wrap(customErrors.ErrNotFound, "wrong department id")
+	}
+
+	return id, nil
+}
+
+func (h DepartmentHandler) GetDepartmentHeads(ctx echo.Context) error {
+	id, err := departmentIDParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	heads, err := h.departmentUsecase.GetDepartmentHeads(ctx.Request().Context(), userData.Id, id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, heads)
+}
+
+func (h DepartmentHandler) AssignDepartmentHead(ctx echo.Context) error {
+	id, err := departmentIDParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.AssignHeadPayload
+
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	heads, err := h.departmentUsecase.AssignDepartmentHead(ctx.Request().Context(), userData.Id, id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, heads)
+}
+
+func (h DepartmentHandler) RemoveDepartmentHead(ctx echo.Context) error {
+	id, err := departmentIDParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	err = h.departmentUsecase.RemoveDepartmentHead(ctx.Request().Context(), userData.Id, id, ctx.Param("identityNumber"))
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h DepartmentHandler) MergeDepartment(ctx echo.Context) error {
+	id, err := departmentIDParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.MergeDepartmentPayload
+
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	result, err := h.departmentUsecase.MergeDepartment(ctx.Request().Context(), userData.Id, id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h DepartmentHandler) SplitDepartment(ctx echo.Context) error {
+	id, err := departmentIDParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.SplitDepartmentPayload
+
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	result, err := h.departmentUsecase.SplitDepartment(ctx.Request().Context(), userData.Id, id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, result)
+}
+
+func (h DepartmentHandler) ArchiveDepartment(ctx echo.Context) error {
+	id, err := departmentIDParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	department, err := h.departmentUsecase.ArchiveDepartment(ctx.Request().Context(), userData.Id, id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, department)
+}
+
+func (h DepartmentHandler) UnarchiveDepartment(ctx echo.Context) error {
+	id, err := departmentIDParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+
+	department, err := h.departmentUsecase.UnarchiveDepartment(ctx.Request().Context(), userData.Id, id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, department)
+}
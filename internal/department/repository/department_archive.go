@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/department/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	queryArchiveDepartment = `
+	UPDATE departments
+	SET archived_at = NOW()
+	WHERE
+		id = @departmentId
+		AND user_id = @userID
+		AND deleted_at IS NULL
+		AND archived_at IS NULL
+	RETURNING id;`
+	queryUnarchiveDepartment = `
+	UPDATE departments
+	SET archived_at = NULL
+	WHERE
+		id = @departmentId
+		AND user_id = @userID
+		AND deleted_at IS NULL
+		AND archived_at IS NOT NULL
+	RETURNING id;`
+	// Terminated employees may stay in an archived department, as that is
+	// where their records belong.
+	queryCheckIfActiveEmployeeExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND department_id = NULLIF(@id, 0)::bigint
+			AND deleted_at IS NULL
+			AND status <> 'terminated'
+	) is_exists;`
+	queryCheckIfActiveSubDepartmentExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM departments
+		WHERE
+			user_id = @userID
+			AND parent_id = NULLIF(@id, 0)::bigint
+			AND deleted_at IS NULL
+			AND archived_at IS NULL
+	) is_exists;`
+)
+
+func (r *DepartmentRepository) ArchiveDepartment(ctx context.Context, userID int, departmentID int) (*dto.Department, error) {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"departmentId": departmentID,
+	}
+
+	err := r.pool.QueryRow(ctx, queryArchiveDepartment, args).Scan(&departmentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to archive department")
+	}
+
+	return r.GetDepartment(ctx, userID, departmentID)
+}
+
+func (r *DepartmentRepository) UnarchiveDepartment(ctx context.Context, userID int, departmentID int) (*dto.Department, error) {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"departmentId": departmentID,
+	}
+
+	err := r.pool.QueryRow(ctx, queryUnarchiveDepartment, args).Scan(&departmentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unarchive department")
+	}
+
+	return r.GetDepartment(ctx, userID, departmentID)
+}
+
+func (r *DepartmentRepository) CheckIfActiveEmployeeExist(ctx context.Context, userID int, departmentID int) (bool, error) {
+	var isExist bool
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"id":     departmentID,
+	}
+	err := r.pool.QueryRow(ctx, queryCheckIfActiveEmployeeExists, args).Scan(&isExist)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check is employee exists")
+	}
+	return isExist, nil
+}
+
+func (r *DepartmentRepository) CheckIfActiveSubDepartmentExist(ctx context.Context, userID int, departmentID int) (bool, error) {
+	var isExist bool
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"id":     departmentID,
+	}
+	err := r.pool.QueryRow(ctx, queryCheckIfActiveSubDepartmentExists, args).Scan(&isExist)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check is sub-department exists")
+	}
+	return isExist, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/department/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+const (
+	queryCheckIfIdentityNumberExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+	) is_exists;`
+	queryCheckIfDepartmentHead = `
+	SELECT EXISTS (
+		SELECT 1
+		FROM department_heads
+		JOIN employees ON employees.id = department_heads.employee_id
+		WHERE
+			department_heads.department_id = @departmentID
+			AND employees.user_id = @userID
+			AND employees.identity_number = @identityNumber
+			AND employees.deleted_at IS NULL
+	) is_head;`
+	queryGetDepartmentHeads = `
+	SELECT
+		employees.identity_number,
+		employees.name,
+		employees.job_title,
+		manager_logins.employee_id IS NOT NULL,
+		department_heads.created_at
+	FROM department_heads
+	JOIN employees ON employees.id = department_heads.employee_id
+	LEFT JOIN manager_logins ON manager_logins.employee_id = employees.id
+	WHERE
+		department_heads.department_id = @departmentID
+		AND employees.user_id = @userID
+		AND employees.deleted_at IS NULL
+	ORDER BY department_heads.created_at, employees.name;`
+	queryAssignDepartmentHead = `
+	INSERT INTO department_heads (department_id, employee_id)
+	SELECT @departmentID, employees.id
+	FROM employees
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL
+	ON CONFLICT DO NOTHING;`
+	queryRemoveDepartmentHead = `
+	DELETE FROM department_heads
+	USING employees
+	WHERE
+		employees.id = department_heads.employee_id
+		AND department_heads.department_id = @departmentID
+		AND employees.user_id = @userID
+		AND employees.identity_number = @identityNumber;`
+)
+
+func (r *DepartmentRepository) CheckIfIdentityNumberExist(ctx context.Context, userID int, identityNumber string) (bool, error) {
+	var isExist bool
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+	err := r.pool.QueryRow(ctx, queryCheckIfIdentityNumberExists, args).Scan(&isExist)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check is employee exists")
+	}
+	return isExist, nil
+}
+
+func (r *DepartmentRepository) CheckIfDepartmentHead(ctx context.Context, userID int, departmentID int, identityNumber string) (bool, error) {
+	var isHead bool
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"departmentID":   departmentID,
+		"identityNumber": identityNumber,
+	}
+	err := r.pool.QueryRow(ctx, queryCheckIfDepartmentHead, args).Scan(&isHead)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check is department head")
+	}
+	return isHead, nil
+}
+
+func (r *DepartmentRepository) GetDepartmentHeads(ctx context.Context, userID int, departmentID int) ([]dto.DepartmentHead, error) {
+	heads := make([]dto.DepartmentHead, 0)
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"departmentID": departmentID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetDepartmentHeads, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get department heads")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var head dto.DepartmentHead
+		jobTitle := new(pgtype.Text)
+
+		err := rows.Scan(
+			&head.IdentityNumber,
+			&head.Name,
+			jobTitle,
+			&head.HasLogin,
+			&head.AssignedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		head.JobTitle = jobTitle.String
+		heads = append(heads, head)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get department heads")
+	}
+
+	return heads, nil
+}
+
+func (r *DepartmentRepository) AssignDepartmentHead(ctx context.Context, userID int, departmentID int, identityNumber string) error {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"departmentID":   departmentID,
+		"identityNumber": identityNumber,
+	}
+
+	if _, err := r.pool.Exec(ctx, queryAssignDepartmentHead, args); err != nil {
+		return errors.Wrap(err, "failed to assign department head")
+	}
+
+	return nil
+}
+
+// RemoveDepartmentHead reports whether the employee was a head of the
+// department.
+func (r *DepartmentRepository) RemoveDepartmentHead(ctx context.Context, userID int, departmentID int, identityNumber string) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"departmentID":   departmentID,
+		"identityNumber": identityNumber,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryRemoveDepartmentHead, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to remove department head")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
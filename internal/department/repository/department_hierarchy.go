@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/department/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrParentNotFound is returned by MoveDepartment when the new parent is
+	// not an active department of the user.
+	ErrParentNotFound = errors.New("parent department not found")
+	// ErrParentIsDescendant is returned by MoveDepartment when the new parent
+	// sits under the department, which would turn the hierarchy into a loop.
+	ErrParentIsDescendant = errors.New("parent department is a sub-department")
+	// ErrParentTrashed is returned by RestoreDepartment while the parent of
+	// the department is still in the trash.
+	ErrParentTrashed = errors.New("parent department is in trash")
+)
+
+const (
+	// Moves of one user are serialized on the user row, so two moves running
+	// side by side cannot close a loop the other one did not see.
+	queryLockDepartmentHierarchy = `
+	SELECT id
+	FROM users
+	WHERE id = @userID
+	FOR UPDATE;`
+	queryCheckIfDescendant = `
+	WITH RECURSIVE descendants AS (
+		SELECT id, ARRAY[id] path
+		FROM departments
+		WHERE
+			user_id = @userID
+			AND id = @id
+			AND deleted_at IS NULL
+		UNION ALL
+		SELECT departments.id, descendants.path || departments.id
+		FROM departments
+		JOIN descendants ON departments.parent_id = descendants.id
+		WHERE
+			departments.deleted_at IS NULL
+			AND NOT departments.id = ANY(descendants.path)
+	)
+	SELECT EXISTS (
+		SELECT 1
+		FROM descendants
+		WHERE id = NULLIF(@parentID, '')::bigint
+	) is_descendant;`
+	queryMoveDepartment = `
+	UPDATE departments
+	SET parent_id = NULLIF(@parentID, '')::bigint
+	WHERE
+		id = @id
+		AND user_id = @userID
+		AND deleted_at IS NULL
+	RETURNING id;`
+	// The subtree is returned depth first, each department right before the
+	// departments under it.
+	queryGetDepartmentSubtree = departmentPaths + `,
+	subtree AS (
+		SELECT id, ARRAY[id] visited
+		FROM departments
+		WHERE
+			user_id = @userID
+			AND id = @id
+			AND deleted_at IS NULL
+		UNION ALL
+		SELECT departments.id, subtree.visited || departments.id
+		FROM departments
+		JOIN subtree ON departments.parent_id = subtree.id
+		WHERE
+			departments.deleted_at IS NULL
+			AND NOT departments.id = ANY(subtree.visited)
+	)
+	SELECT` + departmentColumns + `
+	FROM subtree
+	JOIN departments ON departments.id = subtree.id
+	LEFT JOIN paths ON paths.id = departments.id
+	ORDER BY subtree.visited;`
+)
+
+// MoveDepartment puts the department under parentID, or at the top level
+// when parentID is empty.
+func (r *DepartmentRepository) MoveDepartment(ctx context.Context, userID int, departmentID int, parentID string) (*dto.Department, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"userID":   userID,
+		"id":       departmentID,
+		"parentID": parentID,
+	}
+
+	if _, err := tx.Exec(ctx, queryLockDepartmentHierarchy, args); err != nil {
+		return nil, errors.Wrap(err, "failed to lock department hierarchy")
+	}
+
+	if parentID != "" {
+		var isExist, isDescendant bool
+		parentArgs := pgx.NamedArgs{
+			"userID": userID,
+			"id":     parentID,
+		}
+		if err := tx.QueryRow(ctx, queryCheckIsDepartmentExist, parentArgs).Scan(&isExist); err != nil {
+			return nil, errors.Wrap(err, "failed to check is department exists")
+		}
+		if !isExist {
+			return nil, ErrParentNotFound
+		}
+
+		if err := tx.QueryRow(ctx, queryCheckIfDescendant, args).Scan(&isDescendant); err != nil {
+			return nil, errors.Wrap(err, "failed to check department hierarchy")
+		}
+		if isDescendant {
+			return nil, ErrParentIsDescendant
+		}
+	}
+
+	if err := tx.QueryRow(ctx, queryMoveDepartment, args).Scan(&departmentID); err != nil {
+		return nil, errors.Wrap(err, "failed to move department")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit department move")
+	}
+
+	return r.GetDepartment(ctx, userID, departmentID)
+}
+
+// GetDepartmentSubtree returns the department and every department nested
+// under it.
+func (r *DepartmentRepository) GetDepartmentSubtree(ctx context.Context, userID int, departmentID int) ([]dto.Department, error) {
+	departments := make([]dto.Department, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"id":     departmentID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetDepartmentSubtree, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get department subtree")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		department, err := scanDepartment(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		departments = append(departments, *department)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get department subtree")
+	}
+
+	return departments, nil
+}
@@ -0,0 +1,356 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/department/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrDepartmentNotFound is returned by MergeDepartment and
+	// SplitDepartment when a department was trashed in the meantime.
+	ErrDepartmentNotFound = errors.New("department not found")
+	// ErrTargetIsDescendant is returned by MergeDepartment when the target
+	// sits under the merged department, which would leave its
+	// sub-departments under themselves.
+	ErrTargetIsDescendant = errors.New("target department is a sub-department")
+	// ErrEmployeesChanged is returned by SplitDepartment when some of the
+	// employees left the department in the meantime.
+	ErrEmployeesChanged = errors.New("employees changed")
+)
+
+const (
+	queryCountActiveDepartments = `
+	SELECT COUNT(*)
+	FROM departments
+	WHERE
+		user_id = @userID
+		AND id = ANY(@ids::bigint[])
+		AND deleted_at IS NULL;`
+	// Without identity numbers every employee of the department moves,
+	// trashed ones included, so that nothing is left behind in it, and keeps
+	// the position that moves along. Employees split off leave their
+	// position behind. Every move is recorded like a transfer, in the
+	// department history and in the employee history.
+	queryMoveDepartmentEmployees = `
+	WITH moved AS (
+		UPDATE employees
+		SET
+			department_id = @toDepartmentID,
+			position_id = CASE WHEN cardinality(@identityNumbers::text[]) = 0 THEN position_id END
+		WHERE
+			user_id = @userID
+			AND department_id = @fromDepartmentID
+			AND (
+				cardinality(@identityNumbers::text[]) = 0
+				OR (identity_number = ANY(@identityNumbers::text[]) AND deleted_at IS NULL)
+			)
+		RETURNING id
+	),
+	assignments AS (
+		INSERT INTO employee_department_assignments(
+			employee_id,
+			from_department_id,
+			to_department_id,
+			effective_date,
+			reason,
+			changed_by,
+			applied_at
+		)
+		SELECT moved.id, @fromDepartmentID, @toDepartmentID, CURRENT_DATE, NULLIF(@reason, ''), @userID, NOW()
+		FROM moved
+	),
+	history AS (
+		INSERT INTO employee_history(employee_id, version, field, old_value, new_value, changed_by)
+		SELECT
+			moved.id,
+			COALESCE(MAX(employee_history.version), 0) + 1,
+			'departmentId',
+			@fromDepartmentID::text,
+			@toDepartmentID::text,
+			@userID
+		FROM moved
+		LEFT JOIN employee_history ON employee_history.employee_id = moved.id
+		GROUP BY moved.id
+	)
+	SELECT COUNT(*) FROM moved;`
+	// Pending transfers from or to the merged department follow it into the
+	// target, and the ones left going nowhere are cancelled.
+	queryRetargetPendingTransfers = `
+	WITH retargeted AS (
+		UPDATE employee_department_assignments
+		SET
+			from_department_id = CASE
+				WHEN from_department_id = @fromDepartmentID THEN @toDepartmentID
+				ELSE from_department_id
+			END,
+			to_department_id = CASE
+				WHEN to_department_id = @fromDepartmentID THEN @toDepartmentID
+				ELSE to_department_id
+			END
+		WHERE
+			applied_at IS NULL
+			AND cancelled_at IS NULL
+			AND (from_department_id = @fromDepartmentID OR to_department_id = @fromDepartmentID)
+		RETURNING id, from_department_id, to_department_id
+	)
+	UPDATE employee_department_assignments
+	SET cancelled_at = NOW()
+	FROM retargeted
+	WHERE
+		employee_department_assignments.id = retargeted.id
+		AND retargeted.from_department_id = retargeted.to_department_id;`
+	queryMovePositions = `
+	UPDATE positions
+	SET
+		department_id = @toDepartmentID,
+		updated_at = NOW()
+	WHERE
+		user_id = @userID
+		AND department_id = @fromDepartmentID;`
+	// Heads of the merged department become heads of the target, so their
+	// manager logins keep reaching the employees they managed.
+	queryMoveDepartmentHeads = `
+	WITH
+	moved AS (
+		INSERT INTO department_heads (department_id, employee_id)
+		SELECT @toDepartmentID, employee_id
+		FROM department_heads
+		WHERE department_id = @fromDepartmentID
+		ON CONFLICT DO NOTHING
+		RETURNING employee_id
+	),
+	removed AS (
+		DELETE FROM department_heads
+		WHERE department_id = @fromDepartmentID
+	),
+	history AS (
+		INSERT INTO employee_history(employee_id, version, field, old_value, new_value, changed_by)
+		SELECT
+			moved.employee_id,
+			COALESCE(MAX(employee_history.version), 0) + 1,
+			'headOfDepartmentId',
+			@fromDepartmentID::text,
+			@toDepartmentID::text,
+			@userID
+		FROM moved
+		LEFT JOIN employee_history ON employee_history.employee_id = moved.employee_id
+		GROUP BY moved.employee_id
+	)
+	SELECT COUNT(*) FROM moved;`
+	queryReparentSubDepartments = `
+	UPDATE departments
+	SET parent_id = @toDepartmentID
+	WHERE
+		user_id = @userID
+		AND parent_id = @fromDepartmentID;`
+	queryTrashMergedDepartment = `
+	UPDATE departments
+	SET deleted_at = NOW()
+	WHERE
+		id = @fromDepartmentID
+		AND user_id = @userID;`
+	querySplitDepartment = `
+	INSERT INTO departments (name, parent_id, user_id)
+	SELECT @name, parent_id, user_id
+	FROM departments
+	WHERE
+		id = @fromDepartmentID
+		AND user_id = @userID
+	RETURNING id;`
+	queryGetEmployeesNotInDepartment = `
+	SELECT requested.identity_number
+	FROM unnest(@identityNumbers::text[]) requested(identity_number)
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM employees
+		WHERE
+			employees.user_id = @userID
+			AND employees.department_id = @departmentID
+			AND employees.identity_number = requested.identity_number
+			AND employees.deleted_at IS NULL
+	)
+	ORDER BY requested.identity_number;`
+)
+
+// MergeDepartment moves every employee, position, head and sub-department of
+// the department into the target and trashes the department, all in one
+// transaction.
+func (r *DepartmentRepository) MergeDepartment(ctx context.Context, userID int, departmentID int, targetID int, reason string) (*dto.ReorganizationResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.lockReorganization(ctx, tx, userID, departmentID, targetID); err != nil {
+		return nil, err
+	}
+
+	var isDescendant bool
+	descendantArgs := pgx.NamedArgs{
+		"userID":   userID,
+		"id":       departmentID,
+		"parentID": targetID,
+	}
+	if err := tx.QueryRow(ctx, queryCheckIfDescendant, descendantArgs).Scan(&isDescendant); err != nil {
+		return nil, errors.Wrap(err, "failed to check department hierarchy")
+	}
+	if isDescendant {
+		return nil, ErrTargetIsDescendant
+	}
+
+	args := pgx.NamedArgs{
+		"userID":           userID,
+		"fromDepartmentID": departmentID,
+		"toDepartmentID":   targetID,
+		"identityNumbers":  []string{},
+		"reason":           reason,
+	}
+
+	var result dto.ReorganizationResult
+	if err := tx.QueryRow(ctx, queryMoveDepartmentEmployees, args).Scan(&result.MovedEmployees); err != nil {
+		return nil, errors.Wrap(err, "failed to move department employees")
+	}
+
+	if _, err := tx.Exec(ctx, queryRetargetPendingTransfers, args); err != nil {
+		return nil, errors.Wrap(err, "failed to retarget pending department transfers")
+	}
+
+	if _, err := tx.Exec(ctx, queryMovePositions, args); err != nil {
+		return nil, errors.Wrap(err, "failed to move positions")
+	}
+
+	if err := tx.QueryRow(ctx, queryMoveDepartmentHeads, args).Scan(&result.MovedHeads); err != nil {
+		return nil, errors.Wrap(err, "failed to move department heads")
+	}
+
+	if _, err := tx.Exec(ctx, queryReparentSubDepartments, args); err != nil {
+		return nil, errors.Wrap(err, "failed to move sub-departments")
+	}
+
+	if _, err := tx.Exec(ctx, queryTrashMergedDepartment, args); err != nil {
+		return nil, errors.Wrap(err, "failed to delete merged department")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit department merge")
+	}
+
+	department, err := r.GetDepartment(ctx, userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Department = *department
+	return &result, nil
+}
+
+// SplitDepartment creates a department next to the given one and moves the
+// employees into it, all in one transaction.
+func (r *DepartmentRepository) SplitDepartment(ctx context.Context, userID int, departmentID int, payload *dto.SplitDepartmentPayload, reason string) (*dto.ReorganizationResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.lockReorganization(ctx, tx, userID, departmentID); err != nil {
+		return nil, err
+	}
+
+	var newDepartmentID int
+	args := pgx.NamedArgs{
+		"userID":           userID,
+		"fromDepartmentID": departmentID,
+		"name":             payload.Name,
+	}
+	if err := tx.QueryRow(ctx, querySplitDepartment, args).Scan(&newDepartmentID); err != nil {
+		return nil, errors.Wrap(err, "failed to create department")
+	}
+
+	args = pgx.NamedArgs{
+		"userID":           userID,
+		"fromDepartmentID": departmentID,
+		"toDepartmentID":   newDepartmentID,
+		"identityNumbers":  payload.IdentityNumbers,
+		"reason":           reason,
+	}
+
+	var result dto.ReorganizationResult
+	if err := tx.QueryRow(ctx, queryMoveDepartmentEmployees, args).Scan(&result.MovedEmployees); err != nil {
+		return nil, errors.Wrap(err, "failed to move department employees")
+	}
+
+	if result.MovedEmployees != len(payload.IdentityNumbers) {
+		return nil, ErrEmployeesChanged
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit department split")
+	}
+
+	department, err := r.GetDepartment(ctx, userID, newDepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Department = *department
+	return &result, nil
+}
+
+// lockReorganization takes the same lock as MoveDepartment, then makes sure
+// the departments are still active.
+func (r *DepartmentRepository) lockReorganization(ctx context.Context, tx pgx.Tx, userID int, departmentIDs ...int) error {
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"ids":    departmentIDs,
+	}
+
+	if _, err := tx.Exec(ctx, queryLockDepartmentHierarchy, args); err != nil {
+		return errors.Wrap(err, "failed to lock department hierarchy")
+	}
+
+	var count int
+	if err := tx.QueryRow(ctx, queryCountActiveDepartments, args).Scan(&count); err != nil {
+		return errors.Wrap(err, "failed to check is department exists")
+	}
+	if count != len(departmentIDs) {
+		return ErrDepartmentNotFound
+	}
+
+	return nil
+}
+
+// GetEmployeesNotInDepartment returns the identity numbers that do not
+// belong to an active employee of the department.
+func (r *DepartmentRepository) GetEmployeesNotInDepartment(ctx context.Context, userID int, departmentID int, identityNumbers []string) ([]string, error) {
+	missing := make([]string, 0)
+	args := pgx.NamedArgs{
+		"userID":          userID,
+		"departmentID":    departmentID,
+		"identityNumbers": identityNumbers,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetEmployeesNotInDepartment, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check department employees")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var identityNumber string
+		if err := rows.Scan(&identityNumber); err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		missing = append(missing, identityNumber)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to check department employees")
+	}
+
+	return missing, nil
+}
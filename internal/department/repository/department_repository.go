@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"ps-gogo-manajer/internal/department/dto"
+	"ps-gogo-manajer/pkg/pagination"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -17,26 +18,95 @@ func NewDepartmentRepository(pool *pgxpool.Pool) *DepartmentRepository {
 	return &DepartmentRepository{pool: pool}
 }
 
+// DepartmentSortColumns whitelists the values accepted by the sort parameter
+// of the department list. Prefix a key with "-" to sort descending.
+var DepartmentSortColumns = map[string]pagination.Column{
+	"id":   {Expr: "departments.id", Type: "bigint"},
+	"name": {Expr: "departments.name", Type: "text"},
+}
+
 const (
+	// departmentPaths builds the breadcrumb path of every department of the
+	// user. A department under a trashed parent starts a path of its own.
+	departmentPaths = `
+	WITH RECURSIVE paths AS (
+		SELECT
+			departments.id,
+			jsonb_build_array(jsonb_build_object('departmentId', departments.id::text, 'name', departments.name)) path,
+			ARRAY[departments.id] visited
+		FROM departments
+		LEFT JOIN departments parent ON parent.id = departments.parent_id AND parent.deleted_at IS NULL
+		WHERE
+			departments.user_id = @userID
+			AND departments.deleted_at IS NULL
+			AND parent.id IS NULL
+		UNION ALL
+		SELECT
+			departments.id,
+			paths.path || jsonb_build_object('departmentId', departments.id::text, 'name', departments.name),
+			paths.visited || departments.id
+		FROM departments
+		JOIN paths ON departments.parent_id = paths.id
+		WHERE
+			departments.deleted_at IS NULL
+			AND NOT departments.id = ANY(paths.visited)
+	)`
+	departmentColumns = `
+		departments.id,
+		departments.name,
+		departments.parent_id::text,
+		COALESCE(paths.path, '[]'::jsonb),
+		departments.archived_at`
+
 	queryCreateDepartment = `
 	INSERT INTO departments
 	(
 		name,
+		parent_id,
 		user_id
 	)
-	VALUES (@name,@userID)
-	RETURNING id,name`
-
-	queryGetListDepartment = `
-	SELECT
-		id,
-		name
+	VALUES (@name,NULLIF(@parentID, '')::bigint,@userID)
+	RETURNING id`
+	queryGetDepartment = departmentPaths + `
+	SELECT` + departmentColumns + `
 	FROM departments
+	LEFT JOIN paths ON paths.id = departments.id
 	WHERE
-		user_id = @userID
-		AND (NULLIF(@name, '') is NULL OR name ILIKE '%' || NULLIF(@name, '') || '%' )
+		departments.user_id = @userID
+		AND departments.id = @id
+		AND departments.deleted_at IS NULL;`
+
+	departmentListFilter = `
+		departments.user_id = @userID
+		AND departments.deleted_at IS NULL
+		AND (@includeArchived::boolean OR departments.archived_at IS NULL)
+		AND (NULLIF(@name, '') is NULL OR departments.name ILIKE '%' || NULLIF(@name, '') || '%' )
+		AND (NULLIF(@parentID, '') is NULL OR departments.parent_id = NULLIF(@parentID, '')::bigint)`
+
+	// The placeholder comments in the list queries below are filled in by
+	// pagination.Sort.Query from a whitelisted sort column.
+	queryGetListDepartment = departmentPaths + `
+	SELECT` + departmentColumns + `
+	FROM departments
+	LEFT JOIN paths ON paths.id = departments.id
+	WHERE` + departmentListFilter + `
+	ORDER BY /*orderBy*/
 	OFFSET @offset
 	LIMIT @limit;`
+	queryGetPageDepartment = departmentPaths + `
+	SELECT
+		(/*sortValue*/)::text,
+		departments.id,` + departmentColumns + `
+	FROM departments
+	LEFT JOIN paths ON paths.id = departments.id
+	WHERE` + departmentListFilter + `
+		AND /*keyset*/
+	ORDER BY /*orderBy*/
+	LIMIT @limit;`
+	queryCountDepartment = `
+	SELECT COUNT(*)
+	FROM departments
+	WHERE` + departmentListFilter + `;`
 
 	queryUpdateDepartment = `
 	WITH
@@ -57,9 +127,9 @@ const (
 	FROM payload
 	WHERE
 		departments.id = @id
-	RETURNING
-	departments.id,
-	departments.name;`
+		AND departments.user_id = @userID
+		AND departments.deleted_at IS NULL
+	RETURNING departments.id;`
 
 	queryCheckIsDepartmentExist = `
 	SELECT EXISTS (
@@ -68,91 +138,219 @@ const (
 		WHERE
 			user_id = @userID
 			AND id = NULLIF(@id, 0)::bigint
+			AND deleted_at IS NULL
 	) is_exists;`
 
 	queryDeleteDepartment = `
-	DELETE FROM departments WHERE id = @departmentId;
-	`
+	UPDATE departments
+	SET deleted_at = NOW()
+	WHERE
+		id = @departmentId
+		AND user_id = @userID
+		AND deleted_at IS NULL;`
 	queryCheckIfEmployeeExists = `
 	SELECT EXISTS (
 		SELECT id
 		FROM employees
 		WHERE
 			user_id = @userID
-			AND id = NULLIF(@id, 0)::bigint
+			AND department_id = NULLIF(@id, 0)::bigint
+			AND deleted_at IS NULL
 	) is_exists;`
+	queryCheckIfSubDepartmentExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM departments
+		WHERE
+			user_id = @userID
+			AND parent_id = NULLIF(@id, 0)::bigint
+			AND deleted_at IS NULL
+	) is_exists;`
+	// A department is only restored under an active parent, so it never
+	// shows up below a department that is hidden in the trash.
+	queryRestoreDepartment = `
+	WITH
+	trashed AS (
+		SELECT
+			departments.id,
+			parent.deleted_at IS NOT NULL is_parent_trashed
+		FROM departments
+		LEFT JOIN departments parent ON parent.id = departments.parent_id
+		WHERE
+			departments.id = @departmentId
+			AND departments.user_id = @userID
+			AND departments.deleted_at IS NOT NULL
+	),
+	restored AS (
+		UPDATE departments
+		SET deleted_at = NULL
+		FROM trashed
+		WHERE
+			departments.id = trashed.id
+			AND NOT trashed.is_parent_trashed
+	)
+	SELECT is_parent_trashed
+	FROM trashed;`
 )
 
 func (r *DepartmentRepository) CreateDepartment(ctx context.Context, userID int, payload *dto.CreateDepartmentPayload) (*dto.Department, error) {
-	var department dto.Department
+	var departmentID int
 
 	args := pgx.NamedArgs{
-		"name":   payload.Name,
+		"name":     payload.Name,
+		"parentID": payload.ParentId,
+		"userID":   userID,
+	}
+
+	err := r.pool.QueryRow(ctx, queryCreateDepartment, args).Scan(&departmentID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create department")
+	}
+
+	return r.GetDepartment(ctx, userID, departmentID)
+}
+
+func (r *DepartmentRepository) GetDepartment(ctx context.Context, userID int, departmentID int) (*dto.Department, error) {
+	args := pgx.NamedArgs{
 		"userID": userID,
+		"id":     departmentID,
+	}
+
+	department, err := scanDepartment(r.pool.QueryRow(ctx, queryGetDepartment, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get department")
 	}
 
-	err := r.pool.QueryRow(ctx, queryCreateDepartment, args).Scan(
+	return department, nil
+}
+
+// scanDepartment scans departmentColumns into a department. Columns the
+// query selects before them are scanned into leading.
+func scanDepartment(row pgx.Row, leading ...any) (*dto.Department, error) {
+	var department dto.Department
+
+	dest := append(leading,
 		&department.DepartmentId,
 		&department.Name,
+		&department.ParentId,
+		&department.Path,
+		&department.ArchivedAt,
 	)
-
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create department")
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
 	}
 
 	return &department, nil
 }
 
-func (r *DepartmentRepository) GetListDepartment(ctx context.Context, userID int, payload *dto.GetDepartmentListParams) (*[]dto.Department, error) {
+func (r *DepartmentRepository) GetListDepartment(ctx context.Context, userID int, payload *dto.GetDepartmentListParams, sort *pagination.Sort) (*[]dto.Department, error) {
 
 	var departments []dto.Department
 
 	args := pgx.NamedArgs{
-		"userID": userID,
-		"name":   payload.Name,
-		"limit":  payload.Limit,
-		"offset": payload.Offset,
+		"userID":          userID,
+		"name":            payload.Name,
+		"parentID":        payload.ParentId,
+		"includeArchived": payload.IncludeArchived,
+		"limit":           payload.Limit,
+		"offset":          payload.Offset,
 	}
 
-	rows, err := r.pool.Query(ctx, queryGetListDepartment, args)
+	query := sort.Query(queryGetListDepartment, "departments.id", nil)
+	rows, err := r.pool.Query(ctx, query, args)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get list department")
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		department := dto.Department{}
-		err := rows.Scan(
-			&department.DepartmentId,
-			&department.Name,
-		)
+		department, err := scanDepartment(rows)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to parse sql response")
 		}
-		departments = append(departments, department)
+		departments = append(departments, *department)
 	}
 
 	return &departments, nil
 }
 
-func (r *DepartmentRepository) UpdateDepartment(ctx context.Context, userID int, departmentId int, payload *dto.PatchDepartmentPayload) (*dto.Department, error) {
+// GetPageDepartment reads one keyset page of the department list starting
+// after the cursor, or the first page when cursor is nil.
+func (r *DepartmentRepository) GetPageDepartment(ctx context.Context, userID int, payload *dto.GetDepartmentListParams, sort *pagination.Sort, cursor *pagination.Cursor) (*pagination.Page[dto.Department], error) {
+	var rows []pagination.Row[dto.Department]
 
-	var department dto.Department
+	// * Fetch one extra row to know whether there is another page
+	args := pgx.NamedArgs{
+		"userID":          userID,
+		"name":            payload.Name,
+		"parentID":        payload.ParentId,
+		"includeArchived": payload.IncludeArchived,
+		"limit":           payload.Limit + 1,
+	}
+
+	if cursor != nil {
+		args["cursorValue"] = cursor.Value
+		args["cursorID"] = cursor.ID
+	}
+
+	query := sort.Query(queryGetPageDepartment, "departments.id", cursor)
+	result, err := r.pool.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list department")
+	}
+	defer result.Close()
+
+	for result.Next() {
+		var row pagination.Row[dto.Department]
+		department, err := scanDepartment(result, &row.SortValue, &row.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		row.Item = *department
+		rows = append(rows, row)
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get list department")
+	}
+
+	return pagination.NewPage(rows, payload.Limit, sort, cursor), nil
+}
+
+func (r *DepartmentRepository) CountDepartment(ctx context.Context, userID int, payload *dto.GetDepartmentListParams) (int, error) {
+	var total int
 
 	args := pgx.NamedArgs{
-		"name": payload.Name,
-		"id":   departmentId,
+		"userID":          userID,
+		"name":            payload.Name,
+		"parentID":        payload.ParentId,
+		"includeArchived": payload.IncludeArchived,
 	}
 
-	err := r.pool.QueryRow(ctx, queryUpdateDepartment, args).Scan(
-		&department.DepartmentId,
-		&department.Name,
-	)
+	err := r.pool.QueryRow(ctx, queryCountDepartment, args).Scan(&total)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count department")
+	}
+
+	return total, nil
+}
+
+func (r *DepartmentRepository) UpdateDepartment(ctx context.Context, userID int, departmentId int, payload *dto.PatchDepartmentPayload) (*dto.Department, error) {
+
+	args := pgx.NamedArgs{
+		"name":   payload.Name,
+		"id":     departmentId,
+		"userID": userID,
+	}
+
+	err := r.pool.QueryRow(ctx, queryUpdateDepartment, args).Scan(&departmentId)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update departments")
 	}
 
-	return &department, nil
+	return r.GetDepartment(ctx, userID, departmentId)
 }
 
 func (r *DepartmentRepository) CheckIfDepartmentExist(ctx context.Context, UserID int, departmentID int) (bool, error) {
@@ -172,14 +370,28 @@ func (r *DepartmentRepository) CheckIfEmployeeExist(ctx context.Context, UserID
 	var isExist bool
 	args := pgx.NamedArgs{
 		"userID": UserID,
+		"id":     departmentID,
 	}
-	err := r.pool.QueryRow(ctx, queryCheckIsDepartmentExist, args).Scan(&isExist)
+	err := r.pool.QueryRow(ctx, queryCheckIfEmployeeExists, args).Scan(&isExist)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check is employee exists")
 	}
 	return isExist, nil
 }
 
+func (r *DepartmentRepository) CheckIfSubDepartmentExist(ctx context.Context, userID int, departmentID int) (bool, error) {
+	var isExist bool
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"id":     departmentID,
+	}
+	err := r.pool.QueryRow(ctx, queryCheckIfSubDepartmentExists, args).Scan(&isExist)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check is sub-department exists")
+	}
+	return isExist, nil
+}
+
 func (r *DepartmentRepository) DeleteDepartment(ctx context.Context, userID int, departmentID int) error {
 	args := pgx.NamedArgs{
 		"userID":       userID,
@@ -192,3 +404,22 @@ func (r *DepartmentRepository) DeleteDepartment(ctx context.Context, userID int,
 	}
 	return nil
 }
+
+func (r *DepartmentRepository) RestoreDepartment(ctx context.Context, userID int, departmentID int) (*dto.Department, error) {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"departmentId": departmentID,
+	}
+
+	var isParentTrashed bool
+	err := r.pool.QueryRow(ctx, queryRestoreDepartment, args).Scan(&isParentTrashed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to restore department")
+	}
+
+	if isParentTrashed {
+		return nil, ErrParentTrashed
+	}
+
+	return r.GetDepartment(ctx, userID, departmentID)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/department/dto"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+const (
+	// The stats of all the departments asked for are aggregated in one go,
+	// so a page of departments costs a single query however long it is.
+	// Candidates are not hired yet, and an employee is hired on the first
+	// applied move into onboarding or active.
+	queryGetDepartmentStats = `
+	WITH members AS (
+		SELECT
+			employees.department_id,
+			employees.identity_number,
+			employees.name,
+			employees.gender,
+			COALESCE(
+				(
+					SELECT MIN(employee_status_transitions.effective_date)
+					FROM employee_status_transitions
+					WHERE
+						employee_status_transitions.employee_id = employees.id
+						AND employee_status_transitions.to_status IN ('onboarding', 'active')
+						AND employee_status_transitions.applied_at IS NOT NULL
+				),
+				employees.status_effective_date
+			) hire_date
+		FROM employees
+		WHERE
+			employees.user_id = @userID
+			AND employees.department_id = ANY(@departmentIDs::bigint[])
+			AND employees.deleted_at IS NULL
+			AND employees.status NOT IN ('candidate', 'terminated')
+	),
+	recent AS (
+		SELECT DISTINCT ON (department_id)
+			department_id,
+			identity_number,
+			name,
+			hire_date
+		FROM members
+		ORDER BY department_id, hire_date DESC, identity_number
+	)
+	SELECT
+		members.department_id::text,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE members.gender = 'male'),
+		COUNT(*) FILTER (WHERE members.gender = 'female'),
+		COUNT(*) FILTER (WHERE members.gender IS NULL),
+		ROUND(AVG(CURRENT_DATE - members.hire_date))::int,
+		recent.identity_number,
+		recent.name,
+		recent.hire_date::text
+	FROM members
+	JOIN recent ON recent.department_id = members.department_id
+	GROUP BY
+		members.department_id,
+		recent.identity_number,
+		recent.name,
+		recent.hire_date;`
+)
+
+// GetDepartmentStats returns the stats of every department given, keyed by
+// department id. Departments without employees get empty stats.
+func (r *DepartmentRepository) GetDepartmentStats(ctx context.Context, userID int, departmentIDs []string) (map[string]*dto.DepartmentStats, error) {
+	stats := make(map[string]*dto.DepartmentStats, len(departmentIDs))
+	ids := make([]int64, 0, len(departmentIDs))
+	for _, departmentID := range departmentIDs {
+		id, err := strconv.ParseInt(departmentID, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid department id")
+		}
+		ids = append(ids, id)
+		stats[departmentID] = &dto.DepartmentStats{}
+	}
+
+	args := pgx.NamedArgs{
+		"userID":        userID,
+		"departmentIDs": ids,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetDepartmentStats, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get department stats")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var departmentID string
+		var departmentStats dto.DepartmentStats
+		averageTenureDays := new(pgtype.Int4)
+		var recentHire dto.RecentHire
+
+		err := rows.Scan(
+			&departmentID,
+			&departmentStats.EmployeeCount,
+			&departmentStats.GenderSplit.Male,
+			&departmentStats.GenderSplit.Female,
+			&departmentStats.GenderSplit.Unspecified,
+			averageTenureDays,
+			&recentHire.IdentityNumber,
+			&recentHire.Name,
+			&recentHire.HireDate,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		if averageTenureDays.Valid {
+			days := int(averageTenureDays.Int32)
+			departmentStats.AverageTenureDays = &days
+		}
+		departmentStats.MostRecentHire = &recentHire
+		stats[departmentID] = &departmentStats
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get department stats")
+	}
+
+	return stats, nil
+}
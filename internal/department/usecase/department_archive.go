@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/department/dto"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+
+	"github.com/pkg/errors"
+)
+
+// ArchiveDepartment hides the department from the default lists and stops it
+// from taking new employees, while keeping it for the records that point to
+// it. Only terminated employees may be left in it.
+func (u *DepartmentUsecase) ArchiveDepartment(ctx context.Context, userID int, departmentID int) (*dto.Department, error) {
+	if err := u.validateDepartmentExists(ctx, userID, departmentID); err != nil {
+		return nil, err
+	}
+
+	isEmployeeExists, err := u.departmentRepo.CheckIfActiveEmployeeExist(ctx, userID, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEmployeeExists {
+		return nil, errors.Wrap(customErrors.ErrConflict, "still containing employee")
+	}
+
+	isSubDepartmentExists, err := u.departmentRepo.CheckIfActiveSubDepartmentExist(ctx, userID, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isSubDepartmentExists {
+		return nil, errors.Wrap(customErrors.ErrConflict, "still containing sub-department that is not archived")
+	}
+
+	department, err := u.departmentRepo.ArchiveDepartment(ctx, userID, departmentID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "department is already archived")
+		}
+		return nil, err
+	}
+
+	return department, nil
+}
+
+func (u *DepartmentUsecase) UnarchiveDepartment(ctx context.Context, userID int, departmentID int) (*dto.Department, error) {
+	department, err := u.departmentRepo.UnarchiveDepartment(ctx, userID, departmentID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "archived department not found")
+		}
+		return nil, err
+	}
+
+	return department, nil
+}
+
+// validateDepartmentAssignable makes sure departmentID can take new
+// employees or sub-departments.
+func (u *DepartmentUsecase) validateDepartmentAssignable(ctx context.Context, userID int, departmentID int, name string) error {
+	department, err := u.departmentRepo.GetDepartment(ctx, userID, departmentID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return errors.Wrapf(customErrors.ErrNotFound, "%s not found", name)
+		}
+		return err
+	}
+
+	if department.ArchivedAt != nil {
+		return errors.Wrapf(customErrors.ErrConflict, "%s is archived", name)
+	}
+
+	return nil
+}
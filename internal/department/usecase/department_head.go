@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/department/dto"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+
+	"github.com/pkg/errors"
+)
+
+func (u *DepartmentUsecase) GetDepartmentHeads(ctx context.Context, userID int, departmentID int) ([]dto.DepartmentHead, error) {
+	if err := u.validateDepartmentExists(ctx, userID, departmentID); err != nil {
+		return nil, err
+	}
+
+	return u.departmentRepo.GetDepartmentHeads(ctx, userID, departmentID)
+}
+
+// AssignDepartmentHead makes the employee a head of the department. A
+// department may have several heads, and a head does not need to work in
+// the department itself.
+func (u *DepartmentUsecase) AssignDepartmentHead(ctx context.Context, userID int, departmentID int, payload *dto.AssignHeadPayload) ([]dto.DepartmentHead, error) {
+	if err := u.validateDepartmentExists(ctx, userID, departmentID); err != nil {
+		return nil, err
+	}
+
+	isEmployeeExists, err := u.departmentRepo.CheckIfIdentityNumberExist(ctx, userID, payload.IdentityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEmployeeExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	isHead, err := u.departmentRepo.CheckIfDepartmentHead(ctx, userID, departmentID, payload.IdentityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if isHead {
+		return nil, errors.Wrap(customErrors.ErrConflict, "employee is already a head of this department")
+	}
+
+	if err := u.departmentRepo.AssignDepartmentHead(ctx, userID, departmentID, payload.IdentityNumber); err != nil {
+		return nil, err
+	}
+
+	return u.departmentRepo.GetDepartmentHeads(ctx, userID, departmentID)
+}
+
+func (u *DepartmentUsecase) RemoveDepartmentHead(ctx context.Context, userID int, departmentID int, identityNumber string) error {
+	if err := u.validateDepartmentExists(ctx, userID, departmentID); err != nil {
+		return err
+	}
+
+	isRemoved, err := u.departmentRepo.RemoveDepartmentHead(ctx, userID, departmentID, identityNumber)
+	if err != nil {
+		return err
+	}
+
+	if !isRemoved {
+		return errors.Wrap(customErrors.ErrNotFound, "employee is not a head of this department")
+	}
+
+	return nil
+}
+
+func (u *DepartmentUsecase) validateDepartmentExists(ctx context.Context, userID int, departmentID int) error {
+	isDepartmentExists, err := u.departmentRepo.CheckIfDepartmentExist(ctx, userID, departmentID)
+	if err != nil {
+		return err
+	}
+
+	if !isDepartmentExists {
+		return errors.Wrap(customErrors.ErrNotFound, "department not found")
+	}
+
+	return nil
+}
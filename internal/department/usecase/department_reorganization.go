@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"ps-gogo-manajer/internal/department/dto"
+	"ps-gogo-manajer/internal/department/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MergeDepartment moves everything in the department into the target
+// department and removes it.
+func (u *DepartmentUsecase) MergeDepartment(ctx context.Context, userID int, departmentID int, payload *dto.MergeDepartmentPayload) (*dto.ReorganizationResult, error) {
+	targetID, _ := strconv.Atoi(payload.TargetDepartmentId)
+	if targetID == departmentID {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "department cannot be merged into itself")
+	}
+
+	if err := u.validateDepartmentExists(ctx, userID, departmentID); err != nil {
+		return nil, err
+	}
+
+	target, err := u.departmentRepo.GetDepartment(ctx, userID, targetID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "target department not found")
+		}
+		return nil, err
+	}
+
+	if target.ArchivedAt != nil {
+		return nil, errors.Wrap(customErrors.ErrConflict, "target department is archived")
+	}
+
+	reason := payload.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("Merged into %s", target.Name)
+	}
+
+	result, err := u.departmentRepo.MergeDepartment(ctx, userID, departmentID, targetID, reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDepartmentNotFound):
+			return nil, errors.Wrap(customErrors.ErrNotFound, "department not found")
+		case errors.Is(err, repository.ErrTargetIsDescendant):
+			return nil, errors.Wrap(customErrors.ErrConflict, "target department is under this department")
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SplitDepartment moves the given employees of the department into a new
+// department next to it.
+func (u *DepartmentUsecase) SplitDepartment(ctx context.Context, userID int, departmentID int, payload *dto.SplitDepartmentPayload) (*dto.ReorganizationResult, error) {
+	if err := u.validateDepartmentExists(ctx, userID, departmentID); err != nil {
+		return nil, err
+	}
+
+	missing, err := u.departmentRepo.GetEmployeesNotInDepartment(ctx, userID, departmentID, payload.IdentityNumbers)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		return nil, errors.Wrapf(customErrors.ErrBadRequest, "employees not in department: %s", strings.Join(missing, ", "))
+	}
+
+	reason := payload.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("Split into %s", payload.Name)
+	}
+
+	result, err := u.departmentRepo.SplitDepartment(ctx, userID, departmentID, payload, reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDepartmentNotFound):
+			return nil, errors.Wrap(customErrors.ErrNotFound, "department not found")
+		case errors.Is(err, repository.ErrEmployeesChanged):
+			return nil, errors.Wrap(customErrors.ErrConflict, "employees of the department changed, please retry")
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -5,10 +5,14 @@ import (
 	"ps-gogo-manajer/internal/department/dto"
 	"ps-gogo-manajer/internal/department/repository"
 	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/pagination"
+	"strconv"
 
 	"github.com/pkg/errors"
 )
 
+const DEFAULT_SORT = "id"
+
 type DepartmentUsecase struct {
 	departmentRepo repository.DepartmentRepository
 }
@@ -20,11 +24,116 @@ func NewDepartmentUsecases(departmentRepo repository.DepartmentRepository) *Depa
 }
 
 func (u *DepartmentUsecase) CreateDepartment(ctx context.Context, userID int, payload *dto.CreateDepartmentPayload) (*dto.Department, error) {
+	if payload.ParentId != "" {
+		parentID, _ := strconv.Atoi(payload.ParentId)
+		if err := u.validateDepartmentAssignable(ctx, userID, parentID, "parent department"); err != nil {
+			return nil, err
+		}
+	}
+
 	return u.departmentRepo.CreateDepartment(ctx, userID, payload)
 }
 
 func (u *DepartmentUsecase) GetListDepartment(ctx context.Context, userID int, payload *dto.GetDepartmentListParams) (*[]dto.Department, error) {
-	return u.departmentRepo.GetListDepartment(ctx, userID, payload)
+	sort, isValid := pagination.ParseSort(payload.Sort, repository.DepartmentSortColumns, DEFAULT_SORT)
+	if !isValid {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "invalid sort")
+	}
+
+	departments, err := u.departmentRepo.GetListDepartment(ctx, userID, payload, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.WithStats {
+		if err := u.attachStats(ctx, userID, *departments); err != nil {
+			return nil, err
+		}
+	}
+
+	return departments, nil
+}
+
+func (u *DepartmentUsecase) GetDepartment(ctx context.Context, userID int, departmentID int) (*dto.Department, error) {
+	department, err := u.departmentRepo.GetDepartment(ctx, userID, departmentID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "department not found")
+		}
+		return nil, err
+	}
+
+	departments := []dto.Department{*department}
+	if err := u.attachStats(ctx, userID, departments); err != nil {
+		return nil, err
+	}
+
+	return &departments[0], nil
+}
+
+// attachStats fills in the stats of the departments with a single query.
+func (u *DepartmentUsecase) attachStats(ctx context.Context, userID int, departments []dto.Department) error {
+	if len(departments) == 0 {
+		return nil
+	}
+
+	departmentIDs := make([]string, 0, len(departments))
+	for _, department := range departments {
+		departmentIDs = append(departmentIDs, department.DepartmentId)
+	}
+
+	stats, err := u.departmentRepo.GetDepartmentStats(ctx, userID, departmentIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range departments {
+		departments[i].Stats = stats[departments[i].DepartmentId]
+	}
+
+	return nil
+}
+
+func (u *DepartmentUsecase) GetPageDepartment(ctx context.Context, userID int, payload *dto.GetDepartmentListParams) (*pagination.Page[dto.Department], error) {
+	sort, isValid := pagination.ParseSort(payload.Sort, repository.DepartmentSortColumns, DEFAULT_SORT)
+	if !isValid {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "invalid sort")
+	}
+
+	var cursor *pagination.Cursor
+	if payload.Cursor != "" {
+		decoded, err := pagination.DecodeCursor(payload.Cursor)
+		if err != nil {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		}
+
+		// * A cursor only makes sense for the ordering it was created with
+		if decoded.Sort != sort.Key {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, "cursor does not match sort")
+		}
+		cursor = decoded
+	}
+
+	page, err := u.departmentRepo.GetPageDepartment(ctx, userID, payload, sort, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.WithStats {
+		if err := u.attachStats(ctx, userID, page.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if payload.WithTotal {
+		total, err := u.departmentRepo.CountDepartment(ctx, userID, payload)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	return page, nil
 }
 
 func (u *DepartmentUsecase) UpdateDepartment(ctx context.Context, userID int, departmentId int, payload *dto.PatchDepartmentPayload) (*dto.Department, error) {
@@ -58,5 +167,70 @@ func (u *DepartmentUsecase) DeleteDepartment(ctx context.Context, userID int, de
 		return errors.Wrap(customErrors.ErrConflict, "still containing employee")
 	}
 
+	isSubDepartmentExists, err := u.departmentRepo.CheckIfSubDepartmentExist(ctx, userID, departmentID)
+	if err != nil {
+		return err
+	}
+
+	if isSubDepartmentExists {
+		return errors.Wrap(customErrors.ErrConflict, "still containing sub-department")
+	}
+
 	return u.departmentRepo.DeleteDepartment(ctx, userID, departmentID)
 }
+
+func (u *DepartmentUsecase) RestoreDepartment(ctx context.Context, userID int, departmentID int) (*dto.Department, error) {
+	department, err := u.departmentRepo.RestoreDepartment(ctx, userID, departmentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrParentTrashed):
+			return nil, errors.Wrap(customErrors.ErrConflict, "parent department of this department is in trash, restore it first")
+		case errors.Is(err, customErrors.ErrNotFound):
+			return nil, errors.Wrap(customErrors.ErrNotFound, "department not found in trash")
+		}
+		return nil, err
+	}
+
+	return department, nil
+}
+
+func (u *DepartmentUsecase) MoveDepartment(ctx context.Context, userID int, departmentID int, payload *dto.MoveDepartmentPayload) (*dto.Department, error) {
+	if payload.ParentId == strconv.Itoa(departmentID) {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "department cannot be its own parent")
+	}
+
+	if payload.ParentId != "" {
+		parentID, _ := strconv.Atoi(payload.ParentId)
+		if err := u.validateDepartmentAssignable(ctx, userID, parentID, "parent department"); err != nil {
+			return nil, err
+		}
+	}
+
+	department, err := u.departmentRepo.MoveDepartment(ctx, userID, departmentID, payload.ParentId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrParentNotFound):
+			return nil, errors.Wrap(customErrors.ErrNotFound, "parent department not found")
+		case errors.Is(err, repository.ErrParentIsDescendant):
+			return nil, errors.Wrap(customErrors.ErrConflict, "parent department is under this department")
+		case errors.Is(err, customErrors.ErrNotFound):
+			return nil, errors.Wrap(customErrors.ErrNotFound, "department not found")
+		}
+		return nil, err
+	}
+
+	return department, nil
+}
+
+func (u *DepartmentUsecase) GetDepartmentSubtree(ctx context.Context, userID int, departmentID int) ([]dto.Department, error) {
+	departments, err := u.departmentRepo.GetDepartmentSubtree(ctx, userID, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(departments) == 0 {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "department not found")
+	}
+
+	return departments, nil
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	employeeDto "ps-gogo-manajer/internal/employee/dto"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindPayslip               Kind = "payslip"
+	KindEmploymentCertificate Kind = "employment_certificate"
+	KindOfferLetter           Kind = "offer_letter"
+)
+
+type DocumentTemplate struct {
+	TemplateId string    `json:"templateId"`
+	Kind       Kind      `json:"kind"`
+	Name       string    `json:"name"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// CreateTemplatePayload holds a template whose title and body may contain
+// merge fields written as {{employee.name}}. Blank lines in the body start a
+// new paragraph.
+type CreateTemplatePayload struct {
+	Kind  Kind   `json:"kind" validate:"required,oneof=payslip employment_certificate offer_letter"`
+	Name  string `json:"name" validate:"required,min=1,max=255"`
+	Title string `json:"title" validate:"required,min=1,max=255"`
+	Body  string `json:"body" validate:"max=20000"`
+}
+
+type PatchTemplatePayload struct {
+	Name  *string `json:"name" validate:"omitempty,min=1,max=255"`
+	Title *string `json:"title" validate:"omitempty,min=1,max=255"`
+	Body  *string `json:"body" validate:"omitempty,max=20000"`
+}
+
+type GetTemplateParams struct {
+	Kind string `query:"kind" validate:"omitempty,oneof=payslip employment_certificate offer_letter"`
+}
+
+type TemplatePathParam struct {
+	TemplateId int `param:"templateId" validate:"required,min=1"`
+}
+
+// GenerateDocumentPayload picks the template by id, or else the built-in
+// template of the kind. Payslips need the payroll run, and Fields fills the
+// {{input.<key>}} merge fields, such as the start date of an offer letter.
+type GenerateDocumentPayload struct {
+	IdentityNumber string            `param:"identityNumber" validate:"required"`
+	Kind           Kind              `json:"kind" validate:"required_without=TemplateId,omitempty,oneof=payslip employment_certificate offer_letter"`
+	TemplateId     int               `json:"templateId" validate:"omitempty,min=1"`
+	PayrollRunId   int               `json:"payrollRunId" validate:"omitempty,min=1"`
+	Name           string            `json:"name" validate:"omitempty,max=255"`
+	Fields         map[string]string `json:"fields" validate:"omitempty,dive,keys,fieldkey,endkeys,max=1000"`
+}
+
+// MergeEmployee is the employee a document is generated for, with the names
+// of the records it refers to by id.
+type MergeEmployee struct {
+	employeeDto.Employee
+	DepartmentName  string
+	ManagerName     string
+	CompanyName     string
+	CompanyImageUri string
+}
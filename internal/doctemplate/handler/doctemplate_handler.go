@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/doctemplate/dto"
+	"ps-gogo-manajer/internal/doctemplate/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type DocumentTemplateHandler struct {
+	templateUsecase usecase.DocumentTemplateUsecase
+	validator       *validator.Validate
+}
+
+func NewDocumentTemplateHandler(templateUsecase usecase.DocumentTemplateUsecase, validator *validator.Validate) *DocumentTemplateHandler {
+	return &DocumentTemplateHandler{
+		templateUsecase: templateUsecase,
+		validator:       validator,
+	}
+}
+
+// bind binds the request into payload and validates it, wrapping any failure
+// as a bad request.
+func (h DocumentTemplateHandler) bind(ctx echo.Context, payload any) error {
+	if err := ctx.Bind(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (h DocumentTemplateHandler) bindPathParam(ctx echo.Context) (*dto.TemplatePathParam, error) {
+	var pathParam dto.TemplatePathParam
+	if err := (&echo.DefaultBinder{}).BindPathParams(ctx, &pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return &pathParam, nil
+}
+
+func (h DocumentTemplateHandler) CreateTemplate(ctx echo.Context) error {
+	var payload dto.CreateTemplatePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	template, err := h.templateUsecase.CreateTemplate(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, template)
+}
+
+func (h DocumentTemplateHandler) GetListTemplate(ctx echo.Context) error {
+	var params dto.GetTemplateParams
+	if err := h.bind(ctx, &params); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	templates, err := h.templateUsecase.GetListTemplate(ctx.Request().Context(), userData.Id, &params)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, templates)
+}
+
+func (h DocumentTemplateHandler) GetTemplate(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	template, err := h.templateUsecase.GetTemplate(ctx.Request().Context(), userData.Id, pathParam.TemplateId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, template)
+}
+
+func (h DocumentTemplateHandler) UpdateTemplate(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.PatchTemplatePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	template, err := h.templateUsecase.UpdateTemplate(ctx.Request().Context(), userData.Id, pathParam.TemplateId, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, template)
+}
+
+func (h DocumentTemplateHandler) DeleteTemplate(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	if err := h.templateUsecase.DeleteTemplate(ctx.Request().Context(), userData.Id, pathParam.TemplateId); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h DocumentTemplateHandler) GetMergeFields(ctx echo.Context) error {
+	var params dto.GetTemplateParams
+	if err := h.bind(ctx, &params); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, usecase.MergeFields(dto.Kind(params.Kind)))
+}
+
+func (h DocumentTemplateHandler) GenerateDocument(ctx echo.Context) error {
+	var payload dto.GenerateDocumentPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	document, err := h.templateUsecase.GenerateDocument(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, document)
+}
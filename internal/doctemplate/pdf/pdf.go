@@ -0,0 +1,151 @@
+// Package pdf lays out generated documents as branded A4 pages.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/pkg/errors"
+)
+
+const (
+	MARGIN      = 20.0
+	LOGO_HEIGHT = 15.0
+	LINE_HEIGHT = 6.0
+	ROW_HEIGHT  = 7.0
+	FONT_FAMILY = "Helvetica"
+	LOGO_IMAGE  = "logo"
+
+	// LABEL_WIDTH is the share of the page width given to the labels of a
+	// table, the amounts take the rest.
+	LABEL_WIDTH = 0.65
+)
+
+// Logo is the company image, PNG or JPEG.
+type Logo struct {
+	Content   []byte
+	ImageType string
+}
+
+type Row struct {
+	Label string
+	Value string
+}
+
+// Table is a list of label and amount rows, closed by an optional total.
+type Table struct {
+	Heading string
+	Rows    []Row
+	Total   *Row
+}
+
+type Document struct {
+	CompanyName string
+	Logo        *Logo
+	Title       string
+	Paragraphs  []string
+	Tables      []Table
+	GeneratedAt time.Time
+}
+
+// Render lays out the letterhead, the title, the paragraphs and then the
+// tables of the document. Text is written with the standard fonts, so
+// characters outside of Windows-1252 are dropped.
+func Render(document *Document) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(MARGIN, MARGIN, MARGIN)
+	pdf.SetAutoPageBreak(true, MARGIN)
+	pdf.SetCreationDate(document.GeneratedAt)
+	pdf.SetCatalogSort(true)
+	pdf.SetTitle(document.Title, true)
+	pdf.SetCreator(document.CompanyName, true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pageWidth, _ := pdf.GetPageSize()
+		halfWidth := (pageWidth - 2*MARGIN) / 2
+
+		pdf.SetY(-MARGIN + 5)
+		pdf.SetFont(FONT_FAMILY, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(halfWidth, 4, "Generated on "+document.GeneratedAt.Format("2 January 2006"), "", 0, "L", false, 0, "")
+		pdf.CellFormat(halfWidth, 4, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+
+	writeLetterhead(pdf, document, tr)
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont(FONT_FAMILY, "B", 16)
+	pdf.MultiCell(0, 8, tr(document.Title), "", "C", false)
+	pdf.Ln(4)
+
+	pdf.SetFont(FONT_FAMILY, "", 11)
+	for _, paragraph := range document.Paragraphs {
+		pdf.MultiCell(0, LINE_HEIGHT, tr(paragraph), "", "L", false)
+		pdf.Ln(3)
+	}
+
+	for _, table := range document.Tables {
+		writeTable(pdf, table, tr)
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, errors.Wrap(err, "failed to render pdf")
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeLetterhead(pdf *fpdf.Fpdf, document *Document, tr func(string) string) {
+	left, top, _, _ := pdf.GetMargins()
+	textX := left
+
+	if document.Logo != nil {
+		options := fpdf.ImageOptions{ImageType: document.Logo.ImageType, ReadDpi: true}
+		info := pdf.RegisterImageOptionsReader(LOGO_IMAGE, options, bytes.NewReader(document.Logo.Content))
+		if pdf.Ok() && info != nil {
+			pdf.ImageOptions(LOGO_IMAGE, left, top, 0, LOGO_HEIGHT, false, options, 0, "")
+			textX += info.Width()*LOGO_HEIGHT/info.Height() + 5
+		} else {
+			// A logo that cannot be decoded leaves the letterhead without
+			// it rather than failing the whole document.
+			pdf.ClearError()
+		}
+	}
+
+	pdf.SetXY(textX, top)
+	pdf.SetFont(FONT_FAMILY, "B", 14)
+	pdf.CellFormat(0, LOGO_HEIGHT, tr(document.CompanyName), "", 1, "L", false, 0, "")
+
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.SetDrawColor(160, 160, 160)
+	pdf.Line(left, top+LOGO_HEIGHT+2, pageWidth-left, top+LOGO_HEIGHT+2)
+	pdf.SetY(top + LOGO_HEIGHT + 8)
+}
+
+func writeTable(pdf *fpdf.Fpdf, table Table, tr func(string) string) {
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	labelWidth := (pageWidth - left - right) * LABEL_WIDTH
+
+	pdf.Ln(2)
+	pdf.SetFont(FONT_FAMILY, "B", 11)
+	pdf.CellFormat(0, ROW_HEIGHT, tr(table.Heading), "B", 1, "L", false, 0, "")
+
+	pdf.SetFont(FONT_FAMILY, "", 10)
+	for _, row := range table.Rows {
+		pdf.CellFormat(labelWidth, ROW_HEIGHT, tr(row.Label), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, ROW_HEIGHT, tr(row.Value), "", 1, "R", false, 0, "")
+	}
+
+	if table.Total != nil {
+		pdf.SetFont(FONT_FAMILY, "B", 10)
+		pdf.CellFormat(labelWidth, ROW_HEIGHT, tr(table.Total.Label), "T", 0, "L", false, 0, "")
+		pdf.CellFormat(0, ROW_HEIGHT, tr(table.Total.Value), "T", 1, "R", false, 0, "")
+	}
+	pdf.Ln(3)
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/doctemplate/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type DocumentTemplateRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDocumentTemplateRepository(pool *pgxpool.Pool) *DocumentTemplateRepository {
+	return &DocumentTemplateRepository{pool: pool}
+}
+
+const (
+	templateColumns = `
+		document_templates.id,
+		document_templates.kind,
+		document_templates.name,
+		document_templates.title,
+		document_templates.body,
+		document_templates.created_at,
+		document_templates.updated_at`
+
+	queryCreateTemplate = `
+	INSERT INTO document_templates(user_id, kind, name, title, body)
+	VALUES (@userID, @kind, @name, @title, @body)
+	RETURNING` + templateColumns + `;`
+	queryGetListTemplate = `
+	SELECT` + templateColumns + `
+	FROM document_templates
+	WHERE
+		user_id = @userID
+		AND (NULLIF(@kind, '') IS NULL OR kind = NULLIF(@kind, '')::enum_document_template_kind)
+	ORDER BY kind, name, id;`
+	queryGetTemplate = `
+	SELECT` + templateColumns + `
+	FROM document_templates
+	WHERE
+		user_id = @userID
+		AND id = @templateID;`
+	queryUpdateTemplate = `
+	UPDATE document_templates
+	SET
+		name = COALESCE(@name, name),
+		title = COALESCE(@title, title),
+		body = COALESCE(@body, body),
+		updated_at = NOW()
+	WHERE
+		user_id = @userID
+		AND id = @templateID
+	RETURNING` + templateColumns + `;`
+	queryDeleteTemplate = `
+	DELETE FROM document_templates
+	WHERE
+		user_id = @userID
+		AND id = @templateID;`
+	queryGetMergeEmployee = `
+	SELECT
+		employees.name,
+		employees.identity_number,
+		employees.gender,
+		employees.department_id,
+		employees.job_title,
+		employees.custom_fields,
+		employees.status,
+		employees.status_effective_date::text,
+		departments.name,
+		manager.identity_number,
+		manager.name,
+		users.company_name,
+		users.company_image_uri
+	FROM employees
+	JOIN users ON users.id = employees.user_id
+	LEFT JOIN departments ON departments.id = employees.department_id
+	LEFT JOIN employees manager ON manager.id = employees.manager_id AND manager.deleted_at IS NULL
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL;`
+)
+
+func scanTemplate(row pgx.Row) (*dto.DocumentTemplate, error) {
+	var template dto.DocumentTemplate
+	err := row.Scan(
+		&template.TemplateId,
+		&template.Kind,
+		&template.Name,
+		&template.Title,
+		&template.Body,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *DocumentTemplateRepository) CreateTemplate(ctx context.Context, userID int, payload *dto.CreateTemplatePayload) (*dto.DocumentTemplate, error) {
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"kind":   payload.Kind,
+		"name":   payload.Name,
+		"title":  payload.Title,
+		"body":   payload.Body,
+	}
+
+	template, err := scanTemplate(r.pool.QueryRow(ctx, queryCreateTemplate, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create document template")
+	}
+
+	return template, nil
+}
+
+func (r *DocumentTemplateRepository) GetListTemplate(ctx context.Context, userID int, params *dto.GetTemplateParams) ([]dto.DocumentTemplate, error) {
+	templates := make([]dto.DocumentTemplate, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"kind":   params.Kind,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListTemplate, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list document template")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		templates = append(templates, *template)
+	}
+
+	return templates, nil
+}
+
+func (r *DocumentTemplateRepository) GetTemplate(ctx context.Context, userID int, templateID int) (*dto.DocumentTemplate, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"templateID": templateID,
+	}
+
+	template, err := scanTemplate(r.pool.QueryRow(ctx, queryGetTemplate, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get document template")
+	}
+
+	return template, nil
+}
+
+func (r *DocumentTemplateRepository) UpdateTemplate(ctx context.Context, userID int, templateID int, payload *dto.PatchTemplatePayload) (*dto.DocumentTemplate, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"templateID": templateID,
+		"name":       payload.Name,
+		"title":      payload.Title,
+		"body":       payload.Body,
+	}
+
+	template, err := scanTemplate(r.pool.QueryRow(ctx, queryUpdateTemplate, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update document template")
+	}
+
+	return template, nil
+}
+
+func (r *DocumentTemplateRepository) DeleteTemplate(ctx context.Context, userID int, templateID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"templateID": templateID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeleteTemplate, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete document template")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *DocumentTemplateRepository) GetMergeEmployee(ctx context.Context, userID int, identityNumber string) (*dto.MergeEmployee, error) {
+	var employee dto.MergeEmployee
+	jobTitle := new(pgtype.Text)
+	departmentName := new(pgtype.Text)
+	managerIdentityNumber := new(pgtype.Text)
+	managerName := new(pgtype.Text)
+	companyName := new(pgtype.Text)
+	companyImageUri := new(pgtype.Text)
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryGetMergeEmployee, args).Scan(
+		&employee.Name,
+		&employee.IdentityNumber,
+		&employee.Gender,
+		&employee.DepartmentId,
+		jobTitle,
+		&employee.CustomFields,
+		&employee.Status,
+		&employee.StatusEffectiveDate,
+		departmentName,
+		managerIdentityNumber,
+		managerName,
+		companyName,
+		companyImageUri,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get employee")
+	}
+
+	employee.JobTitle = jobTitle.String
+	employee.DepartmentName = departmentName.String
+	employee.ManagerIdentityNumber = managerIdentityNumber.String
+	employee.ManagerName = managerName.String
+	employee.CompanyName = companyName.String
+	employee.CompanyImageUri = companyImageUri.String
+	return &employee, nil
+}
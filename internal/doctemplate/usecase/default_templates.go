@@ -0,0 +1,40 @@
+package usecase
+
+import "ps-gogo-manajer/internal/doctemplate/dto"
+
+// defaultTemplates are used for documents generated without a template of
+// the organization's own.
+var defaultTemplates = map[dto.Kind]dto.DocumentTemplate{
+	dto.KindPayslip: {
+		Kind:  dto.KindPayslip,
+		Name:  "Payslip",
+		Title: "Payslip {{payroll.period}}",
+		Body: `Name: {{employee.name}}
+Identity number: {{employee.identityNumber}}
+Department: {{employee.department}}
+Job title: {{employee.jobTitle}}`,
+	},
+	dto.KindEmploymentCertificate: {
+		Kind:  dto.KindEmploymentCertificate,
+		Name:  "Employment Certificate",
+		Title: "Employment Certificate",
+		Body: `This is to certify that {{employee.name}}, identity number {{employee.identityNumber}}, is employed by {{company.name}} as {{employee.jobTitle}} in the {{employee.department}} department.
+
+This certificate is issued on {{today}} at the request of the employee, to be used as needed.
+
+{{company.name}}`,
+	},
+	dto.KindOfferLetter: {
+		Kind:  dto.KindOfferLetter,
+		Name:  "Offer Letter",
+		Title: "Offer of Employment",
+		Body: `Dear {{employee.name}},
+
+We are pleased to offer you the position of {{employee.jobTitle}} in the {{employee.department}} department of {{company.name}}, starting on {{input.startDate}}.
+
+Please confirm your acceptance of this offer by signing and returning a copy of this letter.
+
+Sincerely,
+{{company.name}}`,
+	},
+}
@@ -0,0 +1,370 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"ps-gogo-manajer/internal/doctemplate/dto"
+	"ps-gogo-manajer/internal/doctemplate/pdf"
+	"ps-gogo-manajer/internal/doctemplate/repository"
+	documentDto "ps-gogo-manajer/internal/document/dto"
+	documentUsecase "ps-gogo-manajer/internal/document/usecase"
+	fileUsecase "ps-gogo-manajer/internal/files/usecase"
+	payrollDto "ps-gogo-manajer/internal/payroll/dto"
+	payrollUsecase "ps-gogo-manajer/internal/payroll/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	MAX_LOGO_SIZE      = 2 * 1024 * 1024
+	LOGO_FETCH_TIMEOUT = 5 * time.Second
+)
+
+// documentCategories files every kind of generated document under a
+// category of the document vault.
+var documentCategories = map[dto.Kind]documentDto.Category{
+	dto.KindPayslip:               documentDto.CategoryPayslip,
+	dto.KindEmploymentCertificate: documentDto.CategoryCertificate,
+	dto.KindOfferLetter:           documentDto.CategoryContract,
+}
+
+type DocumentTemplateUsecase struct {
+	templateRepo    repository.DocumentTemplateRepository
+	payrollUsecase  payrollUsecase.PayrollUsecase
+	documentUsecase documentUsecase.DocumentUsecase
+	fileUsecase     *fileUsecase.FileUsecase
+	httpClient      *http.Client
+	log             *logrus.Logger
+}
+
+func NewDocumentTemplateUsecase(templateRepo repository.DocumentTemplateRepository, payrollUsecase payrollUsecase.PayrollUsecase, documentUsecase documentUsecase.DocumentUsecase, fileUsecase *fileUsecase.FileUsecase, log *logrus.Logger) *DocumentTemplateUsecase {
+	return &DocumentTemplateUsecase{
+		templateRepo:    templateRepo,
+		payrollUsecase:  payrollUsecase,
+		documentUsecase: documentUsecase,
+		fileUsecase:     fileUsecase,
+		httpClient:      newLogoClient(),
+		log:             log,
+	}
+}
+
+// newLogoClient fetches company images from other hosts. They are set by
+// the tenant, so the client only reaches public addresses and does not
+// follow redirects.
+func newLogoClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: LOGO_FETCH_TIMEOUT,
+		// * Checked on the resolved address, so a name pointing inside is refused as well
+		Control: func(network string, address string, conn syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddr(addrPort.Addr()) {
+				return errors.Errorf("address %s is not allowed", addrPort.Addr())
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: LOGO_FETCH_TIMEOUT,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: LOGO_FETCH_TIMEOUT,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not public
+// either.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+func (u *DocumentTemplateUsecase) CreateTemplate(ctx context.Context, userID int, payload *dto.CreateTemplatePayload) (*dto.DocumentTemplate, error) {
+	if err := validateMergeFields(payload.Kind, payload.Title, payload.Body); err != nil {
+		return nil, err
+	}
+
+	return u.templateRepo.CreateTemplate(ctx, userID, payload)
+}
+
+func (u *DocumentTemplateUsecase) GetListTemplate(ctx context.Context, userID int, params *dto.GetTemplateParams) ([]dto.DocumentTemplate, error) {
+	return u.templateRepo.GetListTemplate(ctx, userID, params)
+}
+
+func (u *DocumentTemplateUsecase) GetTemplate(ctx context.Context, userID int, templateID int) (*dto.DocumentTemplate, error) {
+	template, err := u.templateRepo.GetTemplate(ctx, userID, templateID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "document template not found")
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (u *DocumentTemplateUsecase) UpdateTemplate(ctx context.Context, userID int, templateID int, payload *dto.PatchTemplatePayload) (*dto.DocumentTemplate, error) {
+	template, err := u.GetTemplate(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var texts []string
+	for _, text := range []*string{payload.Title, payload.Body} {
+		if text != nil {
+			texts = append(texts, *text)
+		}
+	}
+	if err := validateMergeFields(template.Kind, texts...); err != nil {
+		return nil, err
+	}
+
+	template, err = u.templateRepo.UpdateTemplate(ctx, userID, templateID, payload)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "document template not found")
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (u *DocumentTemplateUsecase) DeleteTemplate(ctx context.Context, userID int, templateID int) error {
+	isDeleted, err := u.templateRepo.DeleteTemplate(ctx, userID, templateID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return errors.Wrap(customErrors.ErrNotFound, "document template not found")
+	}
+
+	return nil
+}
+
+// GenerateDocument renders a template for the employee as a PDF and stores
+// it in the employee's document vault.
+func (u *DocumentTemplateUsecase) GenerateDocument(ctx context.Context, userID int, payload *dto.GenerateDocumentPayload) (*documentDto.Document, error) {
+	template, err := u.getGenerationTemplate(ctx, userID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	employee, err := u.templateRepo.GetMergeEmployee(ctx, userID, payload.IdentityNumber)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+		}
+		return nil, err
+	}
+
+	var payslip *payrollDto.Payslip
+	if template.Kind == dto.KindPayslip {
+		payslip, err = u.getPayslip(ctx, userID, payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	values := mergeValues(employee, payslip, payload.Fields, now)
+	title, paragraphs, err := mergeTemplate(template, values)
+	if err != nil {
+		return nil, err
+	}
+
+	document := pdf.Document{
+		CompanyName: employee.CompanyName,
+		Logo:        u.fetchLogo(ctx, employee.CompanyImageUri),
+		Title:       title,
+		Paragraphs:  paragraphs,
+		GeneratedAt: now,
+	}
+	if payslip != nil {
+		document.Tables = payslipTables(&payslip.Item)
+	}
+
+	content, err := pdf.Render(&document)
+	if err != nil {
+		return nil, err
+	}
+
+	name := payload.Name
+	if name == "" {
+		name = title + ".pdf"
+	}
+
+	return u.documentUsecase.SaveGeneratedDocument(ctx, userID, &documentDto.UploadDocumentPayload{
+		IdentityNumber: payload.IdentityNumber,
+		Category:       documentCategories[template.Kind],
+		Name:           name,
+		ContentType:    fileUsecase.PDF,
+	}, content)
+}
+
+// getGenerationTemplate returns the template asked for, or the built-in
+// template of the kind.
+func (u *DocumentTemplateUsecase) getGenerationTemplate(ctx context.Context, userID int, payload *dto.GenerateDocumentPayload) (*dto.DocumentTemplate, error) {
+	if payload.TemplateId == 0 {
+		template := defaultTemplates[payload.Kind]
+		return &template, nil
+	}
+
+	template, err := u.GetTemplate(ctx, userID, payload.TemplateId)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Kind != "" && payload.Kind != template.Kind {
+		return nil, errors.Wrapf(customErrors.ErrBadRequest, "document template is not a %s template", payload.Kind)
+	}
+
+	return template, nil
+}
+
+// getPayslip returns the payroll item of the employee. Payslips are only
+// issued once the run is locked, as draft runs may still change.
+func (u *DocumentTemplateUsecase) getPayslip(ctx context.Context, userID int, payload *dto.GenerateDocumentPayload) (*payrollDto.Payslip, error) {
+	if payload.PayrollRunId == 0 {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "payrollRunId is required for payslips")
+	}
+
+	payslip, err := u.payrollUsecase.GetPayslip(ctx, userID, &payrollDto.PayrollItemPathParam{
+		PayrollRunId:   payload.PayrollRunId,
+		IdentityNumber: payload.IdentityNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if payslip.Status != payrollDto.RunStatusLocked {
+		return nil, errors.Wrap(customErrors.ErrConflict, "payroll run is not locked")
+	}
+
+	return payslip, nil
+}
+
+func payslipTables(item *payrollDto.PayrollItem) []pdf.Table {
+	sections := []struct {
+		lineType payrollDto.ComponentType
+		heading  string
+		total    string
+	}{
+		{payrollDto.ComponentTypeEarning, "Earnings", "Gross Pay"},
+		{payrollDto.ComponentTypeDeduction, "Deductions", "Total Deductions"},
+		{payrollDto.ComponentTypeEmployerContribution, "Paid by the Company", "Total Company Contributions"},
+	}
+
+	var tables []pdf.Table
+	for _, section := range sections {
+		table := pdf.Table{Heading: section.heading}
+		for _, line := range item.Lines {
+			if line.Type == section.lineType {
+				table.Rows = append(table.Rows, pdf.Row{Label: line.Name, Value: formatRupiah(line.Amount)})
+			}
+		}
+		if len(table.Rows) == 0 {
+			continue
+		}
+
+		table.Total = &pdf.Row{Label: section.total, Value: formatRupiah(item.Total(section.lineType))}
+		tables = append(tables, table)
+	}
+
+	return append(tables, pdf.Table{
+		Heading: "Take Home Pay",
+		Total:   &pdf.Row{Label: "Net Pay", Value: formatRupiah(item.Net)},
+	})
+}
+
+// fetchLogo reads the company image for the letterhead, through the storage
+// when it is one of our files and otherwise from its https URL. A logo that
+// cannot be read leaves the letterhead without it, so failures are logged
+// instead of failing the document.
+func (u *DocumentTemplateUsecase) fetchLogo(ctx context.Context, uri string) *pdf.Logo {
+	if uri == "" {
+		return nil
+	}
+
+	log := u.log.WithField("uri", uri)
+	body, err := u.openLogo(ctx, uri)
+	if err != nil {
+		log.WithError(err).Warn("failed to fetch company image")
+		return nil
+	}
+	defer body.Close()
+
+	var content bytes.Buffer
+	if _, err := io.Copy(&content, io.LimitReader(body, MAX_LOGO_SIZE+1)); err != nil {
+		log.WithError(err).Warn("failed to fetch company image")
+		return nil
+	}
+	if content.Len() > MAX_LOGO_SIZE {
+		log.Warn("company image is too large for the letterhead")
+		return nil
+	}
+
+	switch http.DetectContentType(content.Bytes()) {
+	case fileUsecase.PNG:
+		return &pdf.Logo{Content: content.Bytes(), ImageType: "PNG"}
+	case fileUsecase.JPEG:
+		return &pdf.Logo{Content: content.Bytes(), ImageType: "JPG"}
+	default:
+		log.Warn("company image is not a png or jpeg")
+		return nil
+	}
+}
+
+func (u *DocumentTemplateUsecase) openLogo(ctx context.Context, uri string) (io.ReadCloser, error) {
+	if key, ok := u.fileUsecase.PublicKey(uri); ok {
+		return u.fileUsecase.DownloadFile(ctx, key)
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "https" {
+		return nil, errors.New("company image is not an https URL")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, errors.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	return res.Body, nil
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"fmt"
+	"ps-gogo-manajer/internal/doctemplate/dto"
+	payrollDto "ps-gogo-manajer/internal/payroll/dto"
+	payrollUsecase "ps-gogo-manajer/internal/payroll/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	FIELD_PREFIX_CUSTOM_FIELD = "employee.customFields."
+	FIELD_PREFIX_INPUT        = "input."
+
+	DATE_LAYOUT = "2 January 2006"
+)
+
+var (
+	mergeFieldPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z][a-zA-Z0-9_.]*)\s*\}\}`)
+	paragraphBreak    = regexp.MustCompile(`\n\s*\n`)
+
+	employeeFields = []string{
+		"company.name",
+		"employee.name",
+		"employee.identityNumber",
+		"employee.gender",
+		"employee.jobTitle",
+		"employee.department",
+		"employee.managerName",
+		"employee.managerIdentityNumber",
+		"employee.status",
+		"employee.statusEffectiveDate",
+		"today",
+	}
+	payrollFields = []string{
+		"payroll.period",
+		"payroll.baseSalary",
+		"payroll.gross",
+		"payroll.totalDeductions",
+		"payroll.net",
+	}
+)
+
+// MergeFields lists the fields templates of the kind can use, next to the
+// employee.customFields.<key> and input.<key> fields.
+func MergeFields(kind dto.Kind) []string {
+	fields := append([]string{}, employeeFields...)
+	if kind == dto.KindPayslip {
+		fields = append(fields, payrollFields...)
+	}
+
+	return fields
+}
+
+// validateMergeFields rejects templates that refer to fields their kind
+// cannot fill.
+func validateMergeFields(kind dto.Kind, texts ...string) error {
+	known := make(map[string]bool)
+	for _, field := range MergeFields(kind) {
+		known[field] = true
+	}
+
+	for _, text := range texts {
+		for _, match := range mergeFieldPattern.FindAllStringSubmatch(text, -1) {
+			field := match[1]
+			if known[field] || strings.HasPrefix(field, FIELD_PREFIX_CUSTOM_FIELD) || strings.HasPrefix(field, FIELD_PREFIX_INPUT) {
+				continue
+			}
+			return errors.Wrapf(customErrors.ErrBadRequest, "unknown merge field %s", field)
+		}
+	}
+
+	return nil
+}
+
+// mergeValues resolves every field the document can use. Custom fields the
+// employee has no value for resolve to an empty string.
+func mergeValues(employee *dto.MergeEmployee, payslip *payrollDto.Payslip, inputs map[string]string, today time.Time) map[string]string {
+	values := map[string]string{
+		"company.name":                   employee.CompanyName,
+		"employee.name":                  employee.Name,
+		"employee.identityNumber":        employee.IdentityNumber,
+		"employee.gender":                string(employee.Gender),
+		"employee.jobTitle":              employee.JobTitle,
+		"employee.department":            employee.DepartmentName,
+		"employee.managerName":           employee.ManagerName,
+		"employee.managerIdentityNumber": employee.ManagerIdentityNumber,
+		"employee.status":                strings.ReplaceAll(string(employee.Status), "_", " "),
+		"employee.statusEffectiveDate":   formatDate(employee.StatusEffectiveDate),
+		"today":                          today.Format(DATE_LAYOUT),
+	}
+
+	for key, value := range employee.CustomFields {
+		if value != nil {
+			values[FIELD_PREFIX_CUSTOM_FIELD+key] = fmt.Sprint(value)
+		}
+	}
+
+	for key, value := range inputs {
+		values[FIELD_PREFIX_INPUT+key] = value
+	}
+
+	if payslip != nil {
+		values["payroll.period"] = formatPeriod(payslip.Period)
+		values["payroll.baseSalary"] = formatRupiah(payslip.Item.BaseSalary)
+		values["payroll.gross"] = formatRupiah(payslip.Item.Gross)
+		values["payroll.totalDeductions"] = formatRupiah(payslip.Item.TotalDeductions)
+		values["payroll.net"] = formatRupiah(payslip.Item.Net)
+	}
+
+	return values
+}
+
+// merge replaces the merge fields of text with their values. Input fields
+// without a value are reported back as missing.
+func merge(text string, values map[string]string) (string, []string) {
+	var missing []string
+	merged := mergeFieldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		field := mergeFieldPattern.FindStringSubmatch(match)[1]
+		value, ok := values[field]
+		if !ok && strings.HasPrefix(field, FIELD_PREFIX_INPUT) {
+			missing = append(missing, field)
+		}
+		return value
+	})
+
+	return merged, missing
+}
+
+// mergeTemplate merges the title and body of a template, splitting the body
+// into paragraphs on blank lines.
+func mergeTemplate(template *dto.DocumentTemplate, values map[string]string) (string, []string, error) {
+	title, missingInTitle := merge(template.Title, values)
+	body, missingInBody := merge(template.Body, values)
+
+	missing := append(missingInTitle, missingInBody...)
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", nil, errors.Wrapf(customErrors.ErrBadRequest, "missing merge fields %s", strings.Join(missing, ", "))
+	}
+
+	var paragraphs []string
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	for _, paragraph := range paragraphBreak.Split(body, -1) {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+
+	return title, paragraphs, nil
+}
+
+// formatRupiah writes an amount the Indonesian way, as in Rp 1.250.000.
+func formatRupiah(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return sign + "Rp " + grouped.String()
+}
+
+func formatDate(date string) string {
+	parsed, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return date
+	}
+
+	return parsed.Format(DATE_LAYOUT)
+}
+
+func formatPeriod(period string) string {
+	parsed, err := time.Parse(payrollUsecase.PERIOD_LAYOUT, period)
+	if err != nil {
+		return period
+	}
+
+	return parsed.Format("January 2006")
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "time"
+
+type Category string
+
+const (
+	CategoryContract    Category = "contract"
+	CategoryIdentity    Category = "identity"
+	CategoryCertificate Category = "certificate"
+	CategoryTax         Category = "tax"
+	CategoryMedical     Category = "medical"
+	CategoryPayslip     Category = "payslip"
+	CategoryOther       Category = "other"
+)
+
+type Document struct {
+	DocumentId  string    `json:"documentId"`
+	Category    Category  `json:"category"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	ExpiresAt   string    `json:"expiresAt"`
+	IsExpired   bool      `json:"isExpired"`
+	UploadedBy  string    `json:"uploadedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	FileKey     string    `json:"-"`
+}
+
+type ExpiringDocument struct {
+	Document
+	EmployeeIdentityNumber string `json:"employeeIdentityNumber"`
+	EmployeeName           string `json:"employeeName"`
+}
+
+// UploadDocumentPayload holds the form fields sent next to the file.
+type UploadDocumentPayload struct {
+	IdentityNumber string   `param:"identityNumber" validate:"required"`
+	Category       Category `form:"category" validate:"required,oneof=contract identity certificate tax medical payslip other"`
+	Name           string   `form:"name" validate:"omitempty,max=255"`
+	ExpiresAt      string   `form:"expiresAt" validate:"omitempty,datetime=2006-01-02"`
+	ContentType    string
+	Size           int64
+}
+
+type GetDocumentParams struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	Category       string `query:"category" validate:"omitempty,oneof=contract identity certificate tax medical payslip other"`
+}
+
+type DocumentPathParam struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	DocumentId     int    `param:"documentId" validate:"required,min=1"`
+}
+
+type GetExpiringDocumentParams struct {
+	WithinDays int `query:"withinDays" validate:"min=0,max=365"`
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"ps-gogo-manajer/internal/document/dto"
+	"ps-gogo-manajer/internal/document/usecase"
+	fileUsecase "ps-gogo-manajer/internal/files/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	customValidators "ps-gogo-manajer/pkg/custom-validators"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type DocumentHandler struct {
+	documentUsecase usecase.DocumentUsecase
+	validator       *validator.Validate
+}
+
+const (
+	DEFAULT_LIMIT       = 5
+	DEFAULT_OFFSET      = 0
+	DEFAULT_WITHIN_DAYS = 30
+)
+
+func NewDocumentHandler(documentUsecase usecase.DocumentUsecase, validator *validator.Validate) *DocumentHandler {
+	return &DocumentHandler{
+		documentUsecase: documentUsecase,
+		validator:       validator,
+	}
+}
+
+func (h DocumentHandler) UploadDocument(ctx echo.Context) error {
+	var payload dto.UploadDocumentPayload
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if fileHeader.Size > usecase.MAX_DOCUMENT_SIZE {
+		err := errors.Wrap(customErrors.ErrBadRequest, "file is too large")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+	defer file.Close()
+
+	fileType, err := fileUsecase.DetectFileType(file)
+	if err != nil || !usecase.DocumentTypes[fileType] {
+		err := errors.Wrap(customErrors.ErrBadRequest, "file is invalid")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	payload.ContentType = fileType
+	payload.Size = fileHeader.Size
+	if payload.Name == "" {
+		payload.Name = fileHeader.Filename
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	document, err := h.documentUsecase.UploadDocument(ctx.Request().Context(), userData.Id, &payload, file)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, document)
+}
+
+func (h DocumentHandler) GetListDocument(ctx echo.Context) error {
+	var payload dto.GetDocumentParams
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	documents, err := h.documentUsecase.GetListDocument(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, documents)
+}
+
+func (h DocumentHandler) DownloadDocument(ctx echo.Context) error {
+	var payload dto.DocumentPathParam
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	document, content, err := h.documentUsecase.DownloadDocument(ctx.Request().Context(), userData.Id, payload.IdentityNumber, payload.DocumentId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+	defer content.Close()
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", document.Name))
+	return ctx.Stream(http.StatusOK, document.ContentType, content)
+}
+
+func (h DocumentHandler) DeleteDocument(ctx echo.Context) error {
+	var payload dto.DocumentPathParam
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	err := h.documentUsecase.DeleteDocument(ctx.Request().Context(), userData.Id, payload.IdentityNumber, payload.DocumentId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h DocumentHandler) GetExpiringDocument(ctx echo.Context) error {
+	limitStr := ctx.QueryParam("limit")
+	offsetStr := ctx.QueryParam("offset")
+
+	payload := dto.GetExpiringDocumentParams{
+		WithinDays: DEFAULT_WITHIN_DAYS,
+		Limit:      customValidators.ParseLimitOffset(limitStr, DEFAULT_LIMIT),
+		Offset:     customValidators.ParseLimitOffset(offsetStr, DEFAULT_OFFSET),
+	}
+
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	documents, err := h.documentUsecase.GetExpiringDocument(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, documents)
+}
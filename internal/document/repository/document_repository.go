@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/document/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type DocumentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDocumentRepository(pool *pgxpool.Pool) *DocumentRepository {
+	return &DocumentRepository{pool: pool}
+}
+
+const (
+	documentColumns = `
+		employee_documents.id,
+		employee_documents.category,
+		employee_documents.name,
+		employee_documents.content_type,
+		employee_documents.size,
+		employee_documents.expires_at::text,
+		COALESCE(employee_documents.expires_at < CURRENT_DATE, FALSE),
+		(SELECT users.email FROM users WHERE users.id = employee_documents.uploaded_by),
+		employee_documents.created_at,
+		employee_documents.file_key`
+
+	queryCheckIfEmployeeExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+	) is_exists;`
+	queryCreateDocument = `
+	INSERT INTO employee_documents(employee_id, category, name, file_key, content_type, size, expires_at, uploaded_by)
+	SELECT
+		employees.id,
+		@category::enum_document_category,
+		@name,
+		@fileKey,
+		@contentType,
+		@size,
+		NULLIF(@expiresAt, '')::date,
+		@uploadedBy
+	FROM employees
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL
+	RETURNING` + documentColumns + `;`
+	queryGetListDocument = `
+	SELECT` + documentColumns + `
+	FROM employee_documents
+	JOIN employees ON employees.id = employee_documents.employee_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL
+		AND (NULLIF(@category, '') IS NULL OR employee_documents.category = NULLIF(@category, '')::enum_document_category)
+	ORDER BY employee_documents.created_at DESC, employee_documents.id DESC;`
+	queryGetDocument = `
+	SELECT` + documentColumns + `
+	FROM employee_documents
+	JOIN employees ON employees.id = employee_documents.employee_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL
+		AND employee_documents.id = @documentID;`
+	queryDeleteDocument = `
+	DELETE FROM employee_documents
+	USING employees
+	WHERE
+		employees.id = employee_documents.employee_id
+		AND employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL
+		AND employee_documents.id = @documentID
+	RETURNING employee_documents.file_key;`
+	queryGetExpiringDocument = `
+	SELECT` + documentColumns + `,
+		employees.identity_number,
+		employees.name
+	FROM employee_documents
+	JOIN employees ON employees.id = employee_documents.employee_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.deleted_at IS NULL
+		AND employee_documents.expires_at <= CURRENT_DATE + @withinDays::int
+	ORDER BY employee_documents.expires_at, employee_documents.id
+	OFFSET @offset
+	LIMIT @limit;`
+)
+
+// scanDocument scans a row selected with documentColumns. Any extra
+// destinations are scanned after it, in order.
+func scanDocument(row pgx.Row, dest ...any) (*dto.Document, error) {
+	var document dto.Document
+	expiresAt := new(pgtype.Text)
+	uploadedBy := new(pgtype.Text)
+
+	dest = append([]any{
+		&document.DocumentId,
+		&document.Category,
+		&document.Name,
+		&document.ContentType,
+		&document.Size,
+		expiresAt,
+		&document.IsExpired,
+		uploadedBy,
+		&document.CreatedAt,
+		&document.FileKey,
+	}, dest...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	document.ExpiresAt = expiresAt.String
+	document.UploadedBy = uploadedBy.String
+	return &document, nil
+}
+
+func (r *DocumentRepository) CheckIfEmployeeExists(ctx context.Context, userID int, identityNumber string) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryCheckIfEmployeeExists, args).Scan(&isExists)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check employee")
+	}
+
+	return isExists, nil
+}
+
+func (r *DocumentRepository) CreateDocument(ctx context.Context, userID int, payload *dto.UploadDocumentPayload, fileKey string) (*dto.Document, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": payload.IdentityNumber,
+		"category":       payload.Category,
+		"name":           payload.Name,
+		"fileKey":        fileKey,
+		"contentType":    payload.ContentType,
+		"size":           payload.Size,
+		"expiresAt":      payload.ExpiresAt,
+		"uploadedBy":     userID,
+	}
+
+	document, err := scanDocument(r.pool.QueryRow(ctx, queryCreateDocument, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create document")
+	}
+
+	return document, nil
+}
+
+func (r *DocumentRepository) GetListDocument(ctx context.Context, userID int, params *dto.GetDocumentParams) ([]dto.Document, error) {
+	documents := make([]dto.Document, 0)
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": params.IdentityNumber,
+		"category":       params.Category,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListDocument, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list document")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		document, err := scanDocument(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		documents = append(documents, *document)
+	}
+
+	return documents, nil
+}
+
+func (r *DocumentRepository) GetDocument(ctx context.Context, userID int, identityNumber string, documentID int) (*dto.Document, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+		"documentID":     documentID,
+	}
+
+	document, err := scanDocument(r.pool.QueryRow(ctx, queryGetDocument, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get document")
+	}
+
+	return document, nil
+}
+
+// DeleteDocument removes the document record and returns the key of the
+// stored file so the caller can remove it from storage.
+func (r *DocumentRepository) DeleteDocument(ctx context.Context, userID int, identityNumber string, documentID int) (string, error) {
+	var fileKey string
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+		"documentID":     documentID,
+	}
+
+	err := r.pool.QueryRow(ctx, queryDeleteDocument, args).Scan(&fileKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to delete document")
+	}
+
+	return fileKey, nil
+}
+
+func (r *DocumentRepository) GetExpiringDocument(ctx context.Context, userID int, params *dto.GetExpiringDocumentParams) ([]dto.ExpiringDocument, error) {
+	documents := make([]dto.ExpiringDocument, 0)
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"withinDays": params.WithinDays,
+		"offset":     params.Offset,
+		"limit":      params.Limit,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetExpiringDocument, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get expiring document")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var expiring dto.ExpiringDocument
+		document, err := scanDocument(rows, &expiring.EmployeeIdentityNumber, &expiring.EmployeeName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		expiring.Document = *document
+		documents = append(documents, expiring)
+	}
+
+	return documents, nil
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"ps-gogo-manajer/internal/document/dto"
+	"ps-gogo-manajer/internal/document/repository"
+	fileUsecase "ps-gogo-manajer/internal/files/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	MAX_DOCUMENT_SIZE   = 10 * 1024 * 1024
+	DOCUMENT_KEY_PREFIX = "documents/%d/"
+)
+
+// DocumentTypes lists the content types accepted for employee documents.
+var DocumentTypes = map[string]bool{
+	fileUsecase.PDF:  true,
+	fileUsecase.JPEG: true,
+	fileUsecase.JPG:  true,
+	fileUsecase.PNG:  true,
+}
+
+type DocumentUsecase struct {
+	documentRepo repository.DocumentRepository
+	fileUsecase  *fileUsecase.FileUsecase
+	log          *logrus.Logger
+}
+
+func NewDocumentUsecase(documentRepo repository.DocumentRepository, fileUsecase *fileUsecase.FileUsecase, log *logrus.Logger) *DocumentUsecase {
+	return &DocumentUsecase{
+		documentRepo: documentRepo,
+		fileUsecase:  fileUsecase,
+		log:          log,
+	}
+}
+
+func (u *DocumentUsecase) UploadDocument(ctx context.Context, userID int, payload *dto.UploadDocumentPayload, file multipart.File) (*dto.Document, error) {
+	if err := u.validateEmployeeExists(ctx, userID, payload.IdentityNumber); err != nil {
+		return nil, err
+	}
+
+	return u.saveDocument(ctx, userID, payload, file)
+}
+
+// SaveGeneratedDocument stores a document produced in-process, such as a
+// generated payslip, in the employee's vault.
+func (u *DocumentUsecase) SaveGeneratedDocument(ctx context.Context, userID int, payload *dto.UploadDocumentPayload, content []byte) (*dto.Document, error) {
+	if err := u.validateEmployeeExists(ctx, userID, payload.IdentityNumber); err != nil {
+		return nil, err
+	}
+
+	payload.Size = int64(len(content))
+	return u.saveDocument(ctx, userID, payload, bytes.NewReader(content))
+}
+
+func (u *DocumentUsecase) saveDocument(ctx context.Context, userID int, payload *dto.UploadDocumentPayload, body io.Reader) (*dto.Document, error) {
+	prefix := fmt.Sprintf(DOCUMENT_KEY_PREFIX, userID)
+	fileKey, err := u.fileUsecase.UploadPrivateContent(ctx, body, payload.ContentType, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := u.documentRepo.CreateDocument(ctx, userID, payload, fileKey)
+	if err != nil {
+		u.deleteFile(ctx, fileKey)
+		return nil, err
+	}
+
+	return document, nil
+}
+
+func (u *DocumentUsecase) GetListDocument(ctx context.Context, userID int, params *dto.GetDocumentParams) ([]dto.Document, error) {
+	if err := u.validateEmployeeExists(ctx, userID, params.IdentityNumber); err != nil {
+		return nil, err
+	}
+
+	return u.documentRepo.GetListDocument(ctx, userID, params)
+}
+
+// DownloadDocument returns the document together with its content. The
+// caller must close the reader.
+func (u *DocumentUsecase) DownloadDocument(ctx context.Context, userID int, identityNumber string, documentID int) (*dto.Document, io.ReadCloser, error) {
+	document, err := u.documentRepo.GetDocument(ctx, userID, identityNumber, documentID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, nil, errors.Wrap(customErrors.ErrNotFound, "document not found")
+		}
+		return nil, nil, err
+	}
+
+	content, err := u.fileUsecase.DownloadFile(ctx, document.FileKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return document, content, nil
+}
+
+func (u *DocumentUsecase) DeleteDocument(ctx context.Context, userID int, identityNumber string, documentID int) error {
+	fileKey, err := u.documentRepo.DeleteDocument(ctx, userID, identityNumber, documentID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return errors.Wrap(customErrors.ErrNotFound, "document not found")
+		}
+		return err
+	}
+
+	u.deleteFile(ctx, fileKey)
+	return nil
+}
+
+func (u *DocumentUsecase) GetExpiringDocument(ctx context.Context, userID int, params *dto.GetExpiringDocumentParams) ([]dto.ExpiringDocument, error) {
+	return u.documentRepo.GetExpiringDocument(ctx, userID, params)
+}
+
+func (u *DocumentUsecase) validateEmployeeExists(ctx context.Context, userID int, identityNumber string) error {
+	isEmployeeExists, err := u.documentRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
+	if err != nil {
+		return err
+	}
+
+	if !isEmployeeExists {
+		return errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	return nil
+}
+
+// deleteFile removes a stored file whose record is already gone. A failure
+// only leaves an unreferenced private object behind, so it is logged
+// instead of failing the request.
+func (u *DocumentUsecase) deleteFile(ctx context.Context, fileKey string) {
+	if err := u.fileUsecase.DeleteFile(ctx, fileKey); err != nil {
+		u.log.WithField("key", fileKey).WithError(err).Warn("failed to delete document file")
+	}
+}
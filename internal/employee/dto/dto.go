@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type Gender string
 
 const (
@@ -7,15 +9,34 @@ const (
 	GenderFemale Gender = "female"
 )
 
+type Status string
+
+const (
+	StatusCandidate  Status = "candidate"
+	StatusOnboarding Status = "onboarding"
+	StatusActive     Status = "active"
+	StatusOnLeave    Status = "on_leave"
+	StatusSuspended  Status = "suspended"
+	StatusTerminated Status = "terminated"
+)
+
 type Employee struct {
-	Name             string `json:"name"`
-	IdentityNumber   string `json:"identityNumber"`
-	Gender           Gender `json:"gender"`
-	DepartmentId     string `json:"departmentId"`
-	EmployeeImageUri string `json:"employeeImageUri"`
+	Name                  string         `json:"name"`
+	IdentityNumber        string         `json:"identityNumber"`
+	Gender                Gender         `json:"gender"`
+	DepartmentId          string         `json:"departmentId"`
+	PositionId            string         `json:"positionId"`
+	EmployeeImageUri      string         `json:"employeeImageUri"`
+	JobTitle              string         `json:"jobTitle"`
+	JobTitleId            string         `json:"jobTitleId"`
+	LocationId            string         `json:"locationId"`
+	CostCenterId          string         `json:"costCenterId"`
+	ManagerIdentityNumber string         `json:"managerIdentityNumber"`
+	CustomFields          map[string]any `json:"customFields"`
+	Status                Status         `json:"status"`
+	StatusEffectiveDate   string         `json:"statusEffectiveDate"`
 }
 
-// TODO:
 type GetEmployeeParams struct {
 	Limit          int
 	Offset         int
@@ -23,24 +44,171 @@ type GetEmployeeParams struct {
 	IdentityNumber string `query:"identityNumber" validate:"omitempty"`
 	Name           string `query:"name" validate:"omitempty"`
 	DepartmentId   int
+	LocationId     int
+	CostCenterId   int
+	// IncludeSubDepartments widens the DepartmentId filter to every
+	// department nested under it.
+	IncludeSubDepartments bool   `query:"includeSubDepartments"`
+	Sort                  string `query:"sort"`
+	Pagination            string `query:"pagination" validate:"omitempty,oneof=offset cursor"`
+	Cursor                string `query:"cursor"`
+	WithTotal             bool   `query:"withTotal"`
+	// CustomFields holds the raw cf.<key>=value filters of the query string,
+	// CustomFieldFilter the same filters typed after their definitions.
+	CustomFields      map[string]string
+	CustomFieldFilter map[string]any
+	// Statuses filters on a comma separated list of statuses. Terminated
+	// employees are left out unless asked for or IncludeTerminated is set.
+	Statuses          []string
+	IncludeTerminated bool `query:"includeTerminated"`
+}
+
+// IsCursorPagination reports whether the client asked for the paginated
+// envelope instead of the plain array returned to older clients.
+func (p *GetEmployeeParams) IsCursorPagination() bool {
+	return p.Pagination == "cursor" || p.Cursor != ""
 }
 
 type CreateEmployeePayload struct {
-	IdentityNumber   string `json:"identityNumber" validate:"required,min=5,max=33"`
-	Name             string `json:"name" validate:"required,min=4,max=33"`
-	Gender           Gender `json:"gender" validate:"required,oneof=male female"`
-	DepartmentId     string `json:"departmentId" validate:"required,number"`
-	EmployeeImageUri string `json:"employeeImageUri" validate:"required"`
+	IdentityNumber        string         `json:"identityNumber" validate:"required,min=5,max=33"`
+	Name                  string         `json:"name" validate:"required,min=4,max=33"`
+	Gender                Gender         `json:"gender" validate:"required,oneof=male female"`
+	DepartmentId          string         `json:"departmentId" validate:"required,number"`
+	EmployeeImageUri      string         `json:"employeeImageUri" validate:"required"`
+	JobTitle              string         `json:"jobTitle" validate:"omitempty,max=255"`
+	ManagerIdentityNumber string         `json:"managerIdentityNumber" validate:"omitempty,min=5,max=33"`
+	CustomFields          map[string]any `json:"customFields"`
+	Status                Status         `json:"status" validate:"omitempty,oneof=candidate onboarding active"`
+	PositionId            string         `json:"positionId" validate:"omitempty,number"`
+	// JobTitleId picks the title from the catalog, taking the place of the
+	// free text JobTitle.
+	JobTitleId   string `json:"jobTitleId" validate:"omitempty,number"`
+	LocationId   string `json:"locationId" validate:"omitempty,number"`
+	CostCenterId string `json:"costCenterId" validate:"omitempty,number"`
+	// AllowOverHeadcount creates the employee even when the department or
+	// the position has no vacant seat left, which is refused otherwise.
+	AllowOverHeadcount bool `json:"allowOverHeadcount"`
 }
 
 type PatchEmployeePayload struct {
-	IdentityNumber   string `json:"identityNumber" validate:"required,omitempty,min=5,max=33"`
-	Name             string `json:"name" validate:"required,omitempty,min=4,max=33"`
-	Gender           Gender `json:"gender" validate:"required,omitempty,oneof=male female"`
-	DepartmentId     string `json:"departmentId" validate:"required,omitempty,number"`
-	EmployeeImageUri string `json:"employeeImageUri" validate:"required,omitempty"`
+	IdentityNumber        string         `json:"identityNumber" validate:"required,omitempty,min=5,max=33"`
+	Name                  string         `json:"name" validate:"required,omitempty,min=4,max=33"`
+	Gender                Gender         `json:"gender" validate:"required,omitempty,oneof=male female"`
+	DepartmentId          string         `json:"departmentId" validate:"required,omitempty,number"`
+	EmployeeImageUri      string         `json:"employeeImageUri" validate:"required,omitempty"`
+	JobTitle              string         `json:"jobTitle" validate:"omitempty,max=255"`
+	ManagerIdentityNumber string         `json:"managerIdentityNumber" validate:"omitempty,min=5,max=33"`
+	CustomFields          map[string]any `json:"customFields"`
+	PositionId            string         `json:"positionId" validate:"omitempty,number"`
+	// JobTitleId picks the title from the catalog. A free text JobTitle
+	// without it takes the employee off the catalog.
+	JobTitleId   string `json:"jobTitleId" validate:"omitempty,number"`
+	LocationId   string `json:"locationId" validate:"omitempty,number"`
+	CostCenterId string `json:"costCenterId" validate:"omitempty,number"`
 }
 
 type UpdateDeletePathParam struct {
 	IdentityNumber string `param:"identityNumber" validate:"required"`
 }
+
+type EmployeeChange struct {
+	Field    string  `json:"field"`
+	OldValue *string `json:"oldValue"`
+	NewValue *string `json:"newValue"`
+}
+
+type EmployeeVersion struct {
+	Version   int              `json:"version"`
+	ChangedBy string           `json:"changedBy"`
+	ChangedAt time.Time        `json:"changedAt"`
+	Changes   []EmployeeChange `json:"changes"`
+}
+
+type Report struct {
+	Employee
+	Level int `json:"level"`
+}
+
+type GetReportsParams struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	Recursive      bool   `query:"recursive"`
+}
+
+type OrgChartNode struct {
+	Employee
+	Reports []*OrgChartNode `json:"reports"`
+}
+
+type SearchEmployeeParams struct {
+	Query  string `query:"q" validate:"required,min=2,max=100"`
+	Limit  int
+	Offset int
+}
+
+type EmployeeSearchResult struct {
+	Employee
+	DepartmentName string            `json:"departmentName"`
+	Rank           float64           `json:"rank"`
+	Highlights     map[string]string `json:"highlights"`
+}
+
+type RevertEmployeePathParam struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	Version        int    `param:"version" validate:"min=0"`
+}
+
+type TerminationDetails struct {
+	Type              string `json:"type"`
+	LastWorkingDate   string `json:"lastWorkingDate"`
+	EligibleForRehire bool   `json:"eligibleForRehire"`
+}
+
+type StatusTransition struct {
+	FromStatus    *Status             `json:"fromStatus"`
+	ToStatus      Status              `json:"toStatus"`
+	EffectiveDate string              `json:"effectiveDate"`
+	Reason        string              `json:"reason"`
+	Termination   *TerminationDetails `json:"termination,omitempty"`
+	State         string              `json:"state"`
+	ChangedBy     string              `json:"changedBy"`
+	CreatedAt     time.Time           `json:"createdAt"`
+}
+
+type ChangeStatusPayload struct {
+	Status            Status `json:"status" validate:"required,oneof=candidate onboarding active on_leave suspended terminated"`
+	EffectiveDate     string `json:"effectiveDate" validate:"omitempty,datetime=2006-01-02"`
+	Reason            string `json:"reason" validate:"required_if=Status terminated,omitempty,max=500"`
+	TerminationType   string `json:"terminationType" validate:"required_if=Status terminated,omitempty,oneof=resignation dismissal end_of_contract retirement death other"`
+	LastWorkingDate   string `json:"lastWorkingDate" validate:"omitempty,datetime=2006-01-02"`
+	EligibleForRehire bool   `json:"eligibleForRehire"`
+}
+
+type DepartmentTransfer struct {
+	FromDepartmentId   *string   `json:"fromDepartmentId"`
+	FromDepartmentName string    `json:"fromDepartmentName"`
+	ToDepartmentId     *string   `json:"toDepartmentId"`
+	ToDepartmentName   string    `json:"toDepartmentName"`
+	EffectiveDate      string    `json:"effectiveDate"`
+	Reason             string    `json:"reason"`
+	State              string    `json:"state"`
+	ChangedBy          string    `json:"changedBy"`
+	CreatedAt          time.Time `json:"createdAt"`
+}
+
+type TransferPayload struct {
+	DepartmentId  string `json:"departmentId" validate:"required,number"`
+	EffectiveDate string `json:"effectiveDate" validate:"omitempty,datetime=2006-01-02"`
+	Reason        string `json:"reason" validate:"required,max=500"`
+}
+
+// DepartmentAssignment is the department an employee is in on a date.
+type DepartmentAssignment struct {
+	DepartmentId   *string `json:"departmentId"`
+	DepartmentName string  `json:"departmentName"`
+	EffectiveDate  string  `json:"effectiveDate"`
+}
+
+type GetDepartmentAsOfParams struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	AsOf           string `query:"asOf" validate:"omitempty,datetime=2006-01-02"`
+}
@@ -7,7 +7,9 @@ import (
 	customErrors "ps-gogo-manajer/pkg/custom-errors"
 	customValidators "ps-gogo-manajer/pkg/custom-validators"
 	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/pagination"
 	"ps-gogo-manajer/pkg/response"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -22,6 +24,8 @@ type EmployeeHandler struct {
 const (
 	DEFAULT_LIMIT  = 5
 	DEFAULT_OFFSET = 0
+
+	CUSTOM_FIELD_FILTER_PREFIX = "cf."
 )
 
 func NewEmployeeHandler(employeeUsecase usecase.EmployeeUsecase, validator *validator.Validate) *EmployeeHandler {
@@ -63,13 +67,28 @@ func (h EmployeeHandler) GetListEmployee(ctx echo.Context) error {
 	genderStr := ctx.QueryParam("gender")
 	gender, isValid := customValidators.ParseGender(genderStr)
 	if !isValid {
-		return ctx.JSON(http.StatusOK, make([]string, 0))
+		return h.emptyEmployeeList(ctx)
 	}
 
 	departmentIDStr := ctx.QueryParam("departmentId")
 	departmentID, isValid := customValidators.ParseDepartmentID(departmentIDStr)
 	if !isValid {
-		return ctx.JSON(http.StatusOK, make([]string, 0))
+		return h.emptyEmployeeList(ctx)
+	}
+
+	locationID, isValid := customValidators.ParseID(ctx.QueryParam("locationId"))
+	if !isValid {
+		return h.emptyEmployeeList(ctx)
+	}
+
+	costCenterID, isValid := customValidators.ParseID(ctx.QueryParam("costCenterId"))
+	if !isValid {
+		return h.emptyEmployeeList(ctx)
+	}
+
+	statuses, isValid := parseStatuses(ctx.QueryParam("status"))
+	if !isValid {
+		return h.emptyEmployeeList(ctx)
 	}
 
 	limitStr := ctx.QueryParam("limit")
@@ -83,6 +102,10 @@ func (h EmployeeHandler) GetListEmployee(ctx echo.Context) error {
 		Offset:       offset,
 		Gender:       gender,
 		DepartmentId: departmentID,
+		LocationId:   locationID,
+		CostCenterId: costCenterID,
+		CustomFields: parseCustomFieldFilters(ctx),
+		Statuses:     statuses,
 	}
 
 	if err := ctx.Bind(&payload); err != nil {
@@ -90,7 +113,21 @@ func (h EmployeeHandler) GetListEmployee(ctx echo.Context) error {
 		return ctx.JSON(response.WriteErrorResponse(err))
 	}
 
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
 	userData := ctx.Get("user").(*jwt.JwtClaim)
+	if payload.IsCursorPagination() {
+		page, err := h.employeeUsecase.GetPageEmployee(ctx.Request().Context(), userData.Id, &payload)
+		if err != nil {
+			return ctx.JSON(response.WriteErrorResponse(err))
+		}
+
+		return ctx.JSON(http.StatusOK, page)
+	}
+
 	employees, err := h.employeeUsecase.GetListEmployee(ctx.Request().Context(), userData.Id, &payload)
 	if err != nil {
 		return ctx.JSON(response.WriteErrorResponse(err))
@@ -103,6 +140,55 @@ func (h EmployeeHandler) GetListEmployee(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, &employees)
 }
 
+// parseCustomFieldFilters collects the cf.<key>=value query parameters used
+// to filter on custom fields.
+func parseCustomFieldFilters(ctx echo.Context) map[string]string {
+	var filters map[string]string
+	for key, values := range ctx.QueryParams() {
+		fieldKey, isFilter := strings.CutPrefix(key, CUSTOM_FIELD_FILTER_PREFIX)
+		if !isFilter || fieldKey == "" || len(values) == 0 {
+			continue
+		}
+
+		if filters == nil {
+			filters = make(map[string]string)
+		}
+		filters[fieldKey] = values[0]
+	}
+
+	return filters
+}
+
+// parseStatuses splits the comma separated status filter, reporting false
+// when it names a status that does not exist.
+func parseStatuses(value string) ([]string, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	statuses := strings.Split(value, ",")
+	for _, status := range statuses {
+		switch dto.Status(status) {
+		case dto.StatusCandidate, dto.StatusOnboarding, dto.StatusActive,
+			dto.StatusOnLeave, dto.StatusSuspended, dto.StatusTerminated:
+		default:
+			return nil, false
+		}
+	}
+
+	return statuses, true
+}
+
+// emptyEmployeeList answers filters that can never match, in whichever
+// shape the client asked for.
+func (h EmployeeHandler) emptyEmployeeList(ctx echo.Context) error {
+	if ctx.QueryParam("pagination") == "cursor" || ctx.QueryParam("cursor") != "" {
+		return ctx.JSON(http.StatusOK, pagination.Page[dto.Employee]{Data: make([]dto.Employee, 0)})
+	}
+
+	return ctx.JSON(http.StatusOK, make([]string, 0))
+}
+
 func (h EmployeeHandler) UpdateEmployee(ctx echo.Context) error {
 	identityNumber := ctx.Param("identityNumber")
 	if identityNumber == "" {
@@ -157,3 +243,240 @@ func (h EmployeeHandler) DeleteEmployee(ctx echo.Context) error {
 		Message: "deleted",
 	})
 }
+
+func (h EmployeeHandler) RestoreEmployee(ctx echo.Context) error {
+	var payload dto.UpdateDeletePathParam
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	employee, err := h.employeeUsecase.RestoreEmployee(ctx.Request().Context(), userData.Id, payload.IdentityNumber)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, employee)
+}
+
+func (h EmployeeHandler) GetEmployeeHistory(ctx echo.Context) error {
+	var payload dto.UpdateDeletePathParam
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	history, err := h.employeeUsecase.GetEmployeeHistory(ctx.Request().Context(), userData.Id, payload.IdentityNumber)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, history)
+}
+
+func (h EmployeeHandler) RevertEmployee(ctx echo.Context) error {
+	var payload dto.RevertEmployeePathParam
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	employee, err := h.employeeUsecase.RevertEmployee(ctx.Request().Context(), userData.Id, payload.IdentityNumber, payload.Version)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, employee)
+}
+
+func (h EmployeeHandler) RemoveManager(ctx echo.Context) error {
+	var payload dto.UpdateDeletePathParam
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	employee, err := h.employeeUsecase.RemoveManager(ctx.Request().Context(), userData.Id, payload.IdentityNumber)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, employee)
+}
+
+func (h EmployeeHandler) GetReports(ctx echo.Context) error {
+	var payload dto.GetReportsParams
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	reports, err := h.employeeUsecase.GetReports(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, reports)
+}
+
+func (h EmployeeHandler) GetOrgChart(ctx echo.Context) error {
+	departmentIDStr := ctx.QueryParam("departmentId")
+	departmentID, isValid := customValidators.ParseDepartmentID(departmentIDStr)
+	if !isValid {
+		err := errors.Wrap(customErrors.ErrBadRequest, "invalid department id")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	chart, err := h.employeeUsecase.GetOrgChart(ctx.Request().Context(), userData.Id, departmentID)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, chart)
+}
+
+func (h EmployeeHandler) SearchEmployee(ctx echo.Context) error {
+	limitStr := ctx.QueryParam("limit")
+	offsetStr := ctx.QueryParam("offset")
+
+	payload := dto.SearchEmployeeParams{
+		Limit:  customValidators.ParseLimitOffset(limitStr, DEFAULT_LIMIT),
+		Offset: customValidators.ParseLimitOffset(offsetStr, DEFAULT_OFFSET),
+	}
+
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	results, err := h.employeeUsecase.SearchEmployee(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, results)
+}
+
+func (h EmployeeHandler) ChangeStatus(ctx echo.Context) error {
+	identityNumber := ctx.Param("identityNumber")
+	if identityNumber == "" {
+		err := errors.Wrap(customErrors.ErrBadRequest, "identity number required")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.ChangeStatusPayload
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	employee, err := h.employeeUsecase.ChangeStatus(ctx.Request().Context(), userData.Id, identityNumber, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, employee)
+}
+
+func (h EmployeeHandler) GetStatusHistory(ctx echo.Context) error {
+	var payload dto.UpdateDeletePathParam
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	history, err := h.employeeUsecase.GetStatusHistory(ctx.Request().Context(), userData.Id, payload.IdentityNumber)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, history)
+}
+
+func (h EmployeeHandler) TransferEmployee(ctx echo.Context) error {
+	identityNumber := ctx.Param("identityNumber")
+	if identityNumber == "" {
+		err := errors.Wrap(customErrors.ErrBadRequest, "identity number required")
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.TransferPayload
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	employee, err := h.employeeUsecase.TransferEmployee(ctx.Request().Context(), userData.Id, identityNumber, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, employee)
+}
+
+func (h EmployeeHandler) GetDepartmentHistory(ctx echo.Context) error {
+	var payload dto.UpdateDeletePathParam
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	history, err := h.employeeUsecase.GetDepartmentHistory(ctx.Request().Context(), userData.Id, payload.IdentityNumber)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, history)
+}
+
+func (h EmployeeHandler) GetDepartmentAsOf(ctx echo.Context) error {
+	var payload dto.GetDepartmentAsOfParams
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	assignment, err := h.employeeUsecase.GetDepartmentAsOf(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, assignment)
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"encoding/json"
+	"ps-gogo-manajer/internal/employee/dto"
+	"sort"
+	"strings"
+)
+
+// Field names recorded in employee_history, matching the JSON names of
+// dto.Employee so clients can map a change straight onto the record.
+const (
+	FieldName                  = "name"
+	FieldIdentityNumber        = "identityNumber"
+	FieldGender                = "gender"
+	FieldDepartmentId          = "departmentId"
+	FieldPositionId            = "positionId"
+	FieldEmployeeImageUri      = "employeeImageUri"
+	FieldJobTitle              = "jobTitle"
+	FieldJobTitleId            = "jobTitleId"
+	FieldLocationId            = "locationId"
+	FieldCostCenterId          = "costCenterId"
+	FieldManagerIdentityNumber = "managerIdentityNumber"
+	// FieldHeadOfDepartmentId is recorded when a department merge hands a
+	// head over to the target department. It is not reverted.
+	FieldHeadOfDepartmentId = "headOfDepartmentId"
+
+	// Custom field changes are recorded per key as "customFields.<key>" with
+	// the JSON encoded value.
+	FieldCustomFieldPrefix = "customFields."
+)
+
+func diffEmployee(before *dto.Employee, after *dto.Employee) []dto.EmployeeChange {
+	var changes []dto.EmployeeChange
+
+	add := func(field string, oldValue string, newValue string) {
+		if oldValue == newValue {
+			return
+		}
+		changes = append(changes, dto.EmployeeChange{
+			Field:    field,
+			OldValue: nullableString(oldValue),
+			NewValue: nullableString(newValue),
+		})
+	}
+
+	add(FieldName, before.Name, after.Name)
+	add(FieldIdentityNumber, before.IdentityNumber, after.IdentityNumber)
+	add(FieldGender, string(before.Gender), string(after.Gender))
+	add(FieldDepartmentId, before.DepartmentId, after.DepartmentId)
+	add(FieldPositionId, before.PositionId, after.PositionId)
+	add(FieldEmployeeImageUri, before.EmployeeImageUri, after.EmployeeImageUri)
+	add(FieldJobTitle, before.JobTitle, after.JobTitle)
+	add(FieldJobTitleId, before.JobTitleId, after.JobTitleId)
+	add(FieldLocationId, before.LocationId, after.LocationId)
+	add(FieldCostCenterId, before.CostCenterId, after.CostCenterId)
+	add(FieldManagerIdentityNumber, before.ManagerIdentityNumber, after.ManagerIdentityNumber)
+
+	for _, key := range customFieldKeys(before.CustomFields, after.CustomFields) {
+		add(FieldCustomFieldPrefix+key, encodeCustomField(before.CustomFields[key]), encodeCustomField(after.CustomFields[key]))
+	}
+
+	return changes
+}
+
+func nullableString(val string) *string {
+	if val == "" {
+		return nil
+	}
+	return &val
+}
+
+func customFieldKeys(before map[string]any, after map[string]any) []string {
+	seen := make(map[string]bool)
+	for key := range before {
+		seen[key] = true
+	}
+	for key := range after {
+		seen[key] = true
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func encodeCustomField(value any) string {
+	if value == nil {
+		return ""
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+// DecodeCustomFieldChange returns the custom field key of a history field
+// and whether the field is a custom field at all.
+func DecodeCustomFieldChange(field string) (string, bool) {
+	if !strings.HasPrefix(field, FieldCustomFieldPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(field, FieldCustomFieldPrefix), true
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	// The base salary is NULL for an employee without a salary structure.
+	queryGetJobTitleSalaryFit = `
+	SELECT
+		job_titles.salary_min,
+		job_titles.salary_max,
+		(
+			SELECT salary_structures.base_salary
+			FROM salary_structures
+			JOIN employees ON employees.id = salary_structures.employee_id
+			WHERE
+				employees.user_id = @userID
+				AND employees.identity_number = @identityNumber
+				AND employees.deleted_at IS NULL
+		)
+	FROM job_titles
+	WHERE
+		job_titles.user_id = @userID
+		AND job_titles.id = @jobTitleID::bigint;`
+)
+
+// GetJobTitleSalaryFit returns the salary range of the job title along with
+// the base salary of the employee, nil when the employee has none yet.
+func (r *EmployeeRepository) GetJobTitleSalaryFit(ctx context.Context, userID int, identityNumber string, jobTitleID string) (*int64, *int64, *int64, error) {
+	var salaryMin, salaryMax, baseSalary *int64
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+		"jobTitleID":     jobTitleID,
+	}
+
+	err := r.pool.QueryRow(ctx, queryGetJobTitleSalaryFit, args).Scan(&salaryMin, &salaryMax, &baseSalary)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to get job title")
+	}
+
+	return salaryMin, salaryMax, baseSalary, nil
+}
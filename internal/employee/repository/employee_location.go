@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	queryCheckIfLocationExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM locations
+		WHERE
+			user_id = @userID
+			AND id = @locationID::bigint
+	) is_exists;`
+	queryCheckIfCostCenterExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM cost_centers
+		WHERE
+			user_id = @userID
+			AND id = @costCenterID::bigint
+	) is_exists;`
+)
+
+func (r *EmployeeRepository) CheckIfLocationExists(ctx context.Context, userID int, locationID string) (bool, error) {
+	var isExist bool
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"locationID": locationID,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCheckIfLocationExists, args).Scan(&isExist); err != nil {
+		return false, errors.Wrap(err, "failed to check if location exists")
+	}
+
+	return isExist, nil
+}
+
+func (r *EmployeeRepository) CheckIfCostCenterExists(ctx context.Context, userID int, costCenterID string) (bool, error) {
+	var isExist bool
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"costCenterID": costCenterID,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCheckIfCostCenterExists, args).Scan(&isExist); err != nil {
+		return false, errors.Wrap(err, "failed to check if cost center exists")
+	}
+
+	return isExist, nil
+}
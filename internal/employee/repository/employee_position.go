@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// ErrNoVacantSeat is returned by CreateEmployee when every seat of the
+// position is filled.
+var ErrNoVacantSeat = errors.New("position has no vacant seat")
+
+// HeadcountError is returned by CreateEmployee when the department is at its
+// approved headcount.
+type HeadcountError struct {
+	Approved int
+}
+
+func (e *HeadcountError) Error() string {
+	return fmt.Sprintf("department is at its approved headcount of %d", e.Approved)
+}
+
+const (
+	// Seats are counted with the department, and the position if any, locked
+	// so that concurrent creates cannot take the same seat.
+	queryLockDepartmentSeats = `
+	SELECT id
+	FROM departments
+	WHERE
+		user_id = @userID
+		AND id = @departmentID::bigint
+	FOR UPDATE;`
+	queryLockPositionSeats = `
+	SELECT id
+	FROM positions
+	WHERE
+		user_id = @userID
+		AND id = @positionID::bigint
+	FOR UPDATE;`
+	// Employees fill a seat until they are terminated.
+	queryGetPositionSeats = `
+	SELECT
+		positions.department_id::text,
+		positions.budgeted_count,
+		(
+			SELECT COUNT(*)
+			FROM employees
+			WHERE
+				employees.position_id = positions.id
+				AND employees.deleted_at IS NULL
+				AND employees.status <> 'terminated'
+		)
+	FROM positions
+	WHERE
+		positions.user_id = @userID
+		AND positions.id = @positionID::bigint;`
+	// The approved headcount of a department is the sum of the seats of its
+	// positions, and is NULL for a department without positions.
+	queryGetDepartmentHeadcount = `
+	SELECT
+		(
+			SELECT SUM(budgeted_count)::int
+			FROM positions
+			WHERE
+				user_id = @userID
+				AND department_id = @departmentID::bigint
+		),
+		(
+			SELECT COUNT(*)
+			FROM employees
+			WHERE
+				user_id = @userID
+				AND department_id = @departmentID::bigint
+				AND deleted_at IS NULL
+				AND status <> 'terminated'
+		);`
+)
+
+// GetPositionSeats returns the department of the position along with its
+// budgeted and filled seats.
+func (r *EmployeeRepository) GetPositionSeats(ctx context.Context, userID int, positionID string) (string, int, int, error) {
+	var departmentID string
+	var budgeted, filled int
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"positionID": positionID,
+	}
+
+	err := r.pool.QueryRow(ctx, queryGetPositionSeats, args).Scan(&departmentID, &budgeted, &filled)
+	if err != nil {
+		return "", 0, 0, errors.Wrap(err, "failed to get position seats")
+	}
+
+	return departmentID, budgeted, filled, nil
+}
+
+// checkSeats refuses a new employee when the position has no vacant seat or
+// the department is at its approved headcount, under a lock held until the
+// transaction ends.
+func (r *EmployeeRepository) checkSeats(ctx context.Context, tx pgx.Tx, userID int, departmentID string, positionID string) error {
+	var lockedID int64
+	var approved *int
+	var budgeted, filled, headcount int
+	var positionDepartmentID string
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"departmentID": departmentID,
+		"positionID":   positionID,
+	}
+
+	if err := tx.QueryRow(ctx, queryLockDepartmentSeats, args).Scan(&lockedID); err != nil {
+		return errors.Wrap(err, "failed to lock department")
+	}
+
+	if positionID != "" {
+		if err := tx.QueryRow(ctx, queryLockPositionSeats, args).Scan(&lockedID); err != nil {
+			return errors.Wrap(err, "failed to lock position")
+		}
+
+		err := tx.QueryRow(ctx, queryGetPositionSeats, args).Scan(&positionDepartmentID, &budgeted, &filled)
+		if err != nil {
+			return errors.Wrap(err, "failed to get position seats")
+		}
+
+		if filled >= budgeted {
+			return ErrNoVacantSeat
+		}
+	}
+
+	err := tx.QueryRow(ctx, queryGetDepartmentHeadcount, args).Scan(&approved, &headcount)
+	if err != nil {
+		return errors.Wrap(err, "failed to get department headcount")
+	}
+
+	if approved != nil && headcount >= *approved {
+		return &HeadcountError{Approved: *approved}
+	}
+
+	return nil
+}
@@ -2,7 +2,12 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	checklistDto "ps-gogo-manajer/internal/checklist/dto"
+	checklistRepository "ps-gogo-manajer/internal/checklist/repository"
 	"ps-gogo-manajer/internal/employee/dto"
+	"ps-gogo-manajer/pkg/pagination"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -10,12 +15,139 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ErrManagerCycle is returned by UpdateEmployee when the new manager
+// already reports, directly or not, to the employee.
+var ErrManagerCycle = errors.New("manager already reports to this employee")
+
 type EmployeeRepository struct {
-	pool *pgxpool.Pool
+	pool          *pgxpool.Pool
+	checklistRepo *checklistRepository.ChecklistRepository
+}
+
+// NewEmployeeRepository takes the checklist repository to create the
+// onboarding and offboarding checklists in the same transaction as the
+// employee change that starts them.
+func NewEmployeeRepository(pool *pgxpool.Pool, checklistRepo *checklistRepository.ChecklistRepository) *EmployeeRepository {
+	return &EmployeeRepository{
+		pool:          pool,
+		checklistRepo: checklistRepo,
+	}
 }
 
-func NewEmployeeRepository(pool *pgxpool.Pool) *EmployeeRepository {
-	return &EmployeeRepository{pool: pool}
+// scanEmployee scans a row selected with employeeColumns. Any extra
+// destinations are scanned first, in order, for columns selected before it.
+func scanEmployee(row pgx.Row, dest ...any) (*dto.Employee, error) {
+	var employee dto.Employee
+	positionID := new(pgtype.Text)
+	imgUri := new(pgtype.Text)
+	jobTitle := new(pgtype.Text)
+	jobTitleID := new(pgtype.Text)
+	locationID := new(pgtype.Text)
+	costCenterID := new(pgtype.Text)
+	managerIdentityNumber := new(pgtype.Text)
+
+	dest = append(dest,
+		&employee.Name,
+		&employee.IdentityNumber,
+		&employee.Gender,
+		&employee.DepartmentId,
+		positionID,
+		imgUri,
+		jobTitle,
+		jobTitleID,
+		locationID,
+		costCenterID,
+		managerIdentityNumber,
+		&employee.CustomFields,
+		&employee.Status,
+		&employee.StatusEffectiveDate,
+	)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	employee.PositionId = positionID.String
+	employee.EmployeeImageUri = imgUri.String
+	employee.JobTitle = jobTitle.String
+	employee.JobTitleId = jobTitleID.String
+	employee.LocationId = locationID.String
+	employee.CostCenterId = costCenterID.String
+	employee.ManagerIdentityNumber = managerIdentityNumber.String
+	return &employee, nil
+}
+
+const (
+	// employeeColumns is the column list scanned by scanEmployee. It is
+	// qualified with the table name so it also works in RETURNING clauses.
+	employeeColumns = `
+		employees.name,
+		employees.identity_number,
+		employees.gender,
+		employees.department_id,
+		employees.position_id::text,
+		employees.employee_image_uri,
+		employees.job_title,
+		employees.job_title_id::text,
+		employees.location_id::text,
+		employees.cost_center_id::text,
+		(
+			SELECT manager.identity_number
+			FROM employees manager
+			WHERE manager.id = employees.manager_id AND manager.deleted_at IS NULL
+		) manager_identity_number,
+		employees.custom_fields,
+		employees.status,
+		employees.status_effective_date::text`
+)
+
+const (
+	employeeListFilter = `
+		employees.user_id = @userID
+		AND employees.deleted_at IS NULL
+		AND (NULLIF(@gender, '') is NULL OR employees.gender = NULLIF(@gender, '')::enum_gender)
+		AND (
+			NULLIF(@departmentID, 0) is NULL
+			OR employees.department_id = NULLIF(@departmentID, 0)::bigint
+			OR (
+				@includeSubDepartments::boolean
+				AND employees.department_id IN (
+					WITH RECURSIVE sub_departments AS (
+						SELECT id, ARRAY[id] path
+						FROM departments
+						WHERE
+							parent_id = NULLIF(@departmentID, 0)::bigint
+							AND deleted_at IS NULL
+						UNION ALL
+						SELECT departments.id, sub_departments.path || departments.id
+						FROM departments
+						JOIN sub_departments ON departments.parent_id = sub_departments.id
+						WHERE
+							departments.deleted_at IS NULL
+							AND NOT departments.id = ANY(sub_departments.path)
+					)
+					SELECT id FROM sub_departments
+				)
+			)
+		)
+		AND (NULLIF(@locationID, 0) is NULL OR employees.location_id = NULLIF(@locationID, 0)::bigint)
+		AND (NULLIF(@costCenterID, 0) is NULL OR employees.cost_center_id = NULLIF(@costCenterID, 0)::bigint)
+		AND (NULLIF(@identityNumber, '') is NULL OR employees.identity_number ILIKE NULLIF(@identityNumber, '') || '%' )
+		AND (NULLIF(@name, '') is NULL OR employees.name ILIKE '%' || NULLIF(@name, '') || '%' )
+		AND (@customFieldFilter::jsonb IS NULL OR employees.custom_fields @> @customFieldFilter::jsonb)
+		AND (cardinality(@statuses::text[]) = 0 OR employees.status::text = ANY(@statuses::text[]))
+		AND (
+			@includeTerminated::boolean
+			OR employees.status <> 'terminated'
+			OR 'terminated' = ANY(@statuses::text[])
+		)` + employeeScopeFilter
+)
+
+// EmployeeSortColumns whitelists the values accepted by the sort parameter
+// of the employee list. Prefix a key with "-" to sort descending.
+var EmployeeSortColumns = map[string]pagination.Column{
+	"id":             {Expr: "employees.id", Type: "bigint"},
+	"name":           {Expr: "employees.name", Type: "text"},
+	"identityNumber": {Expr: "employees.identity_number", Type: "text"},
 }
 
 const (
@@ -26,7 +158,20 @@ const (
 		WHERE
 			user_id = @userID
 			AND identity_number = @identityNumber
+			AND deleted_at IS NULL` + employeeScopeFilter + `
 	) is_exists;`
+	// Identity numbers are unique across the whole account, so this check is
+	// not limited to the departments of a manager login.
+	queryCheckIfIdentityNumberExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+	) is_exists;`
+	// Archived departments do not take new employees.
 	queryCheckIfDepartmentExists = `
 	SELECT EXISTS (
 		SELECT id
@@ -34,27 +179,67 @@ const (
 		WHERE 
 			user_id = @userID
 			AND id = NULLIF(@departmentID, 0)::bigint
+			AND deleted_at IS NULL
+			AND archived_at IS NULL` + departmentScopeFilter + `
 	) is_exists;`
+	// The placeholder comments in the list queries below are filled in by
+	// pagination.Sort.Query from a whitelisted sort column.
 	queryGetListEmployee = `
-	SELECT
-		name,
-		identity_number,
-		gender,
-		department_id,
-		employee_image_uri
+	SELECT` + employeeColumns + `
 	FROM employees
-	WHERE
-		user_id = @userID
-		AND (NULLIF(@gender, '') is NULL OR gender = NULLIF(@gender, '')::enum_gender)
-		AND (NULLIF(@departmentID, 0) is NULL OR department_id = NULLIF(@departmentID, 0)::bigint)
-		AND (NULLIF(@identityNumber, '') is NULL OR identity_number ILIKE NULLIF(@identityNumber, '') || '%' )
-		AND (NULLIF(@name, '') is NULL OR name ILIKE '%' || NULLIF(@name, '') || '%' )
+	WHERE` + employeeListFilter + `
+	ORDER BY /*orderBy*/
 	OFFSET @offset
 	LIMIT @limit;`
+	queryGetPageEmployee = `
+	SELECT
+		(/*sortValue*/)::text,
+		employees.id,` + employeeColumns + `
+	FROM employees
+	WHERE` + employeeListFilter + `
+		AND /*keyset*/
+	ORDER BY /*orderBy*/
+	LIMIT @limit;`
+	queryCountEmployee = `
+	SELECT COUNT(*)
+	FROM employees
+	WHERE` + employeeListFilter + `;`
 	queryCreateEmployee = `
-	INSERT INTO employees(name, gender, identity_number, department_id, user_id, employee_image_uri)
-	VALUES (@name, @gender, @identityNumber, @departmentID, @userID, @employeeImageUri)
-	RETURNING name, identity_number, gender, department_id, employee_image_uri;`
+	INSERT INTO employees(name, gender, identity_number, department_id, position_id, user_id, employee_image_uri, job_title, job_title_id, location_id, cost_center_id, custom_fields, status, manager_id)
+	VALUES (
+		@name,
+		@gender,
+		@identityNumber,
+		@departmentID,
+		NULLIF(@positionID, '')::bigint,
+		@userID,
+		@employeeImageUri,
+		COALESCE(
+			(
+				SELECT name
+				FROM job_titles
+				WHERE
+					user_id = @userID
+					AND id = NULLIF(@jobTitleID, '')::bigint
+			),
+			NULLIF(@jobTitle, '')
+		),
+		NULLIF(@jobTitleID, '')::bigint,
+		NULLIF(@locationID, '')::bigint,
+		NULLIF(@costCenterID, '')::bigint,
+		jsonb_strip_nulls(COALESCE(@customFields::jsonb, '{}')),
+		COALESCE(NULLIF(@status, '')::enum_employee_status, 'active'),
+		(
+			SELECT id
+			FROM employees
+			WHERE
+				user_id = @userID
+				AND identity_number = NULLIF(@managerIdentityNumber, '')
+				AND deleted_at IS NULL
+		)
+	)
+	RETURNING
+		employees.id,` + employeeColumns + `;`
 	queryUpdateEmployee = `
 	WITH 
 	payload as (
@@ -63,21 +248,36 @@ const (
 			NULLIF(t.name, '') name,
 			NULLIF(t.gender, '')::enum_gender gender,
 			NULLIF(t.department_id, '')::bigint department_id,
-			NULLIF(t.employee_image_uri, '') employee_image_uri
+			NULLIF(t.position_id, '')::bigint position_id,
+			NULLIF(t.employee_image_uri, '') employee_image_uri,
+			NULLIF(t.job_title, '') job_title,
+			NULLIF(t.job_title_id, '')::bigint job_title_id,
+			NULLIF(t.location_id, '')::bigint location_id,
+			NULLIF(t.cost_center_id, '')::bigint cost_center_id
 		FROM (
 			VALUES (
 				@payloadIdentityNumber,
 				@name,
 				@gender,
 				@departmentId,
-				@employeeImageUri
+				@positionId,
+				@employeeImageUri,
+				@jobTitle,
+				@jobTitleId,
+				@locationId,
+				@costCenterId
 			)
 		) AS t(
 			identity_number,
 			name,
 			gender,
 			department_id,
-			employee_image_uri
+			position_id,
+			employee_image_uri,
+			job_title,
+			job_title_id,
+			location_id,
+			cost_center_id
 		)
 	)
 	UPDATE employees
@@ -86,24 +286,330 @@ const (
 		name = COALESCE(payload.name, employees.name),
 		gender = COALESCE(payload.gender, employees.gender),
 		department_id = COALESCE(payload.department_id, employees.department_id),
-		employee_image_uri = COALESCE(payload.employee_image_uri, employees.employee_image_uri)
+		-- * Positions belong to a department, so moving out leaves the position
+		position_id = CASE
+			WHEN payload.position_id IS NOT NULL THEN payload.position_id
+			WHEN payload.department_id <> employees.department_id THEN NULL
+			ELSE employees.position_id
+		END,
+		employee_image_uri = COALESCE(payload.employee_image_uri, employees.employee_image_uri),
+		job_title = COALESCE(
+			(
+				SELECT job_titles.name
+				FROM job_titles
+				WHERE
+					job_titles.user_id = @userID
+					AND job_titles.id = payload.job_title_id
+			),
+			payload.job_title,
+			employees.job_title
+		),
+		job_title_id = CASE
+			WHEN payload.job_title_id IS NOT NULL THEN payload.job_title_id
+			WHEN payload.job_title <> employees.job_title THEN NULL
+			ELSE employees.job_title_id
+		END,
+		location_id = COALESCE(payload.location_id, employees.location_id),
+		cost_center_id = COALESCE(payload.cost_center_id, employees.cost_center_id),
+		custom_fields = CASE
+			WHEN @customFields::jsonb IS NULL THEN employees.custom_fields
+			ELSE jsonb_strip_nulls(employees.custom_fields || @customFields::jsonb)
+		END,
+		manager_id = COALESCE(
+			(
+				SELECT manager.id
+				FROM employees manager
+				WHERE
+					manager.user_id = @userID
+					AND manager.identity_number = NULLIF(@managerIdentityNumber, '')
+					AND manager.deleted_at IS NULL
+			),
+			employees.manager_id
+		)
 	FROM payload
 	WHERE
 		employees.user_id = @userID
 		AND employees.identity_number = @identityNumber
-	RETURNING
-		employees.name,
-		employees.identity_number,
-		employees.gender,
+		AND employees.deleted_at IS NULL` + employeeScopeFilter + `
+	RETURNING` + employeeColumns + `;`
+	queryGetEmployee = `
+	SELECT` + employeeColumns + `
+	FROM employees
+	WHERE
+		user_id = @userID
+		AND identity_number = @identityNumber
+		AND deleted_at IS NULL` + employeeScopeFilter + `;`
+	queryLockEmployee = `
+	SELECT
+		employees.id,` + employeeColumns + `
+	FROM employees
+	WHERE
+		user_id = @userID
+		AND identity_number = @identityNumber
+		AND deleted_at IS NULL` + employeeScopeFilter + `
+	FOR UPDATE;`
+	queryNextEmployeeVersion = `
+	SELECT COALESCE(MAX(version), 0) + 1
+	FROM employee_history
+	WHERE employee_id = @employeeID;`
+	queryInsertEmployeeHistory = `
+	INSERT INTO employee_history(employee_id, version, field, old_value, new_value, changed_by, changed_by_employee_id)
+	VALUES (@employeeID, @version, @field, @oldValue, @newValue, @changedBy, @changedByEmployeeID::bigint);`
+	queryGetEmployeeHistory = `
+	SELECT
+		employee_history.version,
+		` + changedByColumn + `,
+		employee_history.changed_at,
+		employee_history.field,
+		employee_history.old_value,
+		employee_history.new_value
+	FROM employee_history
+	JOIN employees ON employees.id = employee_history.employee_id
+	LEFT JOIN users ON users.id = employee_history.changed_by
+	LEFT JOIN employees changed_by_employee ON changed_by_employee.id = employee_history.changed_by_employee_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL` + employeeScopeFilter + `
+	ORDER BY employee_history.version DESC, employee_history.id ASC;`
+	// queryRevertEmployee writes every field as given, an empty value clears
+	// the field instead of leaving it unchanged.
+	queryRevertEmployee = `
+	UPDATE employees
+	SET
+		identity_number = @payloadIdentityNumber,
+		name = @name,
+		gender = NULLIF(@gender, '')::enum_gender,
+		department_id = NULLIF(@departmentId, '')::bigint,
+		position_id = NULLIF(@positionId, '')::bigint,
+		employee_image_uri = NULLIF(@employeeImageUri, ''),
+		job_title = NULLIF(@jobTitle, ''),
+		job_title_id = NULLIF(@jobTitleId, '')::bigint,
+		location_id = NULLIF(@locationId, '')::bigint,
+		cost_center_id = NULLIF(@costCenterId, '')::bigint,
+		custom_fields = CASE
+			WHEN @customFields::jsonb IS NULL THEN employees.custom_fields
+			ELSE jsonb_strip_nulls(employees.custom_fields || @customFields::jsonb)
+		END,
+		manager_id = (
+			SELECT manager.id
+			FROM employees manager
+			WHERE
+				manager.user_id = @userID
+				AND manager.identity_number = NULLIF(@managerIdentityNumber, '')
+				AND manager.deleted_at IS NULL
+		)
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL` + employeeScopeFilter + `
+	RETURNING` + employeeColumns + `;`
+	queryRemoveManager = `
+	UPDATE employees
+	SET manager_id = NULL
+	WHERE
+		user_id = @userID
+		AND identity_number = @identityNumber
+		AND deleted_at IS NULL` + employeeScopeFilter + `
+	RETURNING` + employeeColumns + `;`
+	// Reporting lines of an account are changed one at a time, so that two
+	// concurrent edits cannot each pass the cycle check and close a loop.
+	queryLockReportingLines = `
+	SELECT id
+	FROM users
+	WHERE id = @userID
+	FOR UPDATE;`
+	queryCheckIfSubordinate = `
+	WITH RECURSIVE subordinates AS (
+		SELECT id, ARRAY[id] path
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+		UNION ALL
+		SELECT employees.id, subordinates.path || employees.id
+		FROM employees
+		JOIN subordinates ON employees.manager_id = subordinates.id
+		WHERE
+			employees.deleted_at IS NULL
+			AND NOT employees.id = ANY(subordinates.path)
+	)
+	SELECT EXISTS (
+		SELECT 1
+		FROM subordinates
+		JOIN employees ON employees.id = subordinates.id
+		WHERE employees.identity_number = @subordinateIdentityNumber
+	) is_subordinate;`
+	queryGetReports = `
+	WITH RECURSIVE reports AS (
+		SELECT employees.id, 1 AS level, ARRAY[manager.id, employees.id] path
+		FROM employees
+		JOIN employees manager ON manager.id = employees.manager_id
+		WHERE
+			manager.user_id = @userID
+			AND manager.identity_number = @identityNumber
+			AND manager.deleted_at IS NULL
+			AND employees.deleted_at IS NULL
+		UNION ALL
+		SELECT employees.id, reports.level + 1, reports.path || employees.id
+		FROM employees
+		JOIN reports ON employees.manager_id = reports.id
+		WHERE
+			@recursive::boolean
+			AND employees.deleted_at IS NULL
+			AND NOT employees.id = ANY(reports.path)
+	)
+	SELECT
+		reports.level,` + employeeColumns + `
+	FROM reports
+	JOIN employees ON employees.id = reports.id
+	WHERE TRUE` + employeeScopeFilter + `
+	ORDER BY reports.level, employees.name;`
+	queryGetOrgChart = `
+	WITH RECURSIVE
+	scope AS (
+		SELECT id, manager_id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND deleted_at IS NULL
+			AND (NULLIF(@departmentID, 0) IS NULL OR department_id = NULLIF(@departmentID, 0)::bigint)` + employeeScopeFilter + `
+	),
+	chart AS (
+		SELECT scope.id, NULL::bigint parent_id, ARRAY[scope.id] path
+		FROM scope
+		WHERE NOT EXISTS (
+			SELECT 1 FROM scope manager WHERE manager.id = scope.manager_id
+		)
+		UNION ALL
+		SELECT scope.id, chart.id, chart.path || scope.id
+		FROM scope
+		JOIN chart ON scope.manager_id = chart.id
+		WHERE NOT scope.id = ANY(chart.path)
+	)
+	SELECT
+		chart.id,
+		chart.parent_id,` + employeeColumns + `
+	FROM chart
+	JOIN employees ON employees.id = chart.id
+	ORDER BY array_length(chart.path, 1), employees.name;`
+	// Every candidate branch can be answered from one of the GIN indexes, the
+	// rank and the headlines are only computed for the rows they return. A
+	// query whose words are split between the employee and the department
+	// matches at least one word on either side, so the full text branches
+	// look up any word and check the whole query on the joined vectors.
+	querySearchEmployee = `
+	WITH
+	search AS (
+		SELECT
+			to_tsquery('simple', @tsQuery) query,
+			@query::text term
+	),
+	candidates AS (
+		SELECT employees.id
+		FROM employees
+		JOIN departments ON departments.id = employees.department_id
+		WHERE
+			employees.user_id = @userID
+			AND employees.search_vector @@ to_tsquery('simple', @anyTsQuery)
+			AND (employees.search_vector || departments.search_vector) @@ to_tsquery('simple', @tsQuery)
+		UNION
+		SELECT employees.id
+		FROM departments
+		JOIN employees ON employees.department_id = departments.id
+		WHERE
+			departments.user_id = @userID
+			AND departments.search_vector @@ to_tsquery('simple', @anyTsQuery)
+			AND (employees.search_vector || departments.search_vector) @@ to_tsquery('simple', @tsQuery)
+		UNION
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND @query::text <% name
+		UNION
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND @query::text % identity_number
+		UNION
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND @query::text <% job_title
+		UNION
+		SELECT employees.id
+		FROM departments
+		JOIN employees ON employees.department_id = departments.id
+		WHERE
+			departments.user_id = @userID
+			AND @query::text <% departments.name
+	)
+	SELECT
+		departments.name,
+		ts_rank(employees.search_vector || departments.search_vector, search.query)
+			+ GREATEST(
+				word_similarity(search.term, employees.name),
+				similarity(search.term, employees.identity_number),
+				word_similarity(search.term, COALESCE(employees.job_title, '')),
+				word_similarity(search.term, departments.name)
+			) rank,
+		ts_headline('simple', translate(employees.name, @headlineMarkers, ''), search.query, @headlineOptions),
+		ts_headline('simple', translate(employees.identity_number, @headlineMarkers, ''), search.query, @headlineOptions),
+		ts_headline('simple', translate(COALESCE(employees.job_title, ''), @headlineMarkers, ''), search.query, @headlineOptions),
+		ts_headline('simple', translate(departments.name, @headlineMarkers, ''), search.query, @headlineOptions),` + employeeColumns + `
+	FROM candidates
+	JOIN employees ON employees.id = candidates.id
+	JOIN departments ON departments.id = employees.department_id
+	CROSS JOIN search
+	WHERE
+		employees.deleted_at IS NULL` + employeeScopeFilter + `
+	ORDER BY rank DESC, employees.name
+	OFFSET @offset
+	LIMIT @limit;`
+	queryDeleteEmployee = `
+	UPDATE employees
+	SET deleted_at = NOW()
+	WHERE
+		user_id = @userID
+		AND identity_number = @identityNumber
+		AND deleted_at IS NULL` + employeeScopeFilter + `;`
+	queryGetTrashedEmployeeDepartment = `
+	SELECT
 		employees.department_id,
-		employees.employee_image_uri;`
-	queryDeleteEmployee = "DELETE FROM employees WHERE user_id = @userID AND identity_number = @identityNumber;"
+		departments.deleted_at IS NOT NULL
+	FROM employees
+	JOIN departments ON departments.id = employees.department_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NOT NULL` + employeeScopeFilter + `
+	ORDER BY employees.deleted_at DESC
+	LIMIT 1;`
+	queryRestoreEmployee = `
+	UPDATE employees
+	SET deleted_at = NULL
+	WHERE id = (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NOT NULL` + employeeScopeFilter + `
+		ORDER BY deleted_at DESC
+		LIMIT 1
+	)
+	RETURNING` + employeeColumns + `;`
 )
 
 func (r *EmployeeRepository) CheckIfEmployeeExists(ctx context.Context, userID int, identityNumber string) (bool, error) {
 	var isExist bool
 	args := pgx.NamedArgs{
 		"userID":         userID,
+		"managerID":      managerScope(ctx),
 		"identityNumber": identityNumber,
 	}
 
@@ -115,10 +621,26 @@ func (r *EmployeeRepository) CheckIfEmployeeExists(ctx context.Context, userID i
 	return isExist, nil
 }
 
+func (r *EmployeeRepository) CheckIfIdentityNumberExists(ctx context.Context, userID int, identityNumber string) (bool, error) {
+	var isExist bool
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryCheckIfIdentityNumberExists, args).Scan(&isExist)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check if identity number exists")
+	}
+
+	return isExist, nil
+}
+
 func (r *EmployeeRepository) CheckIfDepartmentExists(ctx context.Context, userID int, departmentID string) (bool, error) {
 	var isExist bool
 	args := pgx.NamedArgs{
 		"userID":       userID,
+		"managerID":    managerScope(ctx),
 		"departmentID": departmentID,
 	}
 
@@ -131,98 +653,570 @@ func (r *EmployeeRepository) CheckIfDepartmentExists(ctx context.Context, userID
 }
 
 func (r *EmployeeRepository) CreateEmployee(ctx context.Context, userID int, payload *dto.CreateEmployeePayload) (*dto.Employee, error) {
-	var employee dto.Employee
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if !payload.AllowOverHeadcount {
+		if err := r.checkSeats(ctx, tx, userID, payload.DepartmentId, payload.PositionId); err != nil {
+			return nil, err
+		}
+	}
 
+	var employeeID int64
 	args := pgx.NamedArgs{
-		"name":             payload.Name,
-		"gender":           payload.Gender,
-		"identityNumber":   payload.IdentityNumber,
-		"departmentID":     payload.DepartmentId,
-		"userID":           userID,
-		"employeeImageUri": payload.EmployeeImageUri,
-	}
-	err := r.pool.QueryRow(ctx, queryCreateEmployee, args).Scan(
-		&employee.Name,
-		&employee.IdentityNumber,
-		&employee.Gender,
-		&employee.DepartmentId,
-		&employee.EmployeeImageUri,
-	)
+		"name":                  payload.Name,
+		"gender":                payload.Gender,
+		"identityNumber":        payload.IdentityNumber,
+		"departmentID":          payload.DepartmentId,
+		"positionID":            payload.PositionId,
+		"userID":                userID,
+		"employeeImageUri":      payload.EmployeeImageUri,
+		"jobTitle":              payload.JobTitle,
+		"jobTitleID":            payload.JobTitleId,
+		"locationID":            payload.LocationId,
+		"costCenterID":          payload.CostCenterId,
+		"managerIdentityNumber": payload.ManagerIdentityNumber,
+		"customFields":          toJSONB(payload.CustomFields),
+		"status":                payload.Status,
+	}
+	employee, err := scanEmployee(tx.QueryRow(ctx, queryCreateEmployee, args), &employeeID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create employee")
 	}
 
-	return &employee, nil
+	// * The initial status is the first entry of the status history
+	args = pgx.NamedArgs{
+		"employeeID":          employeeID,
+		"fromStatus":          nil,
+		"toStatus":            employee.Status,
+		"effectiveDate":       employee.StatusEffectiveDate,
+		"changedBy":           userID,
+		"changedByEmployeeID": managerScope(ctx),
+		"isApplied":           true,
+	}
+	if _, err := tx.Exec(ctx, queryInsertStatusTransition, args); err != nil {
+		return nil, errors.Wrap(err, "failed to record employee status")
+	}
+
+	// * And the department the first entry of the department history
+	args = pgx.NamedArgs{
+		"employeeID":          employeeID,
+		"fromDepartmentID":    "",
+		"toDepartmentID":      employee.DepartmentId,
+		"effectiveDate":       employee.StatusEffectiveDate,
+		"reason":              "",
+		"changedBy":           userID,
+		"changedByEmployeeID": managerScope(ctx),
+		"isApplied":           true,
+	}
+	if _, err := tx.Exec(ctx, queryInsertDepartmentAssignment, args); err != nil {
+		return nil, errors.Wrap(err, "failed to record employee department")
+	}
+
+	err = r.checklistRepo.InstantiateChecklists(ctx, tx, userID, employee.IdentityNumber, checklistDto.ChecklistTypeOnboarding, employee.StatusEffectiveDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit employee create")
+	}
+
+	return employee, nil
 }
 
-func (r *EmployeeRepository) GetListEmployee(ctx context.Context, userID int, payload *dto.GetEmployeeParams) (*[]dto.Employee, error) {
-	var employees []dto.Employee
+func (r *EmployeeRepository) GetEmployee(ctx context.Context, userID int, identityNumber string) (*dto.Employee, error) {
 	args := pgx.NamedArgs{
 		"userID":         userID,
-		"identityNumber": payload.IdentityNumber,
-		"name":           payload.Name,
-		"gender":         payload.Gender,
-		"departmentID":   payload.DepartmentId,
-		"limit":          payload.Limit,
-		"offset":         payload.Offset,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	employee, err := scanEmployee(r.pool.QueryRow(ctx, queryGetEmployee, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get employee")
 	}
 
-	rows, err := r.pool.Query(ctx, queryGetListEmployee, args)
+	return employee, nil
+}
+
+func (r *EmployeeRepository) GetListEmployee(ctx context.Context, userID int, payload *dto.GetEmployeeParams, sort *pagination.Sort) (*[]dto.Employee, error) {
+	var employees []dto.Employee
+	args := employeeListArgs(ctx, userID, payload)
+	args["offset"] = payload.Offset
+
+	query := sort.Query(queryGetListEmployee, "employees.id", nil)
+	rows, err := r.pool.Query(ctx, query, args)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get list employee")
 	}
 
+	defer rows.Close()
+
 	for rows.Next() {
-		employee := dto.Employee{}
-		imgUri := new(pgtype.Text)
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
 
-		err := rows.Scan(
-			&employee.Name,
-			&employee.IdentityNumber,
-			&employee.Gender,
-			&employee.DepartmentId,
-			imgUri,
+		employees = append(employees, *employee)
+	}
+
+	return &employees, nil
+}
+
+// GetPageEmployee reads one keyset page of the employee list starting after
+// the cursor, or the first page when cursor is nil.
+func (r *EmployeeRepository) GetPageEmployee(ctx context.Context, userID int, payload *dto.GetEmployeeParams, sort *pagination.Sort, cursor *pagination.Cursor) (*pagination.Page[dto.Employee], error) {
+	var rows []pagination.Row[dto.Employee]
+	args := employeeListArgs(ctx, userID, payload)
+	// * Fetch one extra row to know whether there is another page
+	args["limit"] = payload.Limit + 1
+
+	if cursor != nil {
+		args["cursorValue"] = cursor.Value
+		args["cursorID"] = cursor.ID
+	}
+
+	query := sort.Query(queryGetPageEmployee, "employees.id", cursor)
+	result, err := r.pool.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list employee")
+	}
+	defer result.Close()
+
+	for result.Next() {
+		var row pagination.Row[dto.Employee]
+		employee, err := scanEmployee(result, &row.SortValue, &row.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		row.Item = *employee
+		rows = append(rows, row)
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get list employee")
+	}
+
+	return pagination.NewPage(rows, payload.Limit, sort, cursor), nil
+}
+
+func (r *EmployeeRepository) CountEmployee(ctx context.Context, userID int, payload *dto.GetEmployeeParams) (int, error) {
+	var total int
+
+	err := r.pool.QueryRow(ctx, queryCountEmployee, employeeListArgs(ctx, userID, payload)).Scan(&total)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count employee")
+	}
+
+	return total, nil
+}
+
+func employeeListArgs(ctx context.Context, userID int, payload *dto.GetEmployeeParams) pgx.NamedArgs {
+	statuses := payload.Statuses
+	if statuses == nil {
+		statuses = make([]string, 0)
+	}
+
+	return pgx.NamedArgs{
+		"userID":                userID,
+		"managerID":             managerScope(ctx),
+		"identityNumber":        payload.IdentityNumber,
+		"name":                  payload.Name,
+		"gender":                payload.Gender,
+		"departmentID":          payload.DepartmentId,
+		"includeSubDepartments": payload.IncludeSubDepartments,
+		"locationID":            payload.LocationId,
+		"costCenterID":          payload.CostCenterId,
+		"customFieldFilter":     toJSONB(payload.CustomFieldFilter),
+		"statuses":              statuses,
+		"includeTerminated":     payload.IncludeTerminated,
+		"limit":                 payload.Limit,
+	}
+}
+
+// toJSONB encodes a map for a ::jsonb parameter, keeping nil as SQL NULL.
+func toJSONB(values map[string]any) *string {
+	if values == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil
+	}
+
+	encoded := string(raw)
+	return &encoded
+}
+
+// SearchEmployee combines prefix full text matching with trigram similarity
+// so partial words and typos still find the employee. Matches are ranked
+// best first and the matched terms are wrapped in <mark> tags.
+func (r *EmployeeRepository) SearchEmployee(ctx context.Context, userID int, payload *dto.SearchEmployeeParams) ([]dto.EmployeeSearchResult, error) {
+	results := make([]dto.EmployeeSearchResult, 0)
+	args := pgx.NamedArgs{
+		"userID":          userID,
+		"managerID":       managerScope(ctx),
+		"query":           payload.Query,
+		"tsQuery":         toPrefixTsQuery(payload.Query, "&"),
+		"anyTsQuery":      toPrefixTsQuery(payload.Query, "|"),
+		"headlineOptions": searchHeadlineOptions,
+		"headlineMarkers": searchMarkerStart + searchMarkerStop,
+		"limit":           payload.Limit,
+		"offset":          payload.Offset,
+	}
+
+	rows, err := r.pool.Query(ctx, querySearchEmployee, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search employee")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result dto.EmployeeSearchResult
+		var name, identityNumber, jobTitle, departmentName string
+
+		employee, err := scanEmployee(rows,
+			&result.DepartmentName,
+			&result.Rank,
+			&name,
+			&identityNumber,
+			&jobTitle,
+			&departmentName,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to parse sql response")
 		}
 
-		employee.EmployeeImageUri = imgUri.String
-		employees = append(employees, employee)
+		result.Employee = *employee
+		result.Highlights = make(map[string]string)
+		for field, highlight := range map[string]string{
+			FieldName:           name,
+			FieldIdentityNumber: identityNumber,
+			FieldJobTitle:       jobTitle,
+			"departmentName":    departmentName,
+		} {
+			if highlight, ok := toHighlight(highlight); ok {
+				result.Highlights[field] = highlight
+			}
+		}
+
+		results = append(results, result)
 	}
 
-	return &employees, nil
+	return results, nil
 }
 
 func (r *EmployeeRepository) UpdateEmployee(ctx context.Context, userID int, identityNumber string, payload *dto.PatchEmployeePayload) (*dto.Employee, error) {
-	var employee dto.Employee
-	args := pgx.NamedArgs{
+	employee, err := r.updateWithHistory(ctx, userID, identityNumber, queryUpdateEmployee, updateArgs(ctx, userID, identityNumber, payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update employee")
+	}
+
+	return employee, nil
+}
+
+// RevertEmployee writes the payload as the whole state of the employee, so
+// fields left empty are cleared. Only the custom fields in the payload are
+// touched, like in UpdateEmployee.
+func (r *EmployeeRepository) RevertEmployee(ctx context.Context, userID int, identityNumber string, payload *dto.PatchEmployeePayload) (*dto.Employee, error) {
+	employee, err := r.updateWithHistory(ctx, userID, identityNumber, queryRevertEmployee, updateArgs(ctx, userID, identityNumber, payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to revert employee")
+	}
+
+	return employee, nil
+}
+
+func updateArgs(ctx context.Context, userID int, identityNumber string, payload *dto.PatchEmployeePayload) pgx.NamedArgs {
+	return pgx.NamedArgs{
 		"userID":                userID,
+		"managerID":             managerScope(ctx),
 		"identityNumber":        identityNumber,
 		"payloadIdentityNumber": payload.IdentityNumber,
 		"name":                  payload.Name,
 		"gender":                payload.Gender,
 		"departmentId":          payload.DepartmentId,
+		"positionId":            payload.PositionId,
 		"employeeImageUri":      payload.EmployeeImageUri,
+		"jobTitle":              payload.JobTitle,
+		"jobTitleId":            payload.JobTitleId,
+		"locationId":            payload.LocationId,
+		"costCenterId":          payload.CostCenterId,
+		"managerIdentityNumber": payload.ManagerIdentityNumber,
+		"customFields":          toJSONB(payload.CustomFields),
 	}
+}
 
-	err := r.pool.QueryRow(ctx, queryUpdateEmployee, args).Scan(
-		&employee.Name,
-		&employee.IdentityNumber,
-		&employee.Gender,
-		&employee.DepartmentId,
-		&employee.EmployeeImageUri,
-	)
+func (r *EmployeeRepository) RemoveManager(ctx context.Context, userID int, identityNumber string) (*dto.Employee, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	employee, err := r.updateWithHistory(ctx, userID, identityNumber, queryRemoveManager, args)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to update employee")
+		return nil, errors.Wrap(err, "failed to remove manager")
 	}
 
-	return &employee, nil
+	return employee, nil
+}
+
+// updateWithHistory locks the employee, runs the given UPDATE query (which
+// must return employeeColumns) and records every changed field as a new
+// version in employee_history, all in one transaction.
+func (r *EmployeeRepository) updateWithHistory(ctx context.Context, userID int, identityNumber string, query string, args pgx.NamedArgs) (*dto.Employee, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var employeeID int64
+	lockArgs := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	before, err := scanEmployee(tx.QueryRow(ctx, queryLockEmployee, lockArgs), &employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if managerIdentityNumber, _ := args["managerIdentityNumber"].(string); managerIdentityNumber != "" && managerIdentityNumber != before.ManagerIdentityNumber {
+		if err := r.checkReportingLine(ctx, tx, userID, before.IdentityNumber, managerIdentityNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	employee, err := scanEmployee(tx.QueryRow(ctx, query, args))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := diffEmployee(before, employee)
+	if len(changes) > 0 {
+		if err := r.insertHistory(ctx, tx, employeeID, userID, changes); err != nil {
+			return nil, err
+		}
+	}
+
+	// * A department changed in place is kept as a transfer effective today
+	if employee.DepartmentId != before.DepartmentId {
+		args := pgx.NamedArgs{
+			"employeeID":          employeeID,
+			"fromDepartmentID":    before.DepartmentId,
+			"toDepartmentID":      employee.DepartmentId,
+			"effectiveDate":       time.Now().Format(time.DateOnly),
+			"reason":              "",
+			"changedBy":           userID,
+			"changedByEmployeeID": managerScope(ctx),
+			"isApplied":           true,
+		}
+		if _, err := tx.Exec(ctx, queryInsertDepartmentAssignment, args); err != nil {
+			return nil, errors.Wrap(err, "failed to record department transfer")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit employee update")
+	}
+
+	return employee, nil
+}
+
+// checkReportingLine locks the reporting lines of the account and makes sure
+// the new manager is not a subordinate of the employee.
+func (r *EmployeeRepository) checkReportingLine(ctx context.Context, tx pgx.Tx, userID int, identityNumber string, managerIdentityNumber string) error {
+	if _, err := tx.Exec(ctx, queryLockReportingLines, pgx.NamedArgs{"userID": userID}); err != nil {
+		return errors.Wrap(err, "failed to lock reporting lines")
+	}
+
+	var isSubordinate bool
+	args := pgx.NamedArgs{
+		"userID":                    userID,
+		"identityNumber":            identityNumber,
+		"subordinateIdentityNumber": managerIdentityNumber,
+	}
+
+	if err := tx.QueryRow(ctx, queryCheckIfSubordinate, args).Scan(&isSubordinate); err != nil {
+		return errors.Wrap(err, "failed to check reporting line")
+	}
+
+	if isSubordinate {
+		return ErrManagerCycle
+	}
+
+	return nil
+}
+
+func (r *EmployeeRepository) CheckIfSubordinate(ctx context.Context, userID int, identityNumber string, subordinateIdentityNumber string) (bool, error) {
+	var isSubordinate bool
+	args := pgx.NamedArgs{
+		"userID":                    userID,
+		"identityNumber":            identityNumber,
+		"subordinateIdentityNumber": subordinateIdentityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryCheckIfSubordinate, args).Scan(&isSubordinate)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check reporting line")
+	}
+
+	return isSubordinate, nil
+}
+
+func (r *EmployeeRepository) GetReports(ctx context.Context, userID int, identityNumber string, recursive bool) ([]dto.Report, error) {
+	reports := make([]dto.Report, 0)
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+		"recursive":      recursive,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetReports, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get reports")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var level int
+		employee, err := scanEmployee(rows, &level)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		reports = append(reports, dto.Report{Employee: *employee, Level: level})
+	}
+
+	return reports, nil
+}
+
+// GetOrgChart returns the reporting tree of the organization, or of a single
+// department when departmentID is set. Employees whose manager is outside
+// the requested scope become roots of the tree.
+func (r *EmployeeRepository) GetOrgChart(ctx context.Context, userID int, departmentID int) ([]*dto.OrgChartNode, error) {
+	roots := make([]*dto.OrgChartNode, 0)
+	nodes := make(map[int64]*dto.OrgChartNode)
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"managerID":    managerScope(ctx),
+		"departmentID": departmentID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetOrgChart, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get org chart")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		parentID := new(pgtype.Int8)
+		employee, err := scanEmployee(rows, &id, parentID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		node := &dto.OrgChartNode{Employee: *employee, Reports: make([]*dto.OrgChartNode, 0)}
+		nodes[id] = node
+
+		// * Rows are ordered by depth, so a parent is always seen before its reports
+		if parent, ok := nodes[parentID.Int64]; parentID.Valid && ok {
+			parent.Reports = append(parent.Reports, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	return roots, nil
+}
+
+func (r *EmployeeRepository) insertHistory(ctx context.Context, tx pgx.Tx, employeeID int64, changedBy int, changes []dto.EmployeeChange) error {
+	var version int
+	err := tx.QueryRow(ctx, queryNextEmployeeVersion, pgx.NamedArgs{"employeeID": employeeID}).Scan(&version)
+	if err != nil {
+		return errors.Wrap(err, "failed to get next employee version")
+	}
+
+	batch := &pgx.Batch{}
+	for _, change := range changes {
+		batch.Queue(queryInsertEmployeeHistory, pgx.NamedArgs{
+			"employeeID":          employeeID,
+			"version":             version,
+			"field":               change.Field,
+			"oldValue":            change.OldValue,
+			"newValue":            change.NewValue,
+			"changedBy":           changedBy,
+			"changedByEmployeeID": managerScope(ctx),
+		})
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return errors.Wrap(err, "failed to record employee history")
+	}
+
+	return nil
+}
+
+func (r *EmployeeRepository) GetEmployeeHistory(ctx context.Context, userID int, identityNumber string) ([]dto.EmployeeVersion, error) {
+	versions := make([]dto.EmployeeVersion, 0)
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetEmployeeHistory, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get employee history")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version dto.EmployeeVersion
+		var change dto.EmployeeChange
+		changedBy := new(pgtype.Text)
+
+		err := rows.Scan(
+			&version.Version,
+			changedBy,
+			&version.ChangedAt,
+			&change.Field,
+			&change.OldValue,
+			&change.NewValue,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		last := len(versions) - 1
+		if last < 0 || versions[last].Version != version.Version {
+			version.ChangedBy = changedBy.String
+			version.Changes = make([]dto.EmployeeChange, 0)
+			versions = append(versions, version)
+			last++
+		}
+		versions[last].Changes = append(versions[last].Changes, change)
+	}
+
+	return versions, nil
 }
 
 func (r *EmployeeRepository) DeleteEmployee(ctx context.Context, userID int, identityNumber string) error {
 	args := pgx.NamedArgs{
 		"userID":         userID,
+		"managerID":      managerScope(ctx),
 		"identityNumber": identityNumber,
 	}
 
@@ -233,3 +1227,38 @@ func (r *EmployeeRepository) DeleteEmployee(ctx context.Context, userID int, ide
 
 	return nil
 }
+
+// GetTrashedEmployeeDepartment returns the department of the most recently
+// trashed employee with the given identity number and whether that
+// department is itself in the trash.
+func (r *EmployeeRepository) GetTrashedEmployeeDepartment(ctx context.Context, userID int, identityNumber string) (string, bool, error) {
+	var departmentID string
+	var isDepartmentTrashed bool
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryGetTrashedEmployeeDepartment, args).Scan(&departmentID, &isDepartmentTrashed)
+	if err != nil {
+		return "", false, errors.Wrap(err, "failed to get trashed employee")
+	}
+
+	return departmentID, isDepartmentTrashed, nil
+}
+
+func (r *EmployeeRepository) RestoreEmployee(ctx context.Context, userID int, identityNumber string) (*dto.Employee, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	employee, err := scanEmployee(r.pool.QueryRow(ctx, queryRestoreEmployee, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to restore employee")
+	}
+
+	return employee, nil
+}
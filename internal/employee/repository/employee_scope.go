@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/pkg/jwt"
+)
+
+const (
+	// managedDepartments lists the departments the @managerID employee heads,
+	// along with every department nested under them. Heads that were deleted
+	// or terminated no longer manage anything.
+	managedDepartments = `
+				WITH RECURSIVE managed AS (
+					SELECT departments.id, ARRAY[departments.id] path
+					FROM department_heads
+					JOIN departments ON departments.id = department_heads.department_id
+					JOIN employees head ON head.id = department_heads.employee_id
+					WHERE
+						department_heads.employee_id = @managerID::bigint
+						AND departments.deleted_at IS NULL
+						AND head.deleted_at IS NULL
+						AND head.status <> 'terminated'
+					UNION ALL
+					SELECT departments.id, managed.path || departments.id
+					FROM departments
+					JOIN managed ON departments.parent_id = managed.id
+					WHERE
+						departments.deleted_at IS NULL
+						AND NOT departments.id = ANY(managed.path)
+				)
+				SELECT id FROM managed`
+	// employeeScopeFilter and departmentScopeFilter limit a query to what the
+	// caller may reach: everything for the owner of the account, only the
+	// managed departments for a manager login.
+	employeeScopeFilter = `
+		AND (
+			@managerID::bigint IS NULL
+			OR employees.department_id IN (` + managedDepartments + `
+			)
+		)`
+	// changedByColumn names who recorded a history entry: the department head
+	// for changes made from a manager login, the email of the account owner
+	// otherwise. It needs the users and changed_by_employee joins.
+	changedByColumn       = `COALESCE(changed_by_employee.name || ' (' || changed_by_employee.identity_number || ')', users.email)`
+	departmentScopeFilter = `
+		AND (
+			@managerID::bigint IS NULL
+			OR departments.id IN (` + managedDepartments + `
+			)
+		)`
+)
+
+// managerScope returns the employee id of a manager login for the
+// @managerID parameter of the scope filters, or nil for the owner of the
+// account and for background jobs.
+func managerScope(ctx context.Context) *int64 {
+	claim, ok := jwt.FromContext(ctx)
+	if !ok || !claim.IsManager() {
+		return nil
+	}
+
+	return &claim.EmployeeId
+}
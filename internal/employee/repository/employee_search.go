@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	searchHighlightStart = "<mark>"
+	searchHighlightStop  = "</mark>"
+	// ts_headline marks matches with control characters, stripped from the
+	// source text first, so the text can be escaped before they become
+	// <mark> tags.
+	searchMarkerStart     = "\x02"
+	searchMarkerStop      = "\x03"
+	searchHeadlineOptions = `StartSel="` + searchMarkerStart + `", StopSel="` + searchMarkerStop + `", HighlightAll=true`
+)
+
+// toHighlight HTML-escapes a headline of user-controlled text and turns its
+// markers into <mark> tags. It reports whether anything was highlighted.
+func toHighlight(headline string) (string, bool) {
+	if !strings.Contains(headline, searchMarkerStart) {
+		return "", false
+	}
+
+	return strings.NewReplacer(
+		searchMarkerStart, searchHighlightStart,
+		searchMarkerStop, searchHighlightStop,
+	).Replace(html.EscapeString(headline)), true
+}
+
+// toPrefixTsQuery turns free text into a tsquery that matches the words as
+// prefixes joined by operator, e.g. "jo smi" becomes "jo:* & smi:*" for "&".
+// Anything that is not a letter or digit is treated as a separator so user
+// input can never break the tsquery syntax.
+func toPrefixTsQuery(query, operator string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, strings.ToLower(word)+":*")
+	}
+
+	return strings.Join(terms, " "+operator+" ")
+}
@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	checklistDto "ps-gogo-manajer/internal/checklist/dto"
+	"ps-gogo-manajer/internal/employee/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// ErrStatusChanged is returned by ChangeStatus when the employee no longer
+// has the status the transition was validated against.
+var ErrStatusChanged = errors.New("employee status changed")
+
+const (
+	queryInsertStatusTransition = `
+	INSERT INTO employee_status_transitions(
+		employee_id,
+		from_status,
+		to_status,
+		effective_date,
+		reason,
+		termination_type,
+		last_working_date,
+		eligible_for_rehire,
+		changed_by,
+		changed_by_employee_id,
+		applied_at
+	)
+	VALUES (
+		@employeeID,
+		@fromStatus::enum_employee_status,
+		@toStatus::enum_employee_status,
+		@effectiveDate::date,
+		NULLIF(@reason, ''),
+		NULLIF(@terminationType, '')::enum_termination_type,
+		NULLIF(@lastWorkingDate, '')::date,
+		@eligibleForRehire::boolean,
+		@changedBy,
+		@changedByEmployeeID::bigint,
+		CASE WHEN @isApplied::boolean THEN NOW() END
+	);`
+	queryApplyEmployeeStatus = `
+	UPDATE employees
+	SET
+		status = @toStatus::enum_employee_status,
+		status_effective_date = @effectiveDate::date
+	WHERE id = @employeeID
+	RETURNING` + employeeColumns + `;`
+	queryCheckIfPendingStatusTransitionExists = `
+	SELECT EXISTS (
+		SELECT employee_status_transitions.id
+		FROM employee_status_transitions
+		JOIN employees ON employees.id = employee_status_transitions.employee_id
+		WHERE
+			employees.user_id = @userID
+			AND employees.identity_number = @identityNumber
+			AND employees.deleted_at IS NULL` + employeeScopeFilter + `
+			AND employee_status_transitions.applied_at IS NULL
+			AND employee_status_transitions.cancelled_at IS NULL
+	) is_exists;`
+	queryGetStatusHistory = `
+	SELECT
+		employee_status_transitions.from_status,
+		employee_status_transitions.to_status,
+		employee_status_transitions.effective_date::text,
+		employee_status_transitions.reason,
+		employee_status_transitions.termination_type,
+		employee_status_transitions.last_working_date::text,
+		employee_status_transitions.eligible_for_rehire,
+		CASE
+			WHEN employee_status_transitions.applied_at IS NOT NULL THEN 'applied'
+			WHEN employee_status_transitions.cancelled_at IS NOT NULL THEN 'cancelled'
+			ELSE 'pending'
+		END,
+		` + changedByColumn + `,
+		employee_status_transitions.created_at
+	FROM employee_status_transitions
+	JOIN employees ON employees.id = employee_status_transitions.employee_id
+	LEFT JOIN users ON users.id = employee_status_transitions.changed_by
+	LEFT JOIN employees changed_by_employee ON changed_by_employee.id = employee_status_transitions.changed_by_employee_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL` + employeeScopeFilter + `
+	ORDER BY employee_status_transitions.created_at DESC, employee_status_transitions.id DESC;`
+	// A pending transition only applies when the employee still has the
+	// status it was scheduled from, otherwise it is cancelled. The applied
+	// transitions are returned to start what follows them.
+	queryApplyDueStatusTransitions = `
+	WITH due AS (
+		SELECT DISTINCT ON (employee_id)
+			id,
+			employee_id,
+			from_status,
+			to_status,
+			effective_date
+		FROM employee_status_transitions
+		WHERE
+			applied_at IS NULL
+			AND cancelled_at IS NULL
+			AND effective_date <= CURRENT_DATE
+		ORDER BY employee_id, effective_date, id
+	),
+	cancelled AS (
+		UPDATE employee_status_transitions
+		SET cancelled_at = NOW()
+		FROM due
+		JOIN employees ON employees.id = due.employee_id
+		WHERE
+			employee_status_transitions.id = due.id
+			AND employees.status IS DISTINCT FROM due.from_status
+		RETURNING employee_status_transitions.id
+	),
+	applied AS (
+		UPDATE employees
+		SET
+			status = due.to_status,
+			status_effective_date = due.effective_date
+		FROM due
+		WHERE
+			employees.id = due.employee_id
+			AND employees.status = due.from_status
+		RETURNING
+			due.id,
+			employees.user_id,
+			employees.identity_number,
+			due.to_status,
+			due.effective_date
+	)
+	UPDATE employee_status_transitions
+	SET applied_at = NOW()
+	FROM applied
+	WHERE employee_status_transitions.id = applied.id
+	RETURNING
+		applied.user_id,
+		applied.identity_number,
+		applied.to_status,
+		applied.effective_date::text;`
+)
+
+// ChangeStatus records a transition away from the given status. When
+// isApplied is set the employee moves to the new status right away,
+// otherwise the transition stays pending until ApplyDueTransitions picks it
+// up on its effective date.
+func (r *EmployeeRepository) ChangeStatus(ctx context.Context, userID int, identityNumber string, fromStatus dto.Status, payload *dto.ChangeStatusPayload, isApplied bool) (*dto.Employee, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var employeeID int64
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	employee, err := scanEmployee(tx.QueryRow(ctx, queryLockEmployee, args), &employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if employee.Status != fromStatus {
+		return nil, ErrStatusChanged
+	}
+
+	var eligibleForRehire *bool
+	if payload.Status == dto.StatusTerminated {
+		eligibleForRehire = &payload.EligibleForRehire
+	}
+
+	args = pgx.NamedArgs{
+		"employeeID":          employeeID,
+		"fromStatus":          fromStatus,
+		"toStatus":            payload.Status,
+		"effectiveDate":       payload.EffectiveDate,
+		"reason":              payload.Reason,
+		"terminationType":     payload.TerminationType,
+		"lastWorkingDate":     payload.LastWorkingDate,
+		"eligibleForRehire":   eligibleForRehire,
+		"changedBy":           userID,
+		"changedByEmployeeID": managerScope(ctx),
+		"isApplied":           isApplied,
+	}
+	if _, err := tx.Exec(ctx, queryInsertStatusTransition, args); err != nil {
+		return nil, errors.Wrap(err, "failed to record status transition")
+	}
+
+	if isApplied {
+		employee, err = scanEmployee(tx.QueryRow(ctx, queryApplyEmployeeStatus, args))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to change employee status")
+		}
+
+		if err := r.startOffboarding(ctx, tx, userID, identityNumber, payload.Status, payload.EffectiveDate); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit status change")
+	}
+
+	return employee, nil
+}
+
+func (r *EmployeeRepository) CheckIfPendingStatusTransitionExists(ctx context.Context, userID int, identityNumber string) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryCheckIfPendingStatusTransitionExists, args).Scan(&isExists)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check pending status transition")
+	}
+
+	return isExists, nil
+}
+
+func (r *EmployeeRepository) GetStatusHistory(ctx context.Context, userID int, identityNumber string) ([]dto.StatusTransition, error) {
+	transitions := make([]dto.StatusTransition, 0)
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetStatusHistory, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get status history")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transition dto.StatusTransition
+		reason := new(pgtype.Text)
+		terminationType := new(pgtype.Text)
+		lastWorkingDate := new(pgtype.Text)
+		eligibleForRehire := new(pgtype.Bool)
+		changedBy := new(pgtype.Text)
+
+		err := rows.Scan(
+			&transition.FromStatus,
+			&transition.ToStatus,
+			&transition.EffectiveDate,
+			reason,
+			terminationType,
+			lastWorkingDate,
+			eligibleForRehire,
+			&transition.State,
+			changedBy,
+			&transition.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		transition.Reason = reason.String
+		transition.ChangedBy = changedBy.String
+		if terminationType.Valid {
+			transition.Termination = &dto.TerminationDetails{
+				Type:              terminationType.String,
+				LastWorkingDate:   lastWorkingDate.String,
+				EligibleForRehire: eligibleForRehire.Bool,
+			}
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	return transitions, nil
+}
+
+// startOffboarding gives a terminated employee their offboarding checklists
+// once the termination is applied, so a pending termination that is
+// cancelled leaves none behind.
+func (r *EmployeeRepository) startOffboarding(ctx context.Context, tx pgx.Tx, userID int, identityNumber string, status dto.Status, effectiveDate string) error {
+	if status != dto.StatusTerminated {
+		return nil
+	}
+
+	return r.checklistRepo.InstantiateChecklists(ctx, tx, userID, identityNumber, checklistDto.ChecklistTypeOffboarding, effectiveDate)
+}
+
+// ApplyDueTransitions applies every pending status transition whose
+// effective date has come and returns how many transitions were applied.
+func (r *EmployeeRepository) ApplyDueTransitions(ctx context.Context) (int64, error) {
+	type appliedTransition struct {
+		userID         int
+		identityNumber string
+		toStatus       dto.Status
+		effectiveDate  string
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, queryApplyDueStatusTransitions)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to apply due status transitions")
+	}
+
+	transitions := make([]appliedTransition, 0)
+	for rows.Next() {
+		var transition appliedTransition
+		err := rows.Scan(&transition.userID, &transition.identityNumber, &transition.toStatus, &transition.effectiveDate)
+		if err != nil {
+			rows.Close()
+			return 0, errors.Wrap(err, "failed to parse sql response")
+		}
+		transitions = append(transitions, transition)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(err, "failed to apply due status transitions")
+	}
+
+	for _, transition := range transitions {
+		err := r.startOffboarding(ctx, tx, transition.userID, transition.identityNumber, transition.toStatus, transition.effectiveDate)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, errors.Wrap(err, "failed to commit status transitions")
+	}
+
+	return int64(len(transitions)), nil
+}
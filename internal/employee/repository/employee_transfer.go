@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/employee/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// ErrDepartmentChanged is returned by TransferEmployee when the employee is
+// no longer in the department the transfer was validated against.
+var ErrDepartmentChanged = errors.New("employee department changed")
+
+const (
+	queryInsertDepartmentAssignment = `
+	INSERT INTO employee_department_assignments(
+		employee_id,
+		from_department_id,
+		to_department_id,
+		effective_date,
+		reason,
+		changed_by,
+		changed_by_employee_id,
+		applied_at
+	)
+	VALUES (
+		@employeeID,
+		NULLIF(@fromDepartmentID, '')::bigint,
+		@toDepartmentID::bigint,
+		@effectiveDate::date,
+		NULLIF(@reason, ''),
+		@changedBy,
+		@changedByEmployeeID::bigint,
+		CASE WHEN @isApplied::boolean THEN NOW() END
+	);`
+	// A transfer leaves the position behind, as positions belong to the
+	// department.
+	queryApplyEmployeeDepartment = `
+	UPDATE employees
+	SET
+		department_id = @toDepartmentID::bigint,
+		position_id = NULL
+	WHERE id = @employeeID
+	RETURNING` + employeeColumns + `;`
+	queryCheckIfPendingTransferExists = `
+	SELECT EXISTS (
+		SELECT employee_department_assignments.id
+		FROM employee_department_assignments
+		JOIN employees ON employees.id = employee_department_assignments.employee_id
+		WHERE
+			employees.user_id = @userID
+			AND employees.identity_number = @identityNumber
+			AND employees.deleted_at IS NULL` + employeeScopeFilter + `
+			AND employee_department_assignments.applied_at IS NULL
+			AND employee_department_assignments.cancelled_at IS NULL
+	) is_exists;`
+	queryGetDepartmentHistory = `
+	SELECT
+		employee_department_assignments.from_department_id::text,
+		from_department.name,
+		employee_department_assignments.to_department_id::text,
+		to_department.name,
+		employee_department_assignments.effective_date::text,
+		employee_department_assignments.reason,
+		CASE
+			WHEN employee_department_assignments.applied_at IS NOT NULL THEN 'applied'
+			WHEN employee_department_assignments.cancelled_at IS NOT NULL THEN 'cancelled'
+			ELSE 'pending'
+		END,
+		` + changedByColumn + `,
+		employee_department_assignments.created_at
+	FROM employee_department_assignments
+	JOIN employees ON employees.id = employee_department_assignments.employee_id
+	LEFT JOIN departments from_department ON from_department.id = employee_department_assignments.from_department_id
+	LEFT JOIN departments to_department ON to_department.id = employee_department_assignments.to_department_id
+	LEFT JOIN users ON users.id = employee_department_assignments.changed_by
+	LEFT JOIN employees changed_by_employee ON changed_by_employee.id = employee_department_assignments.changed_by_employee_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL` + employeeScopeFilter + `
+	ORDER BY employee_department_assignments.created_at DESC, employee_department_assignments.id DESC;`
+	// Pending transfers count as well, so a date after a scheduled transfer
+	// answers with the department the employee is moving to.
+	queryGetDepartmentAsOf = `
+	SELECT
+		employee_department_assignments.to_department_id::text,
+		departments.name,
+		employee_department_assignments.effective_date::text
+	FROM employee_department_assignments
+	JOIN employees ON employees.id = employee_department_assignments.employee_id
+	LEFT JOIN departments ON departments.id = employee_department_assignments.to_department_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL` + employeeScopeFilter + `
+		AND employee_department_assignments.cancelled_at IS NULL
+		AND employee_department_assignments.effective_date <= @asOf::date
+	ORDER BY employee_department_assignments.effective_date DESC, employee_department_assignments.id DESC
+	LIMIT 1;`
+	// A pending transfer only applies when the employee is still in the
+	// department it was scheduled from and the department it goes to is
+	// neither in the trash nor archived, otherwise it is cancelled.
+	queryApplyDueTransfers = `
+	WITH due AS (
+		SELECT DISTINCT ON (employee_department_assignments.employee_id)
+			employee_department_assignments.id,
+			employee_department_assignments.employee_id,
+			employee_department_assignments.from_department_id,
+			employee_department_assignments.to_department_id,
+			departments.id IS NOT NULL
+				AND departments.deleted_at IS NULL
+				AND departments.archived_at IS NULL is_target_active
+		FROM employee_department_assignments
+		LEFT JOIN departments ON departments.id = employee_department_assignments.to_department_id
+		WHERE
+			employee_department_assignments.applied_at IS NULL
+			AND employee_department_assignments.cancelled_at IS NULL
+			AND employee_department_assignments.effective_date <= CURRENT_DATE
+		ORDER BY
+			employee_department_assignments.employee_id,
+			employee_department_assignments.effective_date,
+			employee_department_assignments.id
+	),
+	cancelled AS (
+		UPDATE employee_department_assignments
+		SET cancelled_at = NOW()
+		FROM due
+		JOIN employees ON employees.id = due.employee_id
+		WHERE
+			employee_department_assignments.id = due.id
+			AND (
+				employees.department_id IS DISTINCT FROM due.from_department_id
+				OR NOT due.is_target_active
+			)
+		RETURNING employee_department_assignments.id
+	),
+	applied AS (
+		UPDATE employees
+		SET
+			department_id = due.to_department_id,
+			position_id = NULL
+		FROM due
+		WHERE
+			employees.id = due.employee_id
+			AND employees.department_id = due.from_department_id
+			AND due.is_target_active
+		RETURNING due.id
+	)
+	UPDATE employee_department_assignments
+	SET applied_at = NOW()
+	FROM applied
+	WHERE employee_department_assignments.id = applied.id;`
+)
+
+// TransferEmployee records a transfer away from the given department. When
+// isApplied is set the employee moves right away and the change is kept in
+// the employee history, otherwise the transfer stays pending until
+// ApplyDueTransfers picks it up on its effective date.
+func (r *EmployeeRepository) TransferEmployee(ctx context.Context, userID int, identityNumber string, fromDepartmentID string, payload *dto.TransferPayload, isApplied bool) (*dto.Employee, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var employeeID int64
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	before, err := scanEmployee(tx.QueryRow(ctx, queryLockEmployee, args), &employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if before.DepartmentId != fromDepartmentID {
+		return nil, ErrDepartmentChanged
+	}
+
+	args = pgx.NamedArgs{
+		"employeeID":          employeeID,
+		"fromDepartmentID":    fromDepartmentID,
+		"toDepartmentID":      payload.DepartmentId,
+		"effectiveDate":       payload.EffectiveDate,
+		"reason":              payload.Reason,
+		"changedBy":           userID,
+		"changedByEmployeeID": managerScope(ctx),
+		"isApplied":           isApplied,
+	}
+	if _, err := tx.Exec(ctx, queryInsertDepartmentAssignment, args); err != nil {
+		return nil, errors.Wrap(err, "failed to record department transfer")
+	}
+
+	employee := before
+	if isApplied {
+		employee, err = scanEmployee(tx.QueryRow(ctx, queryApplyEmployeeDepartment, args))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to transfer employee")
+		}
+
+		if err := r.insertHistory(ctx, tx, employeeID, userID, diffEmployee(before, employee)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit department transfer")
+	}
+
+	return employee, nil
+}
+
+func (r *EmployeeRepository) CheckIfPendingTransferExists(ctx context.Context, userID int, identityNumber string) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryCheckIfPendingTransferExists, args).Scan(&isExists)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check pending department transfer")
+	}
+
+	return isExists, nil
+}
+
+func (r *EmployeeRepository) GetDepartmentHistory(ctx context.Context, userID int, identityNumber string) ([]dto.DepartmentTransfer, error) {
+	transfers := make([]dto.DepartmentTransfer, 0)
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetDepartmentHistory, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get department history")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transfer dto.DepartmentTransfer
+		fromDepartmentName := new(pgtype.Text)
+		toDepartmentName := new(pgtype.Text)
+		reason := new(pgtype.Text)
+		changedBy := new(pgtype.Text)
+
+		err := rows.Scan(
+			&transfer.FromDepartmentId,
+			fromDepartmentName,
+			&transfer.ToDepartmentId,
+			toDepartmentName,
+			&transfer.EffectiveDate,
+			reason,
+			&transfer.State,
+			changedBy,
+			&transfer.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		transfer.FromDepartmentName = fromDepartmentName.String
+		transfer.ToDepartmentName = toDepartmentName.String
+		transfer.Reason = reason.String
+		transfer.ChangedBy = changedBy.String
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, nil
+}
+
+func (r *EmployeeRepository) GetDepartmentAsOf(ctx context.Context, userID int, identityNumber string, asOf string) (*dto.DepartmentAssignment, error) {
+	var assignment dto.DepartmentAssignment
+	departmentName := new(pgtype.Text)
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"managerID":      managerScope(ctx),
+		"identityNumber": identityNumber,
+		"asOf":           asOf,
+	}
+
+	err := r.pool.QueryRow(ctx, queryGetDepartmentAsOf, args).Scan(
+		&assignment.DepartmentId,
+		departmentName,
+		&assignment.EffectiveDate,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get department as of date")
+	}
+
+	assignment.DepartmentName = departmentName.String
+	return &assignment, nil
+}
+
+// ApplyDueTransfers applies every pending department transfer whose
+// effective date has come and returns how many transfers were applied.
+func (r *EmployeeRepository) ApplyDueTransfers(ctx context.Context) (int64, error) {
+	tag, err := r.pool.Exec(ctx, queryApplyDueTransfers)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to apply due department transfers")
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+
+	"github.com/pkg/errors"
+)
+
+// validateJobTitle makes sure the job title is in the catalog and that the
+// base salary of the employee, if any, falls within its range.
+func (u *EmployeeUsecase) validateJobTitle(ctx context.Context, userID int, identityNumber string, jobTitleID string) error {
+	salaryMin, salaryMax, baseSalary, err := u.employeeRepo.GetJobTitleSalaryFit(ctx, userID, identityNumber, jobTitleID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return errors.Wrap(customErrors.ErrNotFound, "job title not found")
+		}
+		return err
+	}
+
+	if baseSalary == nil {
+		return nil
+	}
+
+	if (salaryMin != nil && *baseSalary < *salaryMin) || (salaryMax != nil && *baseSalary > *salaryMax) {
+		return errors.Wrap(customErrors.ErrBadRequest, "base salary is outside the salary range of the job title")
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+
+	"github.com/pkg/errors"
+)
+
+// validateLocationAndCostCenter makes sure the location and the cost center
+// the employee is assigned to belong to the user. Empty ids are skipped.
+func (u *EmployeeUsecase) validateLocationAndCostCenter(ctx context.Context, userID int, locationID string, costCenterID string) error {
+	if locationID != "" {
+		isLocationExists, err := u.employeeRepo.CheckIfLocationExists(ctx, userID, locationID)
+		if err != nil {
+			return err
+		}
+
+		if !isLocationExists {
+			return errors.Wrap(customErrors.ErrNotFound, "location not found")
+		}
+	}
+
+	if costCenterID != "" {
+		isCostCenterExists, err := u.employeeRepo.CheckIfCostCenterExists(ctx, userID, costCenterID)
+		if err != nil {
+			return err
+		}
+
+		if !isCostCenterExists {
+			return errors.Wrap(customErrors.ErrNotFound, "cost center not found")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+
+	"github.com/pkg/errors"
+)
+
+// validatePosition makes sure the position belongs to the department and,
+// with checkVacancy, that it still has a vacant seat.
+func (u *EmployeeUsecase) validatePosition(ctx context.Context, userID int, positionID string, departmentID string, checkVacancy bool) error {
+	positionDepartmentID, budgeted, filled, err := u.employeeRepo.GetPositionSeats(ctx, userID, positionID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return errors.Wrap(customErrors.ErrNotFound, "position not found")
+		}
+		return err
+	}
+
+	if positionDepartmentID != departmentID {
+		return errors.Wrap(customErrors.ErrBadRequest, "position is not in the department")
+	}
+
+	if checkVacancy && filled >= budgeted {
+		return errors.Wrap(customErrors.ErrConflict, "position has no vacant seat")
+	}
+
+	return nil
+}
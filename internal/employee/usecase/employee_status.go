@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/employee/dto"
+	"ps-gogo-manajer/internal/employee/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const DATE_LAYOUT = "2006-01-02"
+
+// statusTransitions lists the statuses an employee may move to from each
+// status. Terminated is final, a rehire is a new employee record.
+var statusTransitions = map[dto.Status][]dto.Status{
+	dto.StatusCandidate:  {dto.StatusOnboarding, dto.StatusActive, dto.StatusTerminated},
+	dto.StatusOnboarding: {dto.StatusActive, dto.StatusTerminated},
+	dto.StatusActive:     {dto.StatusOnLeave, dto.StatusSuspended, dto.StatusTerminated},
+	dto.StatusOnLeave:    {dto.StatusActive, dto.StatusTerminated},
+	dto.StatusSuspended:  {dto.StatusActive, dto.StatusTerminated},
+	dto.StatusTerminated: {},
+}
+
+func canTransition(from dto.Status, to dto.Status) bool {
+	for _, status := range statusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeStatus moves an employee to a new status. A transition effective
+// today or earlier is applied right away, a future one is kept pending until
+// its effective date. Only one pending transition is allowed at a time.
+func (u *EmployeeUsecase) ChangeStatus(ctx context.Context, userID int, identityNumber string, payload *dto.ChangeStatusPayload) (*dto.Employee, error) {
+	employee, err := u.employeeRepo.GetEmployee(ctx, userID, identityNumber)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+		}
+		return nil, err
+	}
+
+	if !canTransition(employee.Status, payload.Status) {
+		return nil, errors.Wrapf(customErrors.ErrBadRequest, "cannot change status from %s to %s", employee.Status, payload.Status)
+	}
+
+	isPendingExists, err := u.employeeRepo.CheckIfPendingStatusTransitionExists(ctx, userID, identityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if isPendingExists {
+		return nil, errors.Wrap(customErrors.ErrConflict, "employee already has a pending status change")
+	}
+
+	today := time.Now().Format(DATE_LAYOUT)
+	if payload.EffectiveDate == "" {
+		payload.EffectiveDate = today
+	}
+
+	if payload.Status == dto.StatusTerminated && payload.LastWorkingDate == "" {
+		payload.LastWorkingDate = payload.EffectiveDate
+	}
+
+	// * Dates share the same layout, so they compare as strings
+	isApplied := payload.EffectiveDate <= today
+
+	employee, err = u.employeeRepo.ChangeStatus(ctx, userID, identityNumber, employee.Status, payload, isApplied)
+	if err != nil {
+		if errors.Is(err, repository.ErrStatusChanged) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "employee status changed, please retry")
+		}
+		return nil, err
+	}
+
+	return employee, nil
+}
+
+func (u *EmployeeUsecase) GetStatusHistory(ctx context.Context, userID int, identityNumber string) ([]dto.StatusTransition, error) {
+	// * Validate if employee exists
+	isEmployeeExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEmployeeExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	return u.employeeRepo.GetStatusHistory(ctx, userID, identityNumber)
+}
+
+// ApplyDueTransitions is run by the scheduler and applies pending status
+// transitions once their effective date has come.
+func (u *EmployeeUsecase) ApplyDueTransitions(ctx context.Context) error {
+	_, err := u.employeeRepo.ApplyDueTransitions(ctx)
+	return err
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/employee/dto"
+	"ps-gogo-manajer/internal/employee/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TransferEmployee moves an employee to another department. A transfer
+// effective today or earlier is applied right away, a future one is kept
+// pending until its effective date. Only one pending transfer is allowed at
+// a time.
+func (u *EmployeeUsecase) TransferEmployee(ctx context.Context, userID int, identityNumber string, payload *dto.TransferPayload) (*dto.Employee, error) {
+	employee, err := u.employeeRepo.GetEmployee(ctx, userID, identityNumber)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+		}
+		return nil, err
+	}
+
+	if employee.DepartmentId == payload.DepartmentId {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "employee is already in the department")
+	}
+
+	isDepartmentExists, err := u.employeeRepo.CheckIfDepartmentExists(ctx, userID, payload.DepartmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDepartmentExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "department id for this user not found")
+	}
+
+	isPendingExists, err := u.employeeRepo.CheckIfPendingTransferExists(ctx, userID, identityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if isPendingExists {
+		return nil, errors.Wrap(customErrors.ErrConflict, "employee already has a pending department transfer")
+	}
+
+	today := time.Now().Format(DATE_LAYOUT)
+	if payload.EffectiveDate == "" {
+		payload.EffectiveDate = today
+	}
+
+	// * Dates share the same layout, so they compare as strings
+	isApplied := payload.EffectiveDate <= today
+
+	employee, err = u.employeeRepo.TransferEmployee(ctx, userID, identityNumber, employee.DepartmentId, payload, isApplied)
+	if err != nil {
+		if errors.Is(err, repository.ErrDepartmentChanged) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "employee department changed, please retry")
+		}
+		return nil, err
+	}
+
+	return employee, nil
+}
+
+func (u *EmployeeUsecase) GetDepartmentHistory(ctx context.Context, userID int, identityNumber string) ([]dto.DepartmentTransfer, error) {
+	// * Validate if employee exists
+	isEmployeeExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEmployeeExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	return u.employeeRepo.GetDepartmentHistory(ctx, userID, identityNumber)
+}
+
+// GetDepartmentAsOf returns the department the employee was in on the date,
+// today when no date is given.
+func (u *EmployeeUsecase) GetDepartmentAsOf(ctx context.Context, userID int, params *dto.GetDepartmentAsOfParams) (*dto.DepartmentAssignment, error) {
+	isEmployeeExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, params.IdentityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEmployeeExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	asOf := params.AsOf
+	if asOf == "" {
+		asOf = time.Now().Format(DATE_LAYOUT)
+	}
+
+	assignment, err := u.employeeRepo.GetDepartmentAsOf(ctx, userID, params.IdentityNumber, asOf)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee had no department on the date")
+		}
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+// ApplyDueTransfers is run by the scheduler and applies pending department
+// transfers once their effective date has come.
+func (u *EmployeeUsecase) ApplyDueTransfers(ctx context.Context) error {
+	_, err := u.employeeRepo.ApplyDueTransfers(ctx)
+	return err
+}
@@ -2,26 +2,35 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	customFieldUsecase "ps-gogo-manajer/internal/customfield/usecase"
 	"ps-gogo-manajer/internal/employee/dto"
 	"ps-gogo-manajer/internal/employee/repository"
 	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/helper"
+	"ps-gogo-manajer/pkg/pagination"
+	"strconv"
 
 	"github.com/pkg/errors"
 )
 
+const DEFAULT_SORT = "id"
+
 type EmployeeUsecase struct {
-	employeeRepo repository.EmployeeRepository
+	employeeRepo       repository.EmployeeRepository
+	customFieldUsecase customFieldUsecase.CustomFieldUsecase
 }
 
-func NewEmployeeUsecase(employeeRepo repository.EmployeeRepository) *EmployeeUsecase {
+func NewEmployeeUsecase(employeeRepo repository.EmployeeRepository, customFieldUsecase customFieldUsecase.CustomFieldUsecase) *EmployeeUsecase {
 	return &EmployeeUsecase{
-		employeeRepo: employeeRepo,
+		employeeRepo:       employeeRepo,
+		customFieldUsecase: customFieldUsecase,
 	}
 }
 
 func (u *EmployeeUsecase) CreateEmployee(ctx context.Context, userID int, payload *dto.CreateEmployeePayload) (*dto.Employee, error) {
 	// Validate if identity number already exists
-	isIdentityNumberExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, payload.IdentityNumber)
+	isIdentityNumberExists, err := u.employeeRepo.CheckIfIdentityNumberExists(ctx, userID, payload.IdentityNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -40,26 +49,216 @@ func (u *EmployeeUsecase) CreateEmployee(ctx context.Context, userID int, payloa
 		return nil, errors.Wrap(customErrors.ErrNotFound, "department id for this user not found")
 	}
 
-	return u.employeeRepo.CreateEmployee(ctx, userID, payload)
+	// * Headcount limits can be overridden, the position must still fit. Seats
+	// are checked when the employee is created, under a lock
+	if payload.PositionId != "" {
+		if err := u.validatePosition(ctx, userID, payload.PositionId, payload.DepartmentId, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if payload.JobTitleId != "" {
+		if err := u.validateJobTitle(ctx, userID, payload.IdentityNumber, payload.JobTitleId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.validateLocationAndCostCenter(ctx, userID, payload.LocationId, payload.CostCenterId); err != nil {
+		return nil, err
+	}
+
+	if payload.ManagerIdentityNumber != "" {
+		if err := u.validateManagerExists(ctx, userID, payload.ManagerIdentityNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.customFieldUsecase.ValidateValues(ctx, userID, payload.CustomFields, true); err != nil {
+		return nil, err
+	}
+
+	employee, err := u.employeeRepo.CreateEmployee(ctx, userID, payload)
+	if err != nil {
+		var headcountErr *repository.HeadcountError
+		switch {
+		case errors.Is(err, repository.ErrNoVacantSeat):
+			return nil, errors.Wrap(customErrors.ErrConflict, "position has no vacant seat")
+		case errors.As(err, &headcountErr):
+			return nil, errors.Wrap(customErrors.ErrConflict, headcountErr.Error())
+		}
+		return nil, err
+	}
+
+	return employee, nil
 }
 
 func (u *EmployeeUsecase) GetListEmployee(ctx context.Context, userID int, payload *dto.GetEmployeeParams) (*[]dto.Employee, error) {
-	return u.employeeRepo.GetListEmployee(ctx, userID, payload)
+	if err := u.parseCustomFieldFilter(ctx, userID, payload); err != nil {
+		return nil, err
+	}
+
+	sort, isValid := pagination.ParseSort(payload.Sort, repository.EmployeeSortColumns, DEFAULT_SORT)
+	if !isValid {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "invalid sort")
+	}
+
+	return u.employeeRepo.GetListEmployee(ctx, userID, payload, sort)
+}
+
+func (u *EmployeeUsecase) GetPageEmployee(ctx context.Context, userID int, payload *dto.GetEmployeeParams) (*pagination.Page[dto.Employee], error) {
+	if err := u.parseCustomFieldFilter(ctx, userID, payload); err != nil {
+		return nil, err
+	}
+
+	sort, isValid := pagination.ParseSort(payload.Sort, repository.EmployeeSortColumns, DEFAULT_SORT)
+	if !isValid {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "invalid sort")
+	}
+
+	var cursor *pagination.Cursor
+	if payload.Cursor != "" {
+		decoded, err := pagination.DecodeCursor(payload.Cursor)
+		if err != nil {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		}
+
+		// * A cursor only makes sense for the ordering it was created with
+		if decoded.Sort != sort.Key {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, "cursor does not match sort")
+		}
+		cursor = decoded
+	}
+
+	page, err := u.employeeRepo.GetPageEmployee(ctx, userID, payload, sort, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.WithTotal {
+		total, err := u.employeeRepo.CountEmployee(ctx, userID, payload)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	return page, nil
 }
 
 func (u *EmployeeUsecase) UpdateEmployee(ctx context.Context, userID int, identityNumber string, payload *dto.PatchEmployeePayload) (*dto.Employee, error) {
 	// Validate if employee exists
-	isEmployeeExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
+	employee, err := u.employeeRepo.GetEmployee(ctx, userID, identityNumber)
 	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+		}
 		return nil, err
 	}
 
-	if !isEmployeeExists {
-		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	if err := u.validateUpdate(ctx, userID, identityNumber, employee, payload); err != nil {
+		return nil, err
+	}
+
+	employee, err = u.employeeRepo.UpdateEmployee(ctx, userID, identityNumber, payload)
+	if err != nil {
+		if errors.Is(err, repository.ErrManagerCycle) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "manager already reports to this employee")
+		}
+		return nil, err
 	}
 
+	return employee, nil
+}
+
+// validateUpdate checks the payload of an update or a revert against the
+// current state of the employee.
+func (u *EmployeeUsecase) validateUpdate(ctx context.Context, userID int, identityNumber string, employee *dto.Employee, payload *dto.PatchEmployeePayload) error {
 	// * Validate if payload's identityNumber already exists
-	isIdentityNumberExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, payload.IdentityNumber)
+	if payload.IdentityNumber != identityNumber {
+		isIdentityNumberExists, err := u.employeeRepo.CheckIfIdentityNumberExists(ctx, userID, payload.IdentityNumber)
+		if err != nil {
+			return err
+		}
+
+		if isIdentityNumberExists {
+			return errors.Wrap(customErrors.ErrConflict, "identity number already exists")
+		}
+	}
+
+	// Validate if department id for respective user exists. Employees left in
+	// an archived department can still be edited as long as they stay there.
+	if payload.DepartmentId != employee.DepartmentId {
+		isDepartmentExists, err := u.employeeRepo.CheckIfDepartmentExists(ctx, userID, payload.DepartmentId)
+		if err != nil {
+			return err
+		}
+
+		if !isDepartmentExists {
+			return errors.Wrap(customErrors.ErrNotFound, "department id for this user not found")
+		}
+	}
+
+	if payload.JobTitleId != "" && payload.JobTitleId != employee.JobTitleId {
+		if err := u.validateJobTitle(ctx, userID, identityNumber, payload.JobTitleId); err != nil {
+			return err
+		}
+	}
+
+	if err := u.validateLocationAndCostCenter(ctx, userID, payload.LocationId, payload.CostCenterId); err != nil {
+		return err
+	}
+
+	if payload.PositionId != "" {
+		// * Only a seat the employee does not hold yet needs to be vacant
+		isNewSeat := payload.PositionId != employee.PositionId
+		if err := u.validatePosition(ctx, userID, payload.PositionId, payload.DepartmentId, isNewSeat); err != nil {
+			return err
+		}
+	}
+
+	if payload.ManagerIdentityNumber != "" {
+		if err := u.validateManager(ctx, userID, identityNumber, payload.ManagerIdentityNumber); err != nil {
+			return err
+		}
+	}
+
+	if err := u.customFieldUsecase.ValidateValues(ctx, userID, payload.CustomFields, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *EmployeeUsecase) DeleteEmployee(ctx context.Context, userID int, identityNumber string) error {
+	// * Validate if employee exists
+	isEmployeeExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
+	if err != nil {
+		return err
+	}
+
+	if !isEmployeeExists {
+		return errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	return u.employeeRepo.DeleteEmployee(ctx, userID, identityNumber)
+}
+
+func (u *EmployeeUsecase) RestoreEmployee(ctx context.Context, userID int, identityNumber string) (*dto.Employee, error) {
+	// * Validate if employee is in the trash
+	_, isDepartmentTrashed, err := u.employeeRepo.GetTrashedEmployeeDepartment(ctx, userID, identityNumber)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found in trash")
+		}
+		return nil, err
+	}
+
+	if isDepartmentTrashed {
+		return nil, errors.Wrap(customErrors.ErrConflict, "department of this employee is in trash, restore it first")
+	}
+
+	// * Validate if identity number has been taken since the employee was deleted
+	isIdentityNumberExists, err := u.employeeRepo.CheckIfIdentityNumberExists(ctx, userID, identityNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -68,29 +267,230 @@ func (u *EmployeeUsecase) UpdateEmployee(ctx context.Context, userID int, identi
 		return nil, errors.Wrap(customErrors.ErrConflict, "identity number already exists")
 	}
 
-	// Validate if department id for respective user exists
-	isDepartmentExists, err := u.employeeRepo.CheckIfDepartmentExists(ctx, userID, payload.DepartmentId)
+	return u.employeeRepo.RestoreEmployee(ctx, userID, identityNumber)
+}
+
+func (u *EmployeeUsecase) GetEmployeeHistory(ctx context.Context, userID int, identityNumber string) ([]dto.EmployeeVersion, error) {
+	// * Validate if employee exists
+	isEmployeeExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	if !isDepartmentExists {
-		return nil, errors.Wrap(customErrors.ErrNotFound, "department id for this user not found")
+	if !isEmployeeExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
 	}
 
-	return u.employeeRepo.UpdateEmployee(ctx, userID, identityNumber, payload)
+	return u.employeeRepo.GetEmployeeHistory(ctx, userID, identityNumber)
 }
 
-func (u *EmployeeUsecase) DeleteEmployee(ctx context.Context, userID int, identityNumber string) error {
+// RevertEmployee brings an employee back to the state it had right after the
+// given version was recorded. Version 0 is the state at creation. The revert
+// is validated like any other edit and shows up as a new version in the
+// history, but fields that were empty in that version are cleared.
+func (u *EmployeeUsecase) RevertEmployee(ctx context.Context, userID int, identityNumber string, version int) (*dto.Employee, error) {
+	employee, err := u.employeeRepo.GetEmployee(ctx, userID, identityNumber)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+		}
+		return nil, err
+	}
+
+	history, err := u.employeeRepo.GetEmployeeHistory(ctx, userID, identityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	latestVersion := 0
+	if len(history) > 0 {
+		latestVersion = history[0].Version
+	}
+
+	if version > latestVersion {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "version not found")
+	}
+
+	if version == latestVersion {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "employee is already at this version")
+	}
+
+	// * Undo every change newer than the requested version, newest first
+	target := *employee
+	customFields := make(map[string]any)
+	for _, v := range history {
+		if v.Version <= version {
+			break
+		}
+		for _, change := range v.Changes {
+			revertEmployeeField(&target, customFields, change)
+		}
+	}
+
+	payload := dto.PatchEmployeePayload{
+		IdentityNumber:        target.IdentityNumber,
+		Name:                  target.Name,
+		Gender:                target.Gender,
+		DepartmentId:          target.DepartmentId,
+		PositionId:            target.PositionId,
+		EmployeeImageUri:      target.EmployeeImageUri,
+		JobTitle:              target.JobTitle,
+		JobTitleId:            target.JobTitleId,
+		LocationId:            target.LocationId,
+		CostCenterId:          target.CostCenterId,
+		ManagerIdentityNumber: target.ManagerIdentityNumber,
+	}
+
+	// * Only the reverted custom fields are sent, a nil value removes the field
+	if len(customFields) > 0 {
+		payload.CustomFields = customFields
+	}
+
+	if err := u.validateUpdate(ctx, userID, identityNumber, employee, &payload); err != nil {
+		return nil, err
+	}
+
+	reverted, err := u.employeeRepo.RevertEmployee(ctx, userID, identityNumber, &payload)
+	if err != nil {
+		if errors.Is(err, repository.ErrManagerCycle) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "manager already reports to this employee")
+		}
+		return nil, err
+	}
+
+	return reverted, nil
+}
+
+func revertEmployeeField(employee *dto.Employee, customFields map[string]any, change dto.EmployeeChange) {
+	oldValue := helper.DerefString(change.OldValue, "")
+
+	if key, isCustomField := repository.DecodeCustomFieldChange(change.Field); isCustomField {
+		var value any
+		if oldValue != "" {
+			_ = json.Unmarshal([]byte(oldValue), &value)
+		}
+		customFields[key] = value
+		return
+	}
+
+	switch change.Field {
+	case repository.FieldName:
+		employee.Name = oldValue
+	case repository.FieldIdentityNumber:
+		employee.IdentityNumber = oldValue
+	case repository.FieldGender:
+		employee.Gender = dto.Gender(oldValue)
+	case repository.FieldDepartmentId:
+		employee.DepartmentId = oldValue
+	case repository.FieldPositionId:
+		employee.PositionId = oldValue
+	case repository.FieldEmployeeImageUri:
+		employee.EmployeeImageUri = oldValue
+	case repository.FieldJobTitle:
+		employee.JobTitle = oldValue
+	case repository.FieldJobTitleId:
+		employee.JobTitleId = oldValue
+	case repository.FieldLocationId:
+		employee.LocationId = oldValue
+	case repository.FieldCostCenterId:
+		employee.CostCenterId = oldValue
+	case repository.FieldManagerIdentityNumber:
+		employee.ManagerIdentityNumber = oldValue
+	}
+}
+
+func (u *EmployeeUsecase) RemoveManager(ctx context.Context, userID int, identityNumber string) (*dto.Employee, error) {
 	// * Validate if employee exists
 	isEmployeeExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !isEmployeeExists {
-		return errors.Wrap(customErrors.ErrNotFound, "employee not found")
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
 	}
 
-	return u.employeeRepo.DeleteEmployee(ctx, userID, identityNumber)
+	return u.employeeRepo.RemoveManager(ctx, userID, identityNumber)
+}
+
+func (u *EmployeeUsecase) GetReports(ctx context.Context, userID int, payload *dto.GetReportsParams) ([]dto.Report, error) {
+	// * Validate if employee exists
+	isEmployeeExists, err := u.employeeRepo.CheckIfEmployeeExists(ctx, userID, payload.IdentityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEmployeeExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	return u.employeeRepo.GetReports(ctx, userID, payload.IdentityNumber, payload.Recursive)
+}
+
+func (u *EmployeeUsecase) GetOrgChart(ctx context.Context, userID int, departmentID int) ([]*dto.OrgChartNode, error) {
+	if departmentID != 0 {
+		isDepartmentExists, err := u.employeeRepo.CheckIfDepartmentExists(ctx, userID, strconv.Itoa(departmentID))
+		if err != nil {
+			return nil, err
+		}
+
+		if !isDepartmentExists {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "department id for this user not found")
+		}
+	}
+
+	return u.employeeRepo.GetOrgChart(ctx, userID, departmentID)
+}
+
+func (u *EmployeeUsecase) validateManagerExists(ctx context.Context, userID int, managerIdentityNumber string) error {
+	// * Reporting lines may cross departments, so the manager is looked up
+	// in the whole account even for manager logins
+	isManagerExists, err := u.employeeRepo.CheckIfIdentityNumberExists(ctx, userID, managerIdentityNumber)
+	if err != nil {
+		return err
+	}
+
+	if !isManagerExists {
+		return errors.Wrap(customErrors.ErrNotFound, "manager not found")
+	}
+
+	return nil
+}
+
+// validateManager makes sure the new manager exists and that assigning it
+// would not turn the reporting lines into a cycle. The repository repeats the
+// cycle check under a lock when the update is written.
+func (u *EmployeeUsecase) validateManager(ctx context.Context, userID int, identityNumber string, managerIdentityNumber string) error {
+	if managerIdentityNumber == identityNumber {
+		return errors.Wrap(customErrors.ErrBadRequest, "employee cannot report to themselves")
+	}
+
+	if err := u.validateManagerExists(ctx, userID, managerIdentityNumber); err != nil {
+		return err
+	}
+
+	isSubordinate, err := u.employeeRepo.CheckIfSubordinate(ctx, userID, identityNumber, managerIdentityNumber)
+	if err != nil {
+		return err
+	}
+
+	if isSubordinate {
+		return errors.Wrap(customErrors.ErrConflict, "manager already reports to this employee")
+	}
+
+	return nil
+}
+
+func (u *EmployeeUsecase) SearchEmployee(ctx context.Context, userID int, payload *dto.SearchEmployeeParams) ([]dto.EmployeeSearchResult, error) {
+	return u.employeeRepo.SearchEmployee(ctx, userID, payload)
+}
+
+func (u *EmployeeUsecase) parseCustomFieldFilter(ctx context.Context, userID int, payload *dto.GetEmployeeParams) error {
+	filter, err := u.customFieldUsecase.ParseFilters(ctx, userID, payload.CustomFields)
+	if err != nil {
+		return err
+	}
+
+	payload.CustomFieldFilter = filter
+	return nil
 }
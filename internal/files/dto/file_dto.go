@@ -1,5 +1,39 @@
 package dto
 
+import "time"
+
 type FileUploadResponse struct {
 	FileUrl string `json:"uri"`
 }
+
+// FileUpload is a file uploaded straight to the storage. It is pending until
+// CompletedAt is set.
+type FileUpload struct {
+	Id          int
+	UserId      int
+	FileKey     string
+	ContentType string
+	Size        int64
+	ExpiresAt   time.Time
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+}
+
+type CreateUploadPayload struct {
+	ContentType string `json:"contentType" validate:"required,oneof=image/jpeg image/png application/pdf"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+}
+
+type CompleteUploadPayload struct {
+	FileKey string `json:"fileKey" validate:"required,max=255"`
+}
+
+// UploadResponse is the request the client sends by itself to upload the file,
+// then completes with the file key.
+type UploadResponse struct {
+	FileKey   string            `json:"fileKey"`
+	UploadUrl string            `json:"uploadUrl"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+}
@@ -3,23 +3,42 @@ package handler
 import (
 	"mime/multipart"
 	"net/http"
+	"ps-gogo-manajer/internal/files/dto"
 	"ps-gogo-manajer/internal/files/usecase"
 	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/jwt"
 	"ps-gogo-manajer/pkg/response"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 type FileHandler struct {
-	Log     *logrus.Logger
-	Usecase *usecase.FileUsecase
+	Log       *logrus.Logger
+	Usecase   *usecase.FileUsecase
+	validator *validator.Validate
 }
 
-func NewFileHandler(Usecase *usecase.FileUsecase, logger *logrus.Logger) *FileHandler {
+func NewFileHandler(Usecase *usecase.FileUsecase, validator *validator.Validate, logger *logrus.Logger) *FileHandler {
 	return &FileHandler{Log: logger,
-		Usecase: Usecase}
+		Usecase:   Usecase,
+		validator: validator}
+}
+
+// bind binds the request into payload and validates it, wrapping any failure
+// as a bad request.
+func (c *FileHandler) bind(ctx echo.Context, payload any) error {
+	if err := ctx.Bind(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := c.validator.Struct(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
 }
 
 func (c *FileHandler) UploadFile(ctx echo.Context) error {
@@ -40,7 +59,7 @@ func (c *FileHandler) UploadFile(ctx echo.Context) error {
 		return ctx.JSON(response.WriteErrorResponse(err))
 	}
 
-	fileResponse, err := c.Usecase.UploadFile(file, *fileType)
+	fileResponse, err := c.Usecase.UploadFile(ctx.Request().Context(), file, *fileType)
 	if err != nil {
 		return ctx.JSON(response.WriteErrorResponse(err))
 	}
@@ -48,6 +67,59 @@ func (c *FileHandler) UploadFile(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, &fileResponse)
 }
 
+// CreateUpload returns a presigned request to upload a file straight to the
+// storage, completed with CompleteUpload once sent.
+func (c *FileHandler) CreateUpload(ctx echo.Context) error {
+	var payload dto.CreateUploadPayload
+	if err := c.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	upload, err := c.Usecase.CreateUpload(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, upload)
+}
+
+func (c *FileHandler) CompleteUpload(ctx echo.Context) error {
+	var payload dto.CompleteUploadPayload
+	if err := c.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	fileResponse, err := c.Usecase.CompleteUpload(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, fileResponse)
+}
+
+// ReceiveUpload receives the presigned uploads of the local storage.
+func (c *FileHandler) ReceiveUpload(ctx echo.Context) error {
+	request := ctx.Request()
+	err := c.Usecase.ReceiveUpload(request.Context(), ctx.Param("*"), request.Header.Get(echo.HeaderContentType), request.ContentLength, request.Body)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+// ServeFile serves the public files of the local storage.
+func (c *FileHandler) ServeFile(ctx echo.Context) error {
+	filePath, err := c.Usecase.GetPublicFilePath(ctx.Param("*"))
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.File(filePath)
+}
+
 func (c *FileHandler) isValidFile(fileHeader *multipart.FileHeader, file multipart.File) (*string, bool) {
 
 	if fileHeader.Size > 100*1024 {
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/files/dto"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type FileRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewFileRepository(pool *pgxpool.Pool) *FileRepository {
+	return &FileRepository{pool: pool}
+}
+
+const (
+	fileUploadColumns = `
+		id,
+		user_id,
+		file_key,
+		content_type,
+		size,
+		expires_at,
+		completed_at,
+		created_at`
+
+	queryCreateUpload = `
+	INSERT INTO file_uploads(user_id, file_key, content_type, size, expires_at)
+	VALUES (@userID, @fileKey, @contentType, @size, @expiresAt)
+	RETURNING` + fileUploadColumns + `;`
+	queryGetUpload = `
+	SELECT` + fileUploadColumns + `
+	FROM file_uploads
+	WHERE
+		user_id = @userID
+		AND file_key = @fileKey;`
+	queryGetPendingUpload = `
+	SELECT` + fileUploadColumns + `
+	FROM file_uploads
+	WHERE
+		file_key = @fileKey
+		AND completed_at IS NULL
+		AND expires_at > NOW();`
+	queryCompleteUpload = `
+	UPDATE file_uploads
+	SET completed_at = NOW()
+	WHERE
+		id = @uploadID
+		AND completed_at IS NULL
+		AND expires_at > NOW()
+	RETURNING` + fileUploadColumns + `;`
+	queryGetExpiredUploads = `
+	SELECT` + fileUploadColumns + `
+	FROM file_uploads
+	WHERE
+		staging_deleted_at IS NULL
+		AND expires_at < @before
+	ORDER BY expires_at
+	LIMIT @limit;`
+	queryMarkStagingDeleted = `
+	UPDATE file_uploads
+	SET staging_deleted_at = NOW()
+	WHERE id = @uploadID;`
+	queryDeleteUpload = `
+	DELETE FROM file_uploads
+	WHERE
+		id = @uploadID
+		AND completed_at IS NULL;`
+)
+
+func scanUpload(row pgx.Row) (*dto.FileUpload, error) {
+	var upload dto.FileUpload
+
+	err := row.Scan(
+		&upload.Id,
+		&upload.UserId,
+		&upload.FileKey,
+		&upload.ContentType,
+		&upload.Size,
+		&upload.ExpiresAt,
+		&upload.CompletedAt,
+		&upload.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+func (r *FileRepository) CreateUpload(ctx context.Context, upload *dto.FileUpload) (*dto.FileUpload, error) {
+	args := pgx.NamedArgs{
+		"userID":      upload.UserId,
+		"fileKey":     upload.FileKey,
+		"contentType": upload.ContentType,
+		"size":        upload.Size,
+		"expiresAt":   upload.ExpiresAt,
+	}
+
+	created, err := scanUpload(r.pool.QueryRow(ctx, queryCreateUpload, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create upload")
+	}
+
+	return created, nil
+}
+
+func (r *FileRepository) GetUpload(ctx context.Context, userID int, fileKey string) (*dto.FileUpload, error) {
+	args := pgx.NamedArgs{
+		"userID":  userID,
+		"fileKey": fileKey,
+	}
+
+	upload, err := scanUpload(r.pool.QueryRow(ctx, queryGetUpload, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get upload")
+	}
+
+	return upload, nil
+}
+
+// GetPendingUpload returns the upload under the key if it can still be
+// uploaded to, whoever it belongs to.
+func (r *FileRepository) GetPendingUpload(ctx context.Context, fileKey string) (*dto.FileUpload, error) {
+	args := pgx.NamedArgs{
+		"fileKey": fileKey,
+	}
+
+	upload, err := scanUpload(r.pool.QueryRow(ctx, queryGetPendingUpload, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get upload")
+	}
+
+	return upload, nil
+}
+
+func (r *FileRepository) CompleteUpload(ctx context.Context, uploadID int) (*dto.FileUpload, error) {
+	args := pgx.NamedArgs{
+		"uploadID": uploadID,
+	}
+
+	upload, err := scanUpload(r.pool.QueryRow(ctx, queryCompleteUpload, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to complete upload")
+	}
+
+	return upload, nil
+}
+
+// GetExpiredUploads returns up to limit uploads expired at before whose
+// staging key has not been cleared yet, pending and completed.
+func (r *FileRepository) GetExpiredUploads(ctx context.Context, before time.Time, limit int) ([]dto.FileUpload, error) {
+	uploads := make([]dto.FileUpload, 0)
+	args := pgx.NamedArgs{
+		"before": before,
+		"limit":  limit,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetExpiredUploads, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get expired uploads")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		uploads = append(uploads, *upload)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get expired uploads")
+	}
+
+	return uploads, nil
+}
+
+func (r *FileRepository) MarkStagingDeleted(ctx context.Context, uploadID int) error {
+	args := pgx.NamedArgs{
+		"uploadID": uploadID,
+	}
+
+	if _, err := r.pool.Exec(ctx, queryMarkStagingDeleted, args); err != nil {
+		return errors.Wrap(err, "failed to update upload")
+	}
+
+	return nil
+}
+
+// DeleteUpload removes a pending upload, a completed one is kept.
+func (r *FileRepository) DeleteUpload(ctx context.Context, uploadID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"uploadID": uploadID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeleteUpload, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete upload")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	publicDir  = "public"
+	privateDir = "private"
+)
+
+// Local keeps the files on the local filesystem, public and private files
+// apart, so that only public files are served by PublicPath. It needs no
+// other service, for development and tests.
+type Local struct {
+	dir       string
+	publicURL string
+}
+
+// NewLocal stores the files under dir. publicURL is the base URL of the API
+// route serving the public files.
+func NewLocal(dir string, publicURL string) (*Local, error) {
+	for _, sub := range []string{publicDir, privateDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, errors.Wrap(err, "failed to create storage directory")
+		}
+	}
+
+	return &Local{
+		dir:       dir,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}, nil
+}
+
+// resolve returns the file path of the key. Cleaning the key as an absolute
+// path first keeps it from reaching outside of the storage directory.
+func (s *Local) resolve(sub string, key string) string {
+	return filepath.Join(s.dir, sub, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+func (s *Local) Put(ctx context.Context, object *Object) error {
+	sub := privateDir
+	if object.IsPublic {
+		sub = publicDir
+	}
+
+	filePath := s.resolve(sub, object.Key)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return errors.Wrap(err, "failed to upload file")
+	}
+
+	// * Written next to the target and renamed, so a failed upload leaves no partial file
+	file, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to upload file")
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, object.Body); err != nil {
+		file.Close()
+		return errors.Wrap(err, "failed to upload file")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "failed to upload file")
+	}
+
+	if err := os.Rename(file.Name(), filePath); err != nil {
+		return errors.Wrap(err, "failed to upload file")
+	}
+
+	return nil
+}
+
+func (s *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	for _, sub := range []string{privateDir, publicDir} {
+		file, err := os.Open(s.resolve(sub, key))
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, errors.Wrap(err, "failed to download file")
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+func (s *Local) Delete(ctx context.Context, key string) error {
+	for _, sub := range []string{privateDir, publicDir} {
+		if err := os.Remove(s.resolve(sub, key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Wrap(err, "failed to delete file")
+		}
+	}
+
+	return nil
+}
+
+func (s *Local) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+// PresignPut points the client to the upload route of the API, which checks
+// the request against the pending upload itself.
+func (s *Local) PresignPut(ctx context.Context, object *Object, expires time.Duration) (*PresignedRequest, error) {
+	return &PresignedRequest{
+		URL:    s.publicURL + "/upload/" + object.Key,
+		Method: http.MethodPut,
+		Headers: map[string]string{
+			"Content-Type":   object.ContentType,
+			"Content-Length": strconv.FormatInt(object.Size, 10),
+		},
+	}, nil
+}
+
+func (s *Local) Stat(ctx context.Context, key string) (int64, error) {
+	for _, sub := range []string{privateDir, publicDir} {
+		info, err := os.Stat(s.resolve(sub, key))
+		if err == nil && !info.IsDir() {
+			return info.Size(), nil
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return 0, errors.Wrap(err, "failed to get file")
+		}
+	}
+
+	return 0, ErrNotFound
+}
+
+func (s *Local) Copy(ctx context.Context, srcKey string, object *Object) error {
+	content, err := s.Get(ctx, srcKey)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	return s.Put(ctx, &Object{
+		Key:         object.Key,
+		ContentType: object.ContentType,
+		Body:        content,
+		IsPublic:    object.IsPublic,
+	})
+}
+
+// PublicPath returns the file path of a public file, for the API route that
+// serves them.
+func (s *Local) PublicPath(key string) (string, error) {
+	filePath := s.resolve(publicDir, key)
+	info, err := os.Stat(filePath)
+	if err != nil || info.IsDir() {
+		return "", ErrNotFound
+	}
+
+	return filePath, nil
+}
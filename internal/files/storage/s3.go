@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/pkg/errors"
+)
+
+type S3Config struct {
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// Endpoint is the URL of an S3 compatible service such as MinIO, AWS is
+	// used when it is empty.
+	Endpoint     string
+	UsePathStyle bool
+	// PublicURL is the base URL public objects are served from, for a CDN or
+	// a service whose public address differs from Endpoint.
+	PublicURL string
+}
+
+type S3 struct {
+	client *s3.Client
+	config S3Config
+}
+
+func NewS3(client *s3.Client, config S3Config) *S3 {
+	return &S3{
+		client: client,
+		config: config,
+	}
+}
+
+func (s *S3) putObjectInput(object *Object) *s3.PutObjectInput {
+	acl := types.ObjectCannedACLPrivate
+	if object.IsPublic {
+		acl = types.ObjectCannedACLPublicRead
+	}
+
+	return &s3.PutObjectInput{
+		Bucket:      aws.String(s.config.Bucket),
+		Key:         aws.String(object.Key),
+		ACL:         acl,
+		ContentType: aws.String(object.ContentType),
+		Body:        object.Body,
+	}
+}
+
+func (s *S3) Put(ctx context.Context, object *Object) error {
+	_, err := s.client.PutObject(ctx, s.putObjectInput(object))
+	if err != nil {
+		return errors.Wrap(err, "failed to upload file")
+	}
+
+	return nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Wrap(err, "failed to download file")
+	}
+
+	return object.Body, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to delete file")
+	}
+
+	return nil
+}
+
+func (s *S3) URL(key string) string {
+	switch {
+	case s.config.PublicURL != "":
+		return strings.TrimSuffix(s.config.PublicURL, "/") + "/" + key
+	case s.config.Endpoint != "" && s.config.UsePathStyle:
+		return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(s.config.Endpoint, "/"), s.config.Bucket, key)
+	case s.config.Endpoint != "":
+		scheme, host, _ := strings.Cut(s.config.Endpoint, "://")
+		return fmt.Sprintf("%s://%s.%s/%s", scheme, s.config.Bucket, strings.TrimSuffix(host, "/"), key)
+	default:
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.config.Bucket, s.config.Region, key)
+	}
+}
+
+// PresignPut signs the content type and the length of the object, so S3
+// refuses an upload of any other type or size.
+func (s *S3) PresignPut(ctx context.Context, object *Object, expires time.Duration) (*PresignedRequest, error) {
+	input := s.putObjectInput(object)
+	input.ContentLength = aws.Int64(object.Size)
+
+	request, err := s3.NewPresignClient(s.client).PresignPutObject(ctx, input, s3.WithPresignExpires(expires))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to presign upload")
+	}
+
+	headers := map[string]string{
+		"Content-Type":   object.ContentType,
+		"Content-Length": strconv.FormatInt(object.Size, 10),
+	}
+	for name, values := range request.SignedHeader {
+		// * The client sets the host from the URL itself
+		if strings.EqualFold(name, "Host") {
+			continue
+		}
+		headers[http.CanonicalHeaderKey(name)] = strings.Join(values, ",")
+	}
+
+	return &PresignedRequest{
+		URL:     request.URL,
+		Method:  request.Method,
+		Headers: headers,
+	}, nil
+}
+
+func (s *S3) Stat(ctx context.Context, key string) (int64, error) {
+	object, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return 0, ErrNotFound
+		}
+		return 0, errors.Wrap(err, "failed to get file")
+	}
+
+	return aws.ToInt64(object.ContentLength), nil
+}
+
+func (s *S3) Copy(ctx context.Context, srcKey string, object *Object) error {
+	acl := types.ObjectCannedACLPrivate
+	if object.IsPublic {
+		acl = types.ObjectCannedACLPublicRead
+	}
+
+	_, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:            aws.String(s.config.Bucket),
+		Key:               aws.String(object.Key),
+		CopySource:        aws.String(s.config.Bucket + "/" + url.PathEscape(srcKey)),
+		ACL:               acl,
+		ContentType:       aws.String(object.ContentType),
+		MetadataDirective: types.MetadataDirectiveReplace,
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return ErrNotFound
+		}
+		return errors.Wrap(err, "failed to copy file")
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound is returned by Get and Stat when there is no file under the
+// key.
+var ErrNotFound = errors.New("file not found")
+
+// Object is a file to store. Public objects can be fetched by anyone from
+// their URL, private ones only through Get.
+type Object struct {
+	Key         string
+	ContentType string
+	Body        io.Reader
+	IsPublic    bool
+	// Size is the exact length of the body, which a presigned upload must
+	// match.
+	Size int64
+}
+
+// PresignedRequest is the request a client sends by itself to upload a file
+// straight to the storage, headers included.
+type PresignedRequest struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+}
+
+// Storage keeps the uploaded files.
+type Storage interface {
+	Put(ctx context.Context, object *Object) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns the address a public object is served from.
+	URL(key string) string
+	// PresignPut returns a request that uploads the object, without its
+	// body, for as long as expires.
+	PresignPut(ctx context.Context, object *Object, expires time.Duration) (*PresignedRequest, error)
+	// Stat returns the size of the file under the key.
+	Stat(ctx context.Context, key string) (int64, error)
+	// Copy stores the file under srcKey as the object, whose body is not
+	// used.
+	Copy(ctx context.Context, srcKey string, object *Object) error
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"ps-gogo-manajer/internal/files/dto"
+	"ps-gogo-manajer/internal/files/storage"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// UPLOAD_URL_EXPIRY is how long the presigned request can be sent.
+	UPLOAD_URL_EXPIRY = 15 * time.Minute
+	// UPLOAD_COMPLETE_EXPIRY is how long an upload can be completed, pending
+	// uploads are purged afterwards.
+	UPLOAD_COMPLETE_EXPIRY = time.Hour
+	MAX_DIRECT_UPLOAD_SIZE = 25 * 1024 * 1024
+	PURGE_UPLOADS_BATCH    = 100
+	// STAGING_PREFIX is where uploads are sent to, privately, until they are
+	// verified and copied to their public key.
+	STAGING_PREFIX = "uploads/pending/"
+)
+
+func stagingKey(fileKey string) string {
+	return STAGING_PREFIX + fileKey
+}
+
+// CreateUpload registers a pending upload and presigns the request the
+// client sends the file with, so the file never passes through the API. The
+// request uploads to a private staging key, only the copy made by
+// CompleteUpload is public.
+func (c *FileUsecase) CreateUpload(ctx context.Context, userID int, payload *dto.CreateUploadPayload) (*dto.UploadResponse, error) {
+	if payload.Size > MAX_DIRECT_UPLOAD_SIZE {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "file is too large")
+	}
+
+	upload, err := c.fileRepo.CreateUpload(ctx, &dto.FileUpload{
+		UserId:      userID,
+		FileKey:     c.generateFilename(payload.ContentType),
+		ContentType: payload.ContentType,
+		Size:        payload.Size,
+		ExpiresAt:   time.Now().Add(UPLOAD_COMPLETE_EXPIRY),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := c.storage.PresignPut(ctx, &storage.Object{
+		Key:         stagingKey(upload.FileKey),
+		ContentType: upload.ContentType,
+		Size:        upload.Size,
+	}, UPLOAD_URL_EXPIRY)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UploadResponse{
+		FileKey:   upload.FileKey,
+		UploadUrl: request.URL,
+		Method:    request.Method,
+		Headers:   request.Headers,
+		ExpiresAt: upload.CreatedAt.Add(UPLOAD_URL_EXPIRY),
+	}, nil
+}
+
+// CompleteUpload accepts the uploaded file once its size and sniffed content
+// type match the upload, copying it from the staging key to its public key.
+// Whatever is uploaded to the staging key afterwards is never copied. A file
+// that does not match is deleted, so the client can upload it again while
+// the upload has not expired.
+func (c *FileUsecase) CompleteUpload(ctx context.Context, userID int, payload *dto.CompleteUploadPayload) (*dto.FileUploadResponse, error) {
+	upload, err := c.fileRepo.GetUpload(ctx, userID, payload.FileKey)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "upload not found")
+		}
+		return nil, err
+	}
+
+	response := &dto.FileUploadResponse{FileUrl: c.storage.URL(upload.FileKey)}
+	if upload.CompletedAt != nil {
+		return response, nil
+	}
+
+	if !upload.ExpiresAt.After(time.Now()) {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "upload has expired")
+	}
+
+	if err := c.verifyUpload(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	err = c.storage.Copy(ctx, stagingKey(upload.FileKey), &storage.Object{
+		Key:         upload.FileKey,
+		ContentType: upload.ContentType,
+		IsPublic:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.fileRepo.CompleteUpload(ctx, upload.Id); err != nil {
+		if !errors.Is(err, customErrors.ErrNotFound) {
+			return nil, err
+		}
+
+		// * Completed by a concurrent request, or expired, in the meantime
+		upload, err = c.fileRepo.GetUpload(ctx, userID, payload.FileKey)
+		if err != nil {
+			return nil, err
+		}
+		if upload.CompletedAt == nil {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "upload has expired")
+		}
+		return response, nil
+	}
+
+	// * The staging key is cleared again by PurgeExpiredUploads, once it can no longer be uploaded to
+	if err := c.storage.Delete(ctx, stagingKey(upload.FileKey)); err != nil {
+		c.log.WithError(err).WithField("fileKey", upload.FileKey).Warn("failed to delete staged upload")
+	}
+
+	return response, nil
+}
+
+func (c *FileUsecase) verifyUpload(ctx context.Context, upload *dto.FileUpload) error {
+	key := stagingKey(upload.FileKey)
+	size, err := c.storage.Stat(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return errors.Wrap(customErrors.ErrBadRequest, "file has not been uploaded")
+		}
+		return err
+	}
+
+	if size != upload.Size {
+		if err := c.storage.Delete(ctx, key); err != nil {
+			return err
+		}
+		return errors.Wrap(customErrors.ErrBadRequest, "file size does not match the upload")
+	}
+
+	content, err := c.storage.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	buffer := make([]byte, 512)
+	n, err := io.ReadFull(content, buffer)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return errors.Wrap(err, "failed to read file")
+	}
+
+	if http.DetectContentType(buffer[:n]) != upload.ContentType {
+		if err := c.storage.Delete(ctx, key); err != nil {
+			return err
+		}
+		return errors.Wrap(customErrors.ErrBadRequest, "file type does not match the upload")
+	}
+
+	return nil
+}
+
+// ReceiveUpload stores the body of a presigned request for the local
+// storage, which has no storage server to send it to. The key of a pending
+// upload is what authorizes the request.
+func (c *FileUsecase) ReceiveUpload(ctx context.Context, key string, contentType string, size int64, body io.Reader) error {
+	if _, ok := c.storage.(*storage.Local); !ok {
+		return errors.Wrap(customErrors.ErrNotFound, "upload not found")
+	}
+
+	upload, err := c.fileRepo.GetPendingUpload(ctx, key)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return errors.Wrap(customErrors.ErrNotFound, "upload not found")
+		}
+		return err
+	}
+
+	if time.Since(upload.CreatedAt) > UPLOAD_URL_EXPIRY {
+		return errors.Wrap(customErrors.ErrNotFound, "upload not found")
+	}
+
+	if contentType != upload.ContentType || size != upload.Size {
+		return errors.Wrap(customErrors.ErrBadRequest, "file does not match the upload")
+	}
+
+	return c.storage.Put(ctx, &storage.Object{
+		Key:         stagingKey(upload.FileKey),
+		ContentType: upload.ContentType,
+		Body:        io.LimitReader(body, upload.Size),
+		Size:        upload.Size,
+	})
+}
+
+// PurgeExpiredUploads is run by the scheduler and deletes the uploads that
+// were never completed along with whatever file was uploaded for them. The
+// staging key of completed uploads is cleared as well, as it could still be
+// uploaded to until the upload expired.
+func (c *FileUsecase) PurgeExpiredUploads(ctx context.Context) error {
+	var purged int
+
+	for {
+		uploads, err := c.fileRepo.GetExpiredUploads(ctx, time.Now(), PURGE_UPLOADS_BATCH)
+		if err != nil {
+			return err
+		}
+
+		for _, upload := range uploads {
+			if err := c.purgeUpload(ctx, &upload); err != nil {
+				return err
+			}
+			purged++
+		}
+
+		if len(uploads) < PURGE_UPLOADS_BATCH {
+			break
+		}
+	}
+
+	if purged > 0 {
+		c.log.WithFields(logrus.Fields{
+			"uploads": purged,
+		}).Info("purged expired uploads")
+	}
+
+	return nil
+}
+
+func (c *FileUsecase) purgeUpload(ctx context.Context, upload *dto.FileUpload) error {
+	if err := c.storage.Delete(ctx, stagingKey(upload.FileKey)); err != nil {
+		return err
+	}
+
+	if upload.CompletedAt != nil {
+		return c.fileRepo.MarkStagingDeleted(ctx, upload.Id)
+	}
+
+	// * The row goes first, so a file completed in the meantime is kept
+	isDeleted, err := c.fileRepo.DeleteUpload(ctx, upload.Id)
+	if err != nil || !isDeleted {
+		return err
+	}
+
+	// * A completion that failed after the copy may have left the public file
+	return c.storage.Delete(ctx, upload.FileKey)
+}
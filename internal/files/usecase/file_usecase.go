@@ -2,63 +2,64 @@ package usecase
 
 import (
 	"context"
-	"fmt"
+	"io"
 	"mime/multipart"
-	"os"
+	"net/http"
 	"ps-gogo-manajer/internal/files/dto"
+	"ps-gogo-manajer/internal/files/repository"
+	"ps-gogo-manajer/internal/files/storage"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"strings"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 type FileUsecase struct {
-	S3Client *s3.Client
+	fileRepo repository.FileRepository
+	storage  storage.Storage
+	log      *logrus.Logger
 }
 
 const (
 	JPEG = "image/jpeg"
 	JPG  = "image/jpg"
 	PNG  = "image/png"
+	PDF  = "application/pdf"
 )
 
-var (
-	AWS_S3_REGION      = os.Getenv("S3_REGION")
-	AWS_S3_ID          = os.Getenv("S3_ID")
-	AWS_S3_SECRET_KEY  = os.Getenv("S3_SECRET_KEY")
-	AWS_S3_BUCKET_NAME = os.Getenv("S3_BUCKET_NAME")
-	nameType           = map[string]string{
-		JPEG: ".jpeg",
-		JPG:  ".jpg",
-		PNG:  ".png",
-	}
-)
+var nameType = map[string]string{
+	JPEG: ".jpeg",
+	JPG:  ".jpg",
+	PNG:  ".png",
+	PDF:  ".pdf",
+}
 
-func NewFileUseCase(client *s3.Client) *FileUsecase {
+func NewFileUseCase(fileRepo repository.FileRepository, storage storage.Storage, log *logrus.Logger) *FileUsecase {
 	return &FileUsecase{
-		S3Client: client,
+		fileRepo: fileRepo,
+		storage:  storage,
+		log:      log,
 	}
 }
 
-func (c *FileUsecase) UploadFile(file multipart.File, fileType string) (*dto.FileUploadResponse, error) {
+func (c *FileUsecase) UploadFile(ctx context.Context, file multipart.File, fileType string) (*dto.FileUploadResponse, error) {
 	var response dto.FileUploadResponse
 	defer file.Close()
 
 	filename := c.generateFilename(fileType)
-	_, err := c.S3Client.PutObject(context.TODO(), &s3.PutObjectInput{
-		Bucket: aws.String(AWS_S3_BUCKET_NAME),
-		Key:    aws.String(filename),
-		ACL:    types.ObjectCannedACLPublicRead,
-		Body:   file,
+	err := c.storage.Put(ctx, &storage.Object{
+		Key:         filename,
+		ContentType: fileType,
+		Body:        file,
+		IsPublic:    true,
 	})
-
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to upload file")
+		return nil, err
 	}
 
-	response.FileUrl = c.generateFileUrl(filename)
+	response.FileUrl = c.storage.URL(filename)
 	return &response, nil
 }
 
@@ -67,11 +68,87 @@ func (c *FileUsecase) generateFilename(fileType string) string {
 	return uuid.New().String() + postfix
 }
 
-func (c *FileUsecase) generateFileUrl(filename string) string {
-	return fmt.Sprintf(
-		"https://%s.s3.%s.amazonaws.com/%s",
-		AWS_S3_BUCKET_NAME,
-		AWS_S3_REGION,
-		filename,
-	)
+// UploadPrivateFile stores the file under the given prefix without a public
+// ACL and returns its key. Private files are only served through the API.
+func (c *FileUsecase) UploadPrivateFile(ctx context.Context, file multipart.File, fileType string, prefix string) (string, error) {
+	defer file.Close()
+
+	return c.UploadPrivateContent(ctx, file, fileType, prefix)
+}
+
+// UploadPrivateContent is UploadPrivateFile for content produced in-process,
+// such as generated documents.
+func (c *FileUsecase) UploadPrivateContent(ctx context.Context, body io.Reader, fileType string, prefix string) (string, error) {
+	key := prefix + c.generateFilename(fileType)
+	err := c.storage.Put(ctx, &storage.Object{
+		Key:         key,
+		ContentType: fileType,
+		Body:        body,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (c *FileUsecase) DownloadFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	content, err := c.storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "file not found")
+		}
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func (c *FileUsecase) DeleteFile(ctx context.Context, key string) error {
+	return c.storage.Delete(ctx, key)
+}
+
+// PublicKey returns the key of a public file from its URL, when the URL is
+// one of ours. Public files are stored without a prefix, so a key with one,
+// a private or staged file, is never taken for a public one.
+func (c *FileUsecase) PublicKey(uri string) (string, bool) {
+	key, ok := strings.CutPrefix(uri, c.storage.URL(""))
+	if !ok || key == "" || strings.ContainsAny(key, "/\\?#") {
+		return "", false
+	}
+
+	return key, true
+}
+
+// GetPublicFilePath returns where a public file of the local storage is, to
+// serve it through the API. Other storages serve their public files
+// themselves, so nothing is found for them.
+func (c *FileUsecase) GetPublicFilePath(key string) (string, error) {
+	local, ok := c.storage.(*storage.Local)
+	if !ok {
+		return "", errors.Wrap(customErrors.ErrNotFound, "file not found")
+	}
+
+	filePath, err := local.PublicPath(key)
+	if err != nil {
+		return "", errors.Wrap(customErrors.ErrNotFound, "file not found")
+	}
+
+	return filePath, nil
+}
+
+// DetectFileType sniffs the content type from the first bytes of the file and
+// rewinds it for the upload.
+func DetectFileType(file multipart.File) (string, error) {
+	buffer := make([]byte, 512)
+	n, err := file.Read(buffer)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return http.DetectContentType(buffer[:n]), nil
 }
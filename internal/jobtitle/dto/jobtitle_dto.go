@@ -0,0 +1,59 @@
+package dto
+
+import "time"
+
+// JobTitle is an entry of the job title catalog. The same name may exist at
+// several levels, each with its own grade and monthly base salary range.
+type JobTitle struct {
+	JobTitleId    string    `json:"jobTitleId"`
+	Name          string    `json:"name"`
+	Level         int       `json:"level"`
+	Grade         string    `json:"grade"`
+	SalaryMin     *int64    `json:"salaryMin"`
+	SalaryMax     *int64    `json:"salaryMax"`
+	EmployeeCount int       `json:"employeeCount"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type CreateJobTitlePayload struct {
+	Name      string `json:"name" validate:"required,min=1,max=255"`
+	Level     int    `json:"level" validate:"omitempty,min=1,max=100"`
+	Grade     string `json:"grade" validate:"omitempty,max=33"`
+	SalaryMin *int64 `json:"salaryMin" validate:"omitempty,min=0"`
+	SalaryMax *int64 `json:"salaryMax" validate:"omitempty,min=0"`
+}
+
+type PatchJobTitlePayload struct {
+	Name      *string `json:"name" validate:"omitempty,min=1,max=255"`
+	Level     *int    `json:"level" validate:"omitempty,min=1,max=100"`
+	Grade     *string `json:"grade" validate:"omitempty,max=33"`
+	SalaryMin *int64  `json:"salaryMin" validate:"omitempty,min=0"`
+	SalaryMax *int64  `json:"salaryMax" validate:"omitempty,min=0"`
+}
+
+type GetJobTitleParams struct {
+	Name  string `query:"name"`
+	Grade string `query:"grade"`
+}
+
+type JobTitlePathParam struct {
+	JobTitleId int `param:"jobTitleId" validate:"required,min=1"`
+}
+
+// GetJobTitleEmployeesParams leaves out terminated employees unless
+// IncludeTerminated is set.
+type GetJobTitleEmployeesParams struct {
+	JobTitleId        int `param:"jobTitleId" validate:"required,min=1"`
+	Limit             int
+	Offset            int
+	IncludeTerminated bool `query:"includeTerminated"`
+}
+
+type JobTitleEmployee struct {
+	IdentityNumber string `json:"identityNumber"`
+	Name           string `json:"name"`
+	DepartmentId   string `json:"departmentId"`
+	DepartmentName string `json:"departmentName"`
+	Status         string `json:"status"`
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/jobtitle/dto"
+	"ps-gogo-manajer/internal/jobtitle/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	customValidators "ps-gogo-manajer/pkg/custom-validators"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type JobTitleHandler struct {
+	jobTitleUsecase usecase.JobTitleUsecase
+	validator       *validator.Validate
+}
+
+const (
+	DEFAULT_LIMIT  = 5
+	DEFAULT_OFFSET = 0
+)
+
+func NewJobTitleHandler(jobTitleUsecase usecase.JobTitleUsecase, validator *validator.Validate) *JobTitleHandler {
+	return &JobTitleHandler{
+		jobTitleUsecase: jobTitleUsecase,
+		validator:       validator,
+	}
+}
+
+// bind binds the request into payload and validates it, wrapping any failure
+// as a bad request.
+func (h JobTitleHandler) bind(ctx echo.Context, payload any) error {
+	if err := ctx.Bind(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (h JobTitleHandler) bindPathParam(ctx echo.Context) (*dto.JobTitlePathParam, error) {
+	var pathParam dto.JobTitlePathParam
+	if err := (&echo.DefaultBinder{}).BindPathParams(ctx, &pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return &pathParam, nil
+}
+
+func (h JobTitleHandler) CreateJobTitle(ctx echo.Context) error {
+	var payload dto.CreateJobTitlePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	jobTitle, err := h.jobTitleUsecase.CreateJobTitle(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, jobTitle)
+}
+
+func (h JobTitleHandler) GetListJobTitle(ctx echo.Context) error {
+	var params dto.GetJobTitleParams
+	if err := h.bind(ctx, &params); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	jobTitles, err := h.jobTitleUsecase.GetListJobTitle(ctx.Request().Context(), userData.Id, &params)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, jobTitles)
+}
+
+func (h JobTitleHandler) GetJobTitle(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	jobTitle, err := h.jobTitleUsecase.GetJobTitle(ctx.Request().Context(), userData.Id, pathParam.JobTitleId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, jobTitle)
+}
+
+func (h JobTitleHandler) UpdateJobTitle(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.PatchJobTitlePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	jobTitle, err := h.jobTitleUsecase.UpdateJobTitle(ctx.Request().Context(), userData.Id, pathParam.JobTitleId, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, jobTitle)
+}
+
+func (h JobTitleHandler) DeleteJobTitle(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	if err := h.jobTitleUsecase.DeleteJobTitle(ctx.Request().Context(), userData.Id, pathParam.JobTitleId); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h JobTitleHandler) GetJobTitleEmployees(ctx echo.Context) error {
+	params := dto.GetJobTitleEmployeesParams{
+		Limit:  customValidators.ParseLimitOffset(ctx.QueryParam("limit"), DEFAULT_LIMIT),
+		Offset: customValidators.ParseLimitOffset(ctx.QueryParam("offset"), DEFAULT_OFFSET),
+	}
+	if err := h.bind(ctx, &params); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	employees, err := h.jobTitleUsecase.GetJobTitleEmployees(ctx.Request().Context(), userData.Id, &params)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, employees)
+}
@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/jobtitle/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type JobTitleRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewJobTitleRepository(pool *pgxpool.Pool) *JobTitleRepository {
+	return &JobTitleRepository{pool: pool}
+}
+
+const (
+	jobTitleColumns = `
+		job_titles.id,
+		job_titles.name,
+		job_titles.level,
+		job_titles.grade,
+		job_titles.salary_min,
+		job_titles.salary_max,
+		(
+			SELECT COUNT(*)
+			FROM employees
+			WHERE
+				employees.job_title_id = job_titles.id
+				AND employees.deleted_at IS NULL
+				AND employees.status <> 'terminated'
+		),
+		job_titles.created_at,
+		job_titles.updated_at`
+
+	queryCreateJobTitle = `
+	INSERT INTO job_titles(user_id, name, level, grade, salary_min, salary_max)
+	VALUES (@userID, @name, @level, NULLIF(@grade, ''), @salaryMin, @salaryMax)
+	RETURNING` + jobTitleColumns + `;`
+	queryGetJobTitle = `
+	SELECT` + jobTitleColumns + `
+	FROM job_titles
+	WHERE
+		user_id = @userID
+		AND id = @jobTitleID;`
+	queryGetListJobTitle = `
+	SELECT` + jobTitleColumns + `
+	FROM job_titles
+	WHERE
+		user_id = @userID
+		AND (NULLIF(@name, '') IS NULL OR name ILIKE '%' || NULLIF(@name, '') || '%')
+		AND (NULLIF(@grade, '') IS NULL OR grade = NULLIF(@grade, ''))
+	ORDER BY name, level, id;`
+	queryUpdateJobTitle = `
+	UPDATE job_titles
+	SET
+		name = COALESCE(@name, name),
+		level = COALESCE(@level, level),
+		grade = COALESCE(@grade, grade),
+		salary_min = COALESCE(@salaryMin, salary_min),
+		salary_max = COALESCE(@salaryMax, salary_max),
+		updated_at = NOW()
+	WHERE
+		user_id = @userID
+		AND id = @jobTitleID
+	RETURNING` + jobTitleColumns + `;`
+	// Employees keep the name of their title in job_title, so a rename
+	// carries over to them.
+	queryRenameEmployeeJobTitle = `
+	UPDATE employees
+	SET job_title = @name
+	WHERE
+		user_id = @userID
+		AND job_title_id = @jobTitleID;`
+	queryDeleteJobTitle = `
+	DELETE FROM job_titles
+	WHERE
+		user_id = @userID
+		AND id = @jobTitleID;`
+	queryCheckIfJobTitleExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM job_titles
+		WHERE
+			user_id = @userID
+			AND name = @name
+			AND level = @level
+			AND id <> @jobTitleID
+	) is_exists;`
+	queryCountEmployeesOutsideRange = `
+	SELECT COUNT(*)
+	FROM employees
+	JOIN salary_structures ON salary_structures.employee_id = employees.id
+	WHERE
+		employees.user_id = @userID
+		AND employees.job_title_id = @jobTitleID
+		AND employees.deleted_at IS NULL
+		AND (
+			salary_structures.base_salary < @salaryMin::bigint
+			OR salary_structures.base_salary > @salaryMax::bigint
+		);`
+	queryGetJobTitleEmployees = `
+	SELECT
+		employees.identity_number,
+		employees.name,
+		employees.department_id::text,
+		departments.name,
+		employees.status
+	FROM employees
+	JOIN departments ON departments.id = employees.department_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.job_title_id = @jobTitleID
+		AND employees.deleted_at IS NULL
+		AND (@includeTerminated::boolean OR employees.status <> 'terminated')
+	ORDER BY employees.name, employees.id
+	OFFSET @offset
+	LIMIT @limit;`
+)
+
+func scanJobTitle(row pgx.Row) (*dto.JobTitle, error) {
+	var jobTitle dto.JobTitle
+	grade := new(pgtype.Text)
+
+	err := row.Scan(
+		&jobTitle.JobTitleId,
+		&jobTitle.Name,
+		&jobTitle.Level,
+		grade,
+		&jobTitle.SalaryMin,
+		&jobTitle.SalaryMax,
+		&jobTitle.EmployeeCount,
+		&jobTitle.CreatedAt,
+		&jobTitle.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	jobTitle.Grade = grade.String
+	return &jobTitle, nil
+}
+
+func (r *JobTitleRepository) CreateJobTitle(ctx context.Context, userID int, payload *dto.CreateJobTitlePayload) (*dto.JobTitle, error) {
+	args := pgx.NamedArgs{
+		"userID":    userID,
+		"name":      payload.Name,
+		"level":     payload.Level,
+		"grade":     payload.Grade,
+		"salaryMin": payload.SalaryMin,
+		"salaryMax": payload.SalaryMax,
+	}
+
+	jobTitle, err := scanJobTitle(r.pool.QueryRow(ctx, queryCreateJobTitle, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create job title")
+	}
+
+	return jobTitle, nil
+}
+
+func (r *JobTitleRepository) GetJobTitle(ctx context.Context, userID int, jobTitleID int) (*dto.JobTitle, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"jobTitleID": jobTitleID,
+	}
+
+	jobTitle, err := scanJobTitle(r.pool.QueryRow(ctx, queryGetJobTitle, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get job title")
+	}
+
+	return jobTitle, nil
+}
+
+func (r *JobTitleRepository) GetListJobTitle(ctx context.Context, userID int, params *dto.GetJobTitleParams) ([]dto.JobTitle, error) {
+	jobTitles := make([]dto.JobTitle, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"name":   params.Name,
+		"grade":  params.Grade,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListJobTitle, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list job title")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		jobTitle, err := scanJobTitle(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		jobTitles = append(jobTitles, *jobTitle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get list job title")
+	}
+
+	return jobTitles, nil
+}
+
+// UpdateJobTitle changes the catalog entry and, on a rename, the job title of
+// the employees holding it.
+func (r *JobTitleRepository) UpdateJobTitle(ctx context.Context, userID int, jobTitleID int, payload *dto.PatchJobTitlePayload) (*dto.JobTitle, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"jobTitleID": jobTitleID,
+		"name":       payload.Name,
+		"level":      payload.Level,
+		"grade":      payload.Grade,
+		"salaryMin":  payload.SalaryMin,
+		"salaryMax":  payload.SalaryMax,
+	}
+
+	jobTitle, err := scanJobTitle(tx.QueryRow(ctx, queryUpdateJobTitle, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update job title")
+	}
+
+	if payload.Name != nil {
+		if _, err := tx.Exec(ctx, queryRenameEmployeeJobTitle, args); err != nil {
+			return nil, errors.Wrap(err, "failed to rename employee job title")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit job title update")
+	}
+
+	return jobTitle, nil
+}
+
+func (r *JobTitleRepository) DeleteJobTitle(ctx context.Context, userID int, jobTitleID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"jobTitleID": jobTitleID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeleteJobTitle, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete job title")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// CheckIfJobTitleExists reports whether another entry than jobTitleID has the
+// name at the level.
+func (r *JobTitleRepository) CheckIfJobTitleExists(ctx context.Context, userID int, name string, level int, jobTitleID int) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"name":       name,
+		"level":      level,
+		"jobTitleID": jobTitleID,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCheckIfJobTitleExists, args).Scan(&isExists); err != nil {
+		return false, errors.Wrap(err, "failed to check is job title exists")
+	}
+
+	return isExists, nil
+}
+
+// CountEmployeesOutsideRange counts the employees holding the title whose
+// base salary falls outside the range. A nil bound is left open.
+func (r *JobTitleRepository) CountEmployeesOutsideRange(ctx context.Context, userID int, jobTitleID int, salaryMin *int64, salaryMax *int64) (int, error) {
+	var count int
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"jobTitleID": jobTitleID,
+		"salaryMin":  salaryMin,
+		"salaryMax":  salaryMax,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCountEmployeesOutsideRange, args).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to check employee salaries")
+	}
+
+	return count, nil
+}
+
+func (r *JobTitleRepository) GetJobTitleEmployees(ctx context.Context, userID int, params *dto.GetJobTitleEmployeesParams) ([]dto.JobTitleEmployee, error) {
+	employees := make([]dto.JobTitleEmployee, 0)
+	args := pgx.NamedArgs{
+		"userID":            userID,
+		"jobTitleID":        params.JobTitleId,
+		"includeTerminated": params.IncludeTerminated,
+		"limit":             params.Limit,
+		"offset":            params.Offset,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetJobTitleEmployees, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get job title employees")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var employee dto.JobTitleEmployee
+		err := rows.Scan(
+			&employee.IdentityNumber,
+			&employee.Name,
+			&employee.DepartmentId,
+			&employee.DepartmentName,
+			&employee.Status,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		employees = append(employees, employee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get job title employees")
+	}
+
+	return employees, nil
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/jobtitle/dto"
+	"ps-gogo-manajer/internal/jobtitle/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+
+	"github.com/pkg/errors"
+)
+
+const DEFAULT_LEVEL = 1
+
+type JobTitleUsecase struct {
+	jobTitleRepo repository.JobTitleRepository
+}
+
+func NewJobTitleUsecase(jobTitleRepo repository.JobTitleRepository) *JobTitleUsecase {
+	return &JobTitleUsecase{
+		jobTitleRepo: jobTitleRepo,
+	}
+}
+
+func (u *JobTitleUsecase) CreateJobTitle(ctx context.Context, userID int, payload *dto.CreateJobTitlePayload) (*dto.JobTitle, error) {
+	if payload.Level == 0 {
+		payload.Level = DEFAULT_LEVEL
+	}
+
+	if err := validateSalaryRange(payload.SalaryMin, payload.SalaryMax); err != nil {
+		return nil, err
+	}
+
+	if err := u.validateUnique(ctx, userID, payload.Name, payload.Level, 0); err != nil {
+		return nil, err
+	}
+
+	return u.jobTitleRepo.CreateJobTitle(ctx, userID, payload)
+}
+
+func (u *JobTitleUsecase) GetListJobTitle(ctx context.Context, userID int, params *dto.GetJobTitleParams) ([]dto.JobTitle, error) {
+	return u.jobTitleRepo.GetListJobTitle(ctx, userID, params)
+}
+
+func (u *JobTitleUsecase) GetJobTitle(ctx context.Context, userID int, jobTitleID int) (*dto.JobTitle, error) {
+	jobTitle, err := u.jobTitleRepo.GetJobTitle(ctx, userID, jobTitleID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "job title not found")
+		}
+		return nil, err
+	}
+
+	return jobTitle, nil
+}
+
+// UpdateJobTitle refuses a salary range that would leave employees holding
+// the title with a base salary outside of it.
+func (u *JobTitleUsecase) UpdateJobTitle(ctx context.Context, userID int, jobTitleID int, payload *dto.PatchJobTitlePayload) (*dto.JobTitle, error) {
+	jobTitle, err := u.GetJobTitle(ctx, userID, jobTitleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// * Checks run against the entry as it will be stored
+	name, level := jobTitle.Name, jobTitle.Level
+	if payload.Name != nil {
+		name = *payload.Name
+	}
+	if payload.Level != nil {
+		level = *payload.Level
+	}
+	if name != jobTitle.Name || level != jobTitle.Level {
+		if err := u.validateUnique(ctx, userID, name, level, jobTitleID); err != nil {
+			return nil, err
+		}
+	}
+
+	salaryMin, salaryMax := jobTitle.SalaryMin, jobTitle.SalaryMax
+	if payload.SalaryMin != nil {
+		salaryMin = payload.SalaryMin
+	}
+	if payload.SalaryMax != nil {
+		salaryMax = payload.SalaryMax
+	}
+	if err := validateSalaryRange(salaryMin, salaryMax); err != nil {
+		return nil, err
+	}
+
+	if payload.SalaryMin != nil || payload.SalaryMax != nil {
+		count, err := u.jobTitleRepo.CountEmployeesOutsideRange(ctx, userID, jobTitleID, salaryMin, salaryMax)
+		if err != nil {
+			return nil, err
+		}
+
+		if count > 0 {
+			return nil, errors.Wrapf(customErrors.ErrConflict, "%d employees with this job title have a base salary outside the range", count)
+		}
+	}
+
+	jobTitle, err = u.jobTitleRepo.UpdateJobTitle(ctx, userID, jobTitleID, payload)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "job title not found")
+		}
+		return nil, err
+	}
+
+	return jobTitle, nil
+}
+
+// DeleteJobTitle removes a title nobody holds anymore. Terminated employees
+// keep the name of the title.
+func (u *JobTitleUsecase) DeleteJobTitle(ctx context.Context, userID int, jobTitleID int) error {
+	jobTitle, err := u.GetJobTitle(ctx, userID, jobTitleID)
+	if err != nil {
+		return err
+	}
+
+	if jobTitle.EmployeeCount > 0 {
+		return errors.Wrap(customErrors.ErrConflict, "still containing employee")
+	}
+
+	isDeleted, err := u.jobTitleRepo.DeleteJobTitle(ctx, userID, jobTitleID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return errors.Wrap(customErrors.ErrNotFound, "job title not found")
+	}
+
+	return nil
+}
+
+func (u *JobTitleUsecase) GetJobTitleEmployees(ctx context.Context, userID int, params *dto.GetJobTitleEmployeesParams) ([]dto.JobTitleEmployee, error) {
+	if _, err := u.GetJobTitle(ctx, userID, params.JobTitleId); err != nil {
+		return nil, err
+	}
+
+	return u.jobTitleRepo.GetJobTitleEmployees(ctx, userID, params)
+}
+
+func (u *JobTitleUsecase) validateUnique(ctx context.Context, userID int, name string, level int, jobTitleID int) error {
+	isExists, err := u.jobTitleRepo.CheckIfJobTitleExists(ctx, userID, name, level, jobTitleID)
+	if err != nil {
+		return err
+	}
+
+	if isExists {
+		return errors.Wrap(customErrors.ErrConflict, "job title already exists at this level")
+	}
+
+	return nil
+}
+
+func validateSalaryRange(salaryMin *int64, salaryMax *int64) error {
+	if salaryMin != nil && salaryMax != nil && *salaryMin > *salaryMax {
+		return errors.Wrap(customErrors.ErrBadRequest, "salaryMin must not be greater than salaryMax")
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package dto
+
+import "time"
+
+type Accrual string
+
+const (
+	AccrualYearly  Accrual = "yearly"
+	AccrualMonthly Accrual = "monthly"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+	StatusCancelled Status = "cancelled"
+)
+
+type LeaveType struct {
+	LeaveTypeId       string  `json:"leaveTypeId"`
+	Code              string  `json:"code"`
+	Name              string  `json:"name"`
+	IsPaid            bool    `json:"isPaid"`
+	RequiresBalance   bool    `json:"requiresBalance"`
+	YearlyEntitlement int     `json:"yearlyEntitlement"`
+	Accrual           Accrual `json:"accrual"`
+}
+
+type CreateLeaveTypePayload struct {
+	Code              string  `json:"code" validate:"required,max=32,fieldkey"`
+	Name              string  `json:"name" validate:"required,min=1,max=255"`
+	IsPaid            bool    `json:"isPaid"`
+	RequiresBalance   bool    `json:"requiresBalance"`
+	YearlyEntitlement int     `json:"yearlyEntitlement" validate:"min=0,max=366"`
+	Accrual           Accrual `json:"accrual" validate:"required,oneof=yearly monthly"`
+}
+
+type PatchLeaveTypePayload struct {
+	Name              *string  `json:"name" validate:"omitempty,min=1,max=255"`
+	IsPaid            *bool    `json:"isPaid"`
+	RequiresBalance   *bool    `json:"requiresBalance"`
+	YearlyEntitlement *int     `json:"yearlyEntitlement" validate:"omitempty,min=0,max=366"`
+	Accrual           *Accrual `json:"accrual" validate:"omitempty,oneof=yearly monthly"`
+}
+
+type LeaveTypePathParam struct {
+	LeaveTypeId int `param:"leaveTypeId" validate:"required,min=1"`
+}
+
+type Holiday struct {
+	HolidayId string `json:"holidayId"`
+	Date      string `json:"date"`
+	Name      string `json:"name"`
+}
+
+type CreateHolidayPayload struct {
+	Date string `json:"date" validate:"required,datetime=2006-01-02"`
+	Name string `json:"name" validate:"required,min=1,max=255"`
+}
+
+type GetHolidayParams struct {
+	Year int `query:"year" validate:"omitempty,min=1900,max=2200"`
+}
+
+type HolidayPathParam struct {
+	HolidayId int `param:"holidayId" validate:"required,min=1"`
+}
+
+type SetEntitlementPayload struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	LeaveTypeId    int    `json:"leaveTypeId" validate:"required,min=1"`
+	Year           int    `json:"year" validate:"required,min=1900,max=2200"`
+	Days           int    `json:"days" validate:"min=0,max=366"`
+}
+
+type Entitlement struct {
+	LeaveTypeId string `json:"leaveTypeId"`
+	Year        int    `json:"year"`
+	Days        int    `json:"days"`
+}
+
+// LeaveBalance is the balance of one leave type for one year. Accrued is the
+// part of the entitlement earned so far, Available what is left of it after
+// approved and pending requests.
+type LeaveBalance struct {
+	LeaveTypeId     string  `json:"leaveTypeId"`
+	Code            string  `json:"code"`
+	Name            string  `json:"name"`
+	RequiresBalance bool    `json:"requiresBalance"`
+	Year            int     `json:"year"`
+	Entitlement     int     `json:"entitlement"`
+	Accrued         float64 `json:"accrued"`
+	Used            int     `json:"used"`
+	Pending         int     `json:"pending"`
+	Available       float64 `json:"available"`
+	Accrual         Accrual `json:"-"`
+}
+
+type GetLeaveBalanceParams struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	Year           int    `query:"year" validate:"omitempty,min=1900,max=2200"`
+}
+
+// LeaveRequest is a request for leave. IsOwnerOverride is set when the
+// account owner reviewed it in place of the employee's manager.
+type LeaveRequest struct {
+	LeaveRequestId         string     `json:"leaveRequestId"`
+	EmployeeIdentityNumber string     `json:"employeeIdentityNumber"`
+	EmployeeName           string     `json:"employeeName"`
+	LeaveTypeId            string     `json:"leaveTypeId"`
+	LeaveTypeName          string     `json:"leaveTypeName"`
+	StartDate              string     `json:"startDate"`
+	EndDate                string     `json:"endDate"`
+	Days                   int        `json:"days"`
+	Reason                 string     `json:"reason"`
+	Status                 Status     `json:"status"`
+	ApproverIdentityNumber string     `json:"approverIdentityNumber"`
+	ReviewedBy             string     `json:"reviewedBy"`
+	IsOwnerOverride        bool       `json:"isOwnerOverride"`
+	ReviewNote             string     `json:"reviewNote"`
+	ReviewedAt             *time.Time `json:"reviewedAt"`
+	CreatedAt              time.Time  `json:"createdAt"`
+}
+
+type CreateLeaveRequestPayload struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	LeaveTypeId    int    `json:"leaveTypeId" validate:"required,min=1"`
+	StartDate      string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate        string `json:"endDate" validate:"required,datetime=2006-01-02"`
+	Reason         string `json:"reason" validate:"omitempty,max=500"`
+	Days           int    `json:"-"`
+}
+
+type GetLeaveRequestParams struct {
+	IdentityNumber string `param:"identityNumber"`
+	Status         string `query:"status" validate:"omitempty,oneof=pending approved rejected cancelled"`
+	Limit          int
+	Offset         int
+}
+
+type LeaveRequestPathParam struct {
+	LeaveRequestId int `param:"leaveRequestId" validate:"required,min=1"`
+}
+
+// ReviewLeavePayload approves or rejects a request. A manager login reviews
+// as itself and has to be one of the employee's managers. The account owner
+// names the manager it reviews for in ApproverIdentityNumber, which may only
+// be left out for employees without a manager.
+type ReviewLeavePayload struct {
+	LeaveRequestId         int    `param:"leaveRequestId" validate:"required,min=1"`
+	ApproverIdentityNumber string `json:"approverIdentityNumber" validate:"omitempty,min=5,max=33"`
+	Note                   string `json:"note" validate:"omitempty,max=500"`
+	ApproverId             int64  `json:"-"`
+	IsOwnerOverride        bool   `json:"-"`
+}
+
+type GetTeamCalendarParams struct {
+	DepartmentId   int    `param:"departmentId" validate:"required,min=1"`
+	From           string `query:"from" validate:"required,datetime=2006-01-02"`
+	To             string `query:"to" validate:"required,datetime=2006-01-02"`
+	IncludePending bool   `query:"includePending"`
+}
+
+type TeamCalendar struct {
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Holidays []Holiday      `json:"holidays"`
+	Leaves   []LeaveRequest `json:"leaves"`
+}
@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/leave/dto"
+	"ps-gogo-manajer/internal/leave/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	customValidators "ps-gogo-manajer/pkg/custom-validators"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type LeaveHandler struct {
+	leaveUsecase usecase.LeaveUsecase
+	validator    *validator.Validate
+}
+
+const (
+	DEFAULT_LIMIT  = 5
+	DEFAULT_OFFSET = 0
+)
+
+func NewLeaveHandler(leaveUsecase usecase.LeaveUsecase, validator *validator.Validate) *LeaveHandler {
+	return &LeaveHandler{
+		leaveUsecase: leaveUsecase,
+		validator:    validator,
+	}
+}
+
+// bind binds the request into payload and validates it, wrapping any failure
+// as a bad request.
+func (h LeaveHandler) bind(ctx echo.Context, payload any) error {
+	if err := ctx.Bind(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (h LeaveHandler) CreateLeaveType(ctx echo.Context) error {
+	var payload dto.CreateLeaveTypePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	leaveType, err := h.leaveUsecase.CreateLeaveType(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, leaveType)
+}
+
+func (h LeaveHandler) GetListLeaveType(ctx echo.Context) error {
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	leaveTypes, err := h.leaveUsecase.GetListLeaveType(ctx.Request().Context(), userData.Id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, leaveTypes)
+}
+
+func (h LeaveHandler) UpdateLeaveType(ctx echo.Context) error {
+	var pathParam dto.LeaveTypePathParam
+	if err := (&echo.DefaultBinder{}).BindPathParams(ctx, &pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.PatchLeaveTypePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	leaveType, err := h.leaveUsecase.UpdateLeaveType(ctx.Request().Context(), userData.Id, pathParam.LeaveTypeId, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, leaveType)
+}
+
+func (h LeaveHandler) DeleteLeaveType(ctx echo.Context) error {
+	var pathParam dto.LeaveTypePathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	err := h.leaveUsecase.DeleteLeaveType(ctx.Request().Context(), userData.Id, pathParam.LeaveTypeId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h LeaveHandler) CreateHoliday(ctx echo.Context) error {
+	var payload dto.CreateHolidayPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	holiday, err := h.leaveUsecase.CreateHoliday(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, holiday)
+}
+
+func (h LeaveHandler) GetListHoliday(ctx echo.Context) error {
+	var payload dto.GetHolidayParams
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	holidays, err := h.leaveUsecase.GetListHoliday(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, holidays)
+}
+
+func (h LeaveHandler) DeleteHoliday(ctx echo.Context) error {
+	var pathParam dto.HolidayPathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	err := h.leaveUsecase.DeleteHoliday(ctx.Request().Context(), userData.Id, pathParam.HolidayId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h LeaveHandler) SetEntitlement(ctx echo.Context) error {
+	var payload dto.SetEntitlementPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	entitlement, err := h.leaveUsecase.SetEntitlement(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, entitlement)
+}
+
+func (h LeaveHandler) GetLeaveBalance(ctx echo.Context) error {
+	var payload dto.GetLeaveBalanceParams
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	balances, err := h.leaveUsecase.GetLeaveBalance(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, balances)
+}
+
+func (h LeaveHandler) CreateLeaveRequest(ctx echo.Context) error {
+	var payload dto.CreateLeaveRequestPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	request, err := h.leaveUsecase.CreateLeaveRequest(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, request)
+}
+
+// GetListLeaveRequest lists the requests of one employee when mounted under
+// an employee, or of the whole organization otherwise.
+func (h LeaveHandler) GetListLeaveRequest(ctx echo.Context) error {
+	limitStr := ctx.QueryParam("limit")
+	offsetStr := ctx.QueryParam("offset")
+
+	payload := dto.GetLeaveRequestParams{
+		Limit:  customValidators.ParseLimitOffset(limitStr, DEFAULT_LIMIT),
+		Offset: customValidators.ParseLimitOffset(offsetStr, DEFAULT_OFFSET),
+	}
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	requests, err := h.leaveUsecase.GetListLeaveRequest(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, requests)
+}
+
+func (h LeaveHandler) ApproveLeaveRequest(ctx echo.Context) error {
+	var payload dto.ReviewLeavePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	request, err := h.leaveUsecase.ApproveLeaveRequest(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, request)
+}
+
+func (h LeaveHandler) RejectLeaveRequest(ctx echo.Context) error {
+	var payload dto.ReviewLeavePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	request, err := h.leaveUsecase.RejectLeaveRequest(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, request)
+}
+
+func (h LeaveHandler) CancelLeaveRequest(ctx echo.Context) error {
+	var pathParam dto.LeaveRequestPathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	request, err := h.leaveUsecase.CancelLeaveRequest(ctx.Request().Context(), userData.Id, pathParam.LeaveRequestId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, request)
+}
+
+func (h LeaveHandler) GetTeamCalendar(ctx echo.Context) error {
+	var payload dto.GetTeamCalendarParams
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	calendar, err := h.leaveUsecase.GetTeamCalendar(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, calendar)
+}
@@ -0,0 +1,913 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/leave/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// ErrLeaveOverlaps is returned by CreateLeaveRequest when the employee has
+// another pending or approved request on any of the dates.
+var ErrLeaveOverlaps = errors.New("leave overlaps with another request")
+
+// exclusionViolation is raised by leave_requests_no_overlap.
+const exclusionViolation = "23P01"
+
+// BalanceValidator checks a request against the leave balance of the
+// employee, read while the balance is locked. The balance is nil when the
+// leave type does not exist.
+type BalanceValidator func(balance *dto.LeaveBalance) error
+
+type LeaveRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewLeaveRepository(pool *pgxpool.Pool) *LeaveRepository {
+	return &LeaveRepository{pool: pool}
+}
+
+const (
+	leaveTypeColumns = `
+		id,
+		code,
+		name,
+		is_paid,
+		requires_balance,
+		yearly_entitlement,
+		accrual`
+	// leaveRequestColumns is the column list scanned by scanLeaveRequest,
+	// selected from leaveRequestFrom.
+	leaveRequestColumns = `
+		leave_requests.id,
+		employees.identity_number,
+		employees.name,
+		leave_types.id,
+		leave_types.name,
+		leave_requests.start_date::text,
+		leave_requests.end_date::text,
+		leave_requests.days,
+		leave_requests.reason,
+		leave_requests.status,
+		approver.identity_number,
+		users.email,
+		leave_requests.is_owner_override,
+		leave_requests.review_note,
+		leave_requests.reviewed_at,
+		leave_requests.created_at`
+	leaveRequestFrom = `
+	FROM leave_requests
+	JOIN employees ON employees.id = leave_requests.employee_id
+	JOIN leave_types ON leave_types.id = leave_requests.leave_type_id
+	LEFT JOIN employees approver ON approver.id = leave_requests.approver_id
+	LEFT JOIN users ON users.id = leave_requests.reviewed_by`
+
+	queryCheckIfEmployeeExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+	) is_exists;`
+	queryCheckIfDepartmentExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM departments
+		WHERE
+			user_id = @userID
+			AND id = @departmentID
+			AND deleted_at IS NULL
+	) is_exists;`
+
+	queryCheckIfLeaveTypeCodeExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM leave_types
+		WHERE
+			user_id = @userID
+			AND code = @code
+	) is_exists;`
+	queryCreateLeaveType = `
+	INSERT INTO leave_types(user_id, code, name, is_paid, requires_balance, yearly_entitlement, accrual)
+	VALUES (@userID, @code, @name, @isPaid, @requiresBalance, @yearlyEntitlement, @accrual)
+	RETURNING` + leaveTypeColumns + `;`
+	queryGetListLeaveType = `
+	SELECT` + leaveTypeColumns + `
+	FROM leave_types
+	WHERE user_id = @userID
+	ORDER BY id;`
+	queryGetLeaveType = `
+	SELECT` + leaveTypeColumns + `
+	FROM leave_types
+	WHERE
+		user_id = @userID
+		AND id = @leaveTypeID;`
+	queryUpdateLeaveType = `
+	UPDATE leave_types
+	SET
+		name = COALESCE(@name, name),
+		is_paid = COALESCE(@isPaid, is_paid),
+		requires_balance = COALESCE(@requiresBalance, requires_balance),
+		yearly_entitlement = COALESCE(@yearlyEntitlement, yearly_entitlement),
+		accrual = COALESCE(@accrual::enum_leave_accrual, accrual)
+	WHERE
+		user_id = @userID
+		AND id = @leaveTypeID
+	RETURNING` + leaveTypeColumns + `;`
+	queryCheckIfLeaveTypeInUse = `
+	SELECT EXISTS (
+		SELECT id
+		FROM leave_requests
+		WHERE leave_type_id = @leaveTypeID
+	) is_exists;`
+	queryDeleteLeaveType = `
+	DELETE FROM leave_types
+	WHERE
+		user_id = @userID
+		AND id = @leaveTypeID;`
+
+	queryCheckIfHolidayExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM holidays
+		WHERE
+			user_id = @userID
+			AND date = @date::date
+	) is_exists;`
+	queryCreateHoliday = `
+	INSERT INTO holidays(user_id, date, name)
+	VALUES (@userID, @date::date, @name)
+	RETURNING id, date::text, name;`
+	queryGetListHoliday = `
+	SELECT id, date::text, name
+	FROM holidays
+	WHERE
+		user_id = @userID
+		AND (NULLIF(@from, '') IS NULL OR date >= NULLIF(@from, '')::date)
+		AND (NULLIF(@to, '') IS NULL OR date <= NULLIF(@to, '')::date)
+	ORDER BY date;`
+	queryDeleteHoliday = `
+	DELETE FROM holidays
+	WHERE
+		user_id = @userID
+		AND id = @holidayID;`
+
+	querySetEntitlement = `
+	INSERT INTO leave_entitlements(employee_id, leave_type_id, year, days)
+	SELECT employees.id, leave_types.id, @year, @days
+	FROM employees
+	JOIN leave_types ON leave_types.user_id = employees.user_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL
+		AND leave_types.id = @leaveTypeID
+	ON CONFLICT (employee_id, leave_type_id, year) DO UPDATE SET days = EXCLUDED.days
+	RETURNING leave_type_id, year, days;`
+	// Requests are counted in the year they start in; the usecase does not
+	// accept requests that span two years.
+	queryGetLeaveBalance = `
+	WITH employee AS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+	)
+	SELECT
+		leave_types.id,
+		leave_types.code,
+		leave_types.name,
+		leave_types.requires_balance,
+		leave_types.accrual,
+		COALESCE(leave_entitlements.days, leave_types.yearly_entitlement),
+		COALESCE(SUM(leave_requests.days) FILTER (WHERE leave_requests.status = 'approved'), 0),
+		COALESCE(SUM(leave_requests.days) FILTER (WHERE leave_requests.status = 'pending'), 0)
+	FROM leave_types
+	CROSS JOIN employee
+	LEFT JOIN leave_entitlements ON
+		leave_entitlements.employee_id = employee.id
+		AND leave_entitlements.leave_type_id = leave_types.id
+		AND leave_entitlements.year = @year
+	LEFT JOIN leave_requests ON
+		leave_requests.employee_id = employee.id
+		AND leave_requests.leave_type_id = leave_types.id
+		AND EXTRACT(YEAR FROM leave_requests.start_date) = @year
+	WHERE
+		leave_types.user_id = @userID
+		AND (NULLIF(@leaveTypeID, 0) IS NULL OR leave_types.id = NULLIF(@leaveTypeID, 0)::bigint)
+	GROUP BY leave_types.id, leave_entitlements.days
+	ORDER BY leave_types.id;`
+
+	// The entitlement row is only an override and may not exist, so the
+	// employee row is what serializes the changes to a balance.
+	queryLockLeaveBalance = `
+	WITH employee AS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+		FOR UPDATE
+	),
+	entitlement AS (
+		SELECT leave_entitlements.id
+		FROM leave_entitlements
+		JOIN employee ON employee.id = leave_entitlements.employee_id
+		WHERE
+			leave_entitlements.leave_type_id = @leaveTypeID
+			AND leave_entitlements.year = @year
+		FOR UPDATE OF leave_entitlements
+	)
+	SELECT employee.id
+	FROM employee
+	LEFT JOIN entitlement ON TRUE;`
+	queryCheckIfLeaveOverlaps = `
+	SELECT EXISTS (
+		SELECT leave_requests.id
+		FROM leave_requests
+		JOIN employees ON employees.id = leave_requests.employee_id
+		WHERE
+			employees.user_id = @userID
+			AND employees.identity_number = @identityNumber
+			AND leave_requests.status IN ('pending', 'approved')
+			AND leave_requests.start_date <= @endDate::date
+			AND leave_requests.end_date >= @startDate::date
+	) is_exists;`
+	queryCreateLeaveRequest = `
+	INSERT INTO leave_requests(employee_id, leave_type_id, start_date, end_date, days, reason)
+	SELECT employees.id, @leaveTypeID, @startDate::date, @endDate::date, @days, NULLIF(@reason, '')
+	FROM employees
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL
+	RETURNING id;`
+	queryGetLeaveRequest = `
+	SELECT` + leaveRequestColumns + leaveRequestFrom + `
+	WHERE
+		employees.user_id = @userID
+		AND employees.deleted_at IS NULL
+		AND leave_requests.id = @leaveRequestID;`
+	queryGetListLeaveRequest = `
+	SELECT` + leaveRequestColumns + leaveRequestFrom + `
+	WHERE
+		employees.user_id = @userID
+		AND employees.deleted_at IS NULL
+		AND (NULLIF(@identityNumber, '') IS NULL OR employees.identity_number = NULLIF(@identityNumber, ''))
+		AND (NULLIF(@status, '') IS NULL OR leave_requests.status = NULLIF(@status, '')::enum_leave_status)
+	ORDER BY leave_requests.start_date DESC, leave_requests.id DESC
+	OFFSET @offset
+	LIMIT @limit;`
+	// The approver has to sit somewhere above the employee in the reporting
+	// line. The first row of the chain is the direct manager, if any.
+	queryCheckIfApprover = `
+	WITH RECURSIVE chain AS (
+		SELECT employees.manager_id id, ARRAY[employees.id] path
+		FROM leave_requests
+		JOIN employees ON employees.id = leave_requests.employee_id
+		WHERE
+			employees.user_id = @userID
+			AND leave_requests.id = @leaveRequestID
+		UNION ALL
+		SELECT employees.manager_id, chain.path || employees.id
+		FROM employees
+		JOIN chain ON employees.id = chain.id
+		WHERE NOT employees.id = ANY(chain.path)
+	)
+	SELECT
+		EXISTS (SELECT 1 FROM chain WHERE id IS NOT NULL),
+		EXISTS (
+			SELECT 1
+			FROM chain
+			JOIN employees ON employees.id = chain.id
+			WHERE
+				(
+					employees.id = NULLIF(@approverID::bigint, 0)
+					OR employees.identity_number = NULLIF(@approverIdentityNumber, '')
+				)
+				AND employees.deleted_at IS NULL
+		);`
+	queryReviewLeaveRequest = `
+	UPDATE leave_requests
+	SET
+		status = @status::enum_leave_status,
+		approver_id = COALESCE(
+			NULLIF(@approverID::bigint, 0),
+			(
+				SELECT id
+				FROM employees
+				WHERE
+					user_id = @userID
+					AND identity_number = NULLIF(@approverIdentityNumber, '')
+					AND deleted_at IS NULL
+			)
+		),
+		reviewed_by = @userID,
+		is_owner_override = @isOwnerOverride::boolean,
+		review_note = NULLIF(@note, ''),
+		reviewed_at = NOW()
+	FROM employees
+	WHERE
+		employees.id = leave_requests.employee_id
+		AND employees.user_id = @userID
+		AND leave_requests.id = @leaveRequestID
+		AND leave_requests.status = 'pending'
+	RETURNING leave_requests.id;`
+	queryCancelLeaveRequest = `
+	UPDATE leave_requests
+	SET status = 'cancelled'
+	FROM employees
+	WHERE
+		employees.id = leave_requests.employee_id
+		AND employees.user_id = @userID
+		AND leave_requests.id = @leaveRequestID
+		AND leave_requests.status = @status::enum_leave_status
+	RETURNING leave_requests.id;`
+	queryGetDepartmentLeave = `
+	SELECT` + leaveRequestColumns + leaveRequestFrom + `
+	WHERE
+		employees.user_id = @userID
+		AND employees.department_id = @departmentID
+		AND employees.deleted_at IS NULL
+		AND leave_requests.start_date <= @to::date
+		AND leave_requests.end_date >= @from::date
+		AND (
+			leave_requests.status = 'approved'
+			OR (@includePending::boolean AND leave_requests.status = 'pending')
+		)
+	ORDER BY leave_requests.start_date, employees.name;`
+)
+
+func scanLeaveType(row pgx.Row) (*dto.LeaveType, error) {
+	var leaveType dto.LeaveType
+	err := row.Scan(
+		&leaveType.LeaveTypeId,
+		&leaveType.Code,
+		&leaveType.Name,
+		&leaveType.IsPaid,
+		&leaveType.RequiresBalance,
+		&leaveType.YearlyEntitlement,
+		&leaveType.Accrual,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &leaveType, nil
+}
+
+func scanLeaveRequest(row pgx.Row) (*dto.LeaveRequest, error) {
+	var request dto.LeaveRequest
+	reason := new(pgtype.Text)
+	approver := new(pgtype.Text)
+	reviewedBy := new(pgtype.Text)
+	reviewNote := new(pgtype.Text)
+
+	err := row.Scan(
+		&request.LeaveRequestId,
+		&request.EmployeeIdentityNumber,
+		&request.EmployeeName,
+		&request.LeaveTypeId,
+		&request.LeaveTypeName,
+		&request.StartDate,
+		&request.EndDate,
+		&request.Days,
+		reason,
+		&request.Status,
+		approver,
+		reviewedBy,
+		&request.IsOwnerOverride,
+		reviewNote,
+		&request.ReviewedAt,
+		&request.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Reason = reason.String
+	request.ApproverIdentityNumber = approver.String
+	request.ReviewedBy = reviewedBy.String
+	request.ReviewNote = reviewNote.String
+	return &request, nil
+}
+
+func (r *LeaveRepository) checkIfExists(ctx context.Context, query string, args pgx.NamedArgs) (bool, error) {
+	var isExists bool
+	if err := r.pool.QueryRow(ctx, query, args).Scan(&isExists); err != nil {
+		return false, err
+	}
+
+	return isExists, nil
+}
+
+func (r *LeaveRepository) CheckIfEmployeeExists(ctx context.Context, userID int, identityNumber string) (bool, error) {
+	isExists, err := r.checkIfExists(ctx, queryCheckIfEmployeeExists, pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check employee")
+	}
+
+	return isExists, nil
+}
+
+func (r *LeaveRepository) CheckIfDepartmentExists(ctx context.Context, userID int, departmentID int) (bool, error) {
+	isExists, err := r.checkIfExists(ctx, queryCheckIfDepartmentExists, pgx.NamedArgs{
+		"userID":       userID,
+		"departmentID": departmentID,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check department")
+	}
+
+	return isExists, nil
+}
+
+func (r *LeaveRepository) CheckIfLeaveTypeCodeExists(ctx context.Context, userID int, code string) (bool, error) {
+	isExists, err := r.checkIfExists(ctx, queryCheckIfLeaveTypeCodeExists, pgx.NamedArgs{
+		"userID": userID,
+		"code":   code,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check leave type code")
+	}
+
+	return isExists, nil
+}
+
+func (r *LeaveRepository) CreateLeaveType(ctx context.Context, userID int, payload *dto.CreateLeaveTypePayload) (*dto.LeaveType, error) {
+	args := pgx.NamedArgs{
+		"userID":            userID,
+		"code":              payload.Code,
+		"name":              payload.Name,
+		"isPaid":            payload.IsPaid,
+		"requiresBalance":   payload.RequiresBalance,
+		"yearlyEntitlement": payload.YearlyEntitlement,
+		"accrual":           payload.Accrual,
+	}
+
+	leaveType, err := scanLeaveType(r.pool.QueryRow(ctx, queryCreateLeaveType, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create leave type")
+	}
+
+	return leaveType, nil
+}
+
+func (r *LeaveRepository) GetListLeaveType(ctx context.Context, userID int) ([]dto.LeaveType, error) {
+	leaveTypes := make([]dto.LeaveType, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListLeaveType, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list leave type")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		leaveType, err := scanLeaveType(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		leaveTypes = append(leaveTypes, *leaveType)
+	}
+
+	return leaveTypes, nil
+}
+
+func (r *LeaveRepository) GetLeaveType(ctx context.Context, userID int, leaveTypeID int) (*dto.LeaveType, error) {
+	args := pgx.NamedArgs{
+		"userID":      userID,
+		"leaveTypeID": leaveTypeID,
+	}
+
+	leaveType, err := scanLeaveType(r.pool.QueryRow(ctx, queryGetLeaveType, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get leave type")
+	}
+
+	return leaveType, nil
+}
+
+func (r *LeaveRepository) UpdateLeaveType(ctx context.Context, userID int, leaveTypeID int, payload *dto.PatchLeaveTypePayload) (*dto.LeaveType, error) {
+	args := pgx.NamedArgs{
+		"userID":            userID,
+		"leaveTypeID":       leaveTypeID,
+		"name":              payload.Name,
+		"isPaid":            payload.IsPaid,
+		"requiresBalance":   payload.RequiresBalance,
+		"yearlyEntitlement": payload.YearlyEntitlement,
+		"accrual":           payload.Accrual,
+	}
+
+	leaveType, err := scanLeaveType(r.pool.QueryRow(ctx, queryUpdateLeaveType, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update leave type")
+	}
+
+	return leaveType, nil
+}
+
+func (r *LeaveRepository) CheckIfLeaveTypeInUse(ctx context.Context, leaveTypeID int) (bool, error) {
+	isExists, err := r.checkIfExists(ctx, queryCheckIfLeaveTypeInUse, pgx.NamedArgs{
+		"leaveTypeID": leaveTypeID,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check leave type usage")
+	}
+
+	return isExists, nil
+}
+
+func (r *LeaveRepository) DeleteLeaveType(ctx context.Context, userID int, leaveTypeID int) error {
+	args := pgx.NamedArgs{
+		"userID":      userID,
+		"leaveTypeID": leaveTypeID,
+	}
+
+	if _, err := r.pool.Exec(ctx, queryDeleteLeaveType, args); err != nil {
+		return errors.Wrap(err, "failed to delete leave type")
+	}
+
+	return nil
+}
+
+func (r *LeaveRepository) CheckIfHolidayExists(ctx context.Context, userID int, date string) (bool, error) {
+	isExists, err := r.checkIfExists(ctx, queryCheckIfHolidayExists, pgx.NamedArgs{
+		"userID": userID,
+		"date":   date,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check holiday")
+	}
+
+	return isExists, nil
+}
+
+func (r *LeaveRepository) CreateHoliday(ctx context.Context, userID int, payload *dto.CreateHolidayPayload) (*dto.Holiday, error) {
+	var holiday dto.Holiday
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"date":   payload.Date,
+		"name":   payload.Name,
+	}
+
+	err := r.pool.QueryRow(ctx, queryCreateHoliday, args).Scan(&holiday.HolidayId, &holiday.Date, &holiday.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create holiday")
+	}
+
+	return &holiday, nil
+}
+
+// GetListHoliday returns the holidays between from and to, both inclusive.
+// An empty bound leaves that side open.
+func (r *LeaveRepository) GetListHoliday(ctx context.Context, userID int, from string, to string) ([]dto.Holiday, error) {
+	holidays := make([]dto.Holiday, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"from":   from,
+		"to":     to,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListHoliday, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list holiday")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var holiday dto.Holiday
+		if err := rows.Scan(&holiday.HolidayId, &holiday.Date, &holiday.Name); err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	return holidays, nil
+}
+
+func (r *LeaveRepository) DeleteHoliday(ctx context.Context, userID int, holidayID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":    userID,
+		"holidayID": holidayID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeleteHoliday, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete holiday")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *LeaveRepository) SetEntitlement(ctx context.Context, userID int, payload *dto.SetEntitlementPayload) (*dto.Entitlement, error) {
+	var entitlement dto.Entitlement
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": payload.IdentityNumber,
+		"leaveTypeID":    payload.LeaveTypeId,
+		"year":           payload.Year,
+		"days":           payload.Days,
+	}
+
+	err := r.pool.QueryRow(ctx, querySetEntitlement, args).Scan(
+		&entitlement.LeaveTypeId,
+		&entitlement.Year,
+		&entitlement.Days,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set leave entitlement")
+	}
+
+	return &entitlement, nil
+}
+
+// GetLeaveBalance returns the entitlement and usage of every leave type, or
+// only of leaveTypeID when it is set, for the given year. Accrual is left to
+// the caller.
+func (r *LeaveRepository) GetLeaveBalance(ctx context.Context, userID int, identityNumber string, year int, leaveTypeID int) ([]dto.LeaveBalance, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+		"year":           year,
+		"leaveTypeID":    leaveTypeID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetLeaveBalance, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get leave balance")
+	}
+
+	return scanLeaveBalances(rows, year)
+}
+
+func scanLeaveBalances(rows pgx.Rows, year int) ([]dto.LeaveBalance, error) {
+	balances := make([]dto.LeaveBalance, 0)
+	defer rows.Close()
+
+	for rows.Next() {
+		balance := dto.LeaveBalance{Year: year}
+		err := rows.Scan(
+			&balance.LeaveTypeId,
+			&balance.Code,
+			&balance.Name,
+			&balance.RequiresBalance,
+			&balance.Accrual,
+			&balance.Entitlement,
+			&balance.Used,
+			&balance.Pending,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		balances = append(balances, balance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get leave balance")
+	}
+
+	return balances, nil
+}
+
+// validateLockedBalance locks the balance of the leave type for the rest of
+// the transaction and runs validate against it, so concurrent requests
+// cannot spend the same days.
+func validateLockedBalance(ctx context.Context, tx pgx.Tx, userID int, identityNumber string, year int, leaveTypeID int, validate BalanceValidator) error {
+	var employeeID int64
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+		"year":           year,
+		"leaveTypeID":    leaveTypeID,
+	}
+
+	if err := tx.QueryRow(ctx, queryLockLeaveBalance, args).Scan(&employeeID); err != nil {
+		return errors.Wrap(err, "failed to lock leave balance")
+	}
+
+	rows, err := tx.Query(ctx, queryGetLeaveBalance, args)
+	if err != nil {
+		return errors.Wrap(err, "failed to get leave balance")
+	}
+
+	balances, err := scanLeaveBalances(rows, year)
+	if err != nil {
+		return err
+	}
+
+	if len(balances) == 0 {
+		return validate(nil)
+	}
+	return validate(&balances[0])
+}
+
+// CreateLeaveRequest files the request once it does not overlap another one
+// and validate accepts the balance of its year, all under the balance lock.
+func (r *LeaveRepository) CreateLeaveRequest(ctx context.Context, userID int, payload *dto.CreateLeaveRequestPayload, year int, validate BalanceValidator) (*dto.LeaveRequest, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	err = validateLockedBalance(ctx, tx, userID, payload.IdentityNumber, year, payload.LeaveTypeId, validate)
+	if err != nil {
+		return nil, err
+	}
+
+	var isOverlapping bool
+	var leaveRequestID int
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": payload.IdentityNumber,
+		"leaveTypeID":    payload.LeaveTypeId,
+		"startDate":      payload.StartDate,
+		"endDate":        payload.EndDate,
+		"days":           payload.Days,
+		"reason":         payload.Reason,
+	}
+
+	if err := tx.QueryRow(ctx, queryCheckIfLeaveOverlaps, args).Scan(&isOverlapping); err != nil {
+		return nil, errors.Wrap(err, "failed to check overlapping leave")
+	}
+
+	if isOverlapping {
+		return nil, ErrLeaveOverlaps
+	}
+
+	if err := tx.QueryRow(ctx, queryCreateLeaveRequest, args).Scan(&leaveRequestID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == exclusionViolation {
+			return nil, ErrLeaveOverlaps
+		}
+		return nil, errors.Wrap(err, "failed to create leave request")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit leave request")
+	}
+
+	return r.GetLeaveRequest(ctx, userID, leaveRequestID)
+}
+
+func (r *LeaveRepository) GetLeaveRequest(ctx context.Context, userID int, leaveRequestID int) (*dto.LeaveRequest, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"leaveRequestID": leaveRequestID,
+	}
+
+	request, err := scanLeaveRequest(r.pool.QueryRow(ctx, queryGetLeaveRequest, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get leave request")
+	}
+
+	return request, nil
+}
+
+func (r *LeaveRepository) GetListLeaveRequest(ctx context.Context, userID int, params *dto.GetLeaveRequestParams) ([]dto.LeaveRequest, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": params.IdentityNumber,
+		"status":         params.Status,
+		"offset":         params.Offset,
+		"limit":          params.Limit,
+	}
+
+	return r.queryLeaveRequests(ctx, queryGetListLeaveRequest, args)
+}
+
+func (r *LeaveRepository) GetDepartmentLeave(ctx context.Context, userID int, params *dto.GetTeamCalendarParams) ([]dto.LeaveRequest, error) {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"departmentID":   params.DepartmentId,
+		"from":           params.From,
+		"to":             params.To,
+		"includePending": params.IncludePending,
+	}
+
+	return r.queryLeaveRequests(ctx, queryGetDepartmentLeave, args)
+}
+
+func (r *LeaveRepository) queryLeaveRequests(ctx context.Context, query string, args pgx.NamedArgs) ([]dto.LeaveRequest, error) {
+	requests := make([]dto.LeaveRequest, 0)
+	rows, err := r.pool.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list leave request")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		request, err := scanLeaveRequest(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		requests = append(requests, *request)
+	}
+
+	return requests, nil
+}
+
+// CheckIfApprover reports whether the employee who made the request has a
+// manager at all, and whether the approver of the payload is one of their
+// managers.
+func (r *LeaveRepository) CheckIfApprover(ctx context.Context, userID int, payload *dto.ReviewLeavePayload) (bool, bool, error) {
+	var hasManager, isApprover bool
+	args := pgx.NamedArgs{
+		"userID":                 userID,
+		"leaveRequestID":         payload.LeaveRequestId,
+		"approverID":             payload.ApproverId,
+		"approverIdentityNumber": payload.ApproverIdentityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryCheckIfApprover, args).Scan(&hasManager, &isApprover)
+	if err != nil {
+		return false, false, errors.Wrap(err, "failed to check leave approver")
+	}
+
+	return hasManager, isApprover, nil
+}
+
+// ReviewLeaveRequest moves a pending request to the given status. It returns
+// pgx.ErrNoRows when the request is no longer pending.
+func (r *LeaveRepository) ReviewLeaveRequest(ctx context.Context, userID int, status dto.Status, payload *dto.ReviewLeavePayload) error {
+	var leaveRequestID int
+	args := reviewArgs(userID, status, payload)
+
+	if err := r.pool.QueryRow(ctx, queryReviewLeaveRequest, args).Scan(&leaveRequestID); err != nil {
+		return errors.Wrap(err, "failed to review leave request")
+	}
+
+	return nil
+}
+
+// ApproveLeaveRequest approves a pending request once validate accepts the
+// balance of its year, under the balance lock. It returns pgx.ErrNoRows
+// when the request is no longer pending.
+func (r *LeaveRepository) ApproveLeaveRequest(ctx context.Context, userID int, request *dto.LeaveRequest, year int, leaveTypeID int, payload *dto.ReviewLeavePayload, validate BalanceValidator) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	err = validateLockedBalance(ctx, tx, userID, request.EmployeeIdentityNumber, year, leaveTypeID, validate)
+	if err != nil {
+		return err
+	}
+
+	var leaveRequestID int
+	args := reviewArgs(userID, dto.StatusApproved, payload)
+
+	if err := tx.QueryRow(ctx, queryReviewLeaveRequest, args).Scan(&leaveRequestID); err != nil {
+		return errors.Wrap(err, "failed to review leave request")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to commit leave approval")
+	}
+
+	return nil
+}
+
+func reviewArgs(userID int, status dto.Status, payload *dto.ReviewLeavePayload) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"userID":                 userID,
+		"leaveRequestID":         payload.LeaveRequestId,
+		"status":                 status,
+		"approverID":             payload.ApproverId,
+		"approverIdentityNumber": payload.ApproverIdentityNumber,
+		"isOwnerOverride":        payload.IsOwnerOverride,
+		"note":                   payload.Note,
+	}
+}
+
+// CancelLeaveRequest cancels a request that still has the given status. It
+// returns pgx.ErrNoRows when the status changed in the meantime.
+func (r *LeaveRepository) CancelLeaveRequest(ctx context.Context, userID int, leaveRequestID int, status dto.Status) error {
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"leaveRequestID": leaveRequestID,
+		"status":         status,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCancelLeaveRequest, args).Scan(&leaveRequestID); err != nil {
+		return errors.Wrap(err, "failed to cancel leave request")
+	}
+
+	return nil
+}
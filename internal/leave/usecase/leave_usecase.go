@@ -0,0 +1,439 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"ps-gogo-manajer/internal/leave/dto"
+	"ps-gogo-manajer/internal/leave/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/jwt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DATE_LAYOUT = "2006-01-02"
+
+	// MAX_CALENDAR_DAYS bounds the range of the team calendar.
+	MAX_CALENDAR_DAYS = 92
+)
+
+type LeaveUsecase struct {
+	leaveRepo repository.LeaveRepository
+}
+
+func NewLeaveUsecase(leaveRepo repository.LeaveRepository) *LeaveUsecase {
+	return &LeaveUsecase{
+		leaveRepo: leaveRepo,
+	}
+}
+
+func (u *LeaveUsecase) CreateLeaveType(ctx context.Context, userID int, payload *dto.CreateLeaveTypePayload) (*dto.LeaveType, error) {
+	isCodeExists, err := u.leaveRepo.CheckIfLeaveTypeCodeExists(ctx, userID, payload.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if isCodeExists {
+		return nil, errors.Wrap(customErrors.ErrConflict, "leave type code already exists")
+	}
+
+	return u.leaveRepo.CreateLeaveType(ctx, userID, payload)
+}
+
+func (u *LeaveUsecase) GetListLeaveType(ctx context.Context, userID int) ([]dto.LeaveType, error) {
+	return u.leaveRepo.GetListLeaveType(ctx, userID)
+}
+
+func (u *LeaveUsecase) UpdateLeaveType(ctx context.Context, userID int, leaveTypeID int, payload *dto.PatchLeaveTypePayload) (*dto.LeaveType, error) {
+	leaveType, err := u.leaveRepo.UpdateLeaveType(ctx, userID, leaveTypeID, payload)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "leave type not found")
+		}
+		return nil, err
+	}
+
+	return leaveType, nil
+}
+
+func (u *LeaveUsecase) DeleteLeaveType(ctx context.Context, userID int, leaveTypeID int) error {
+	if _, err := u.getLeaveType(ctx, userID, leaveTypeID); err != nil {
+		return err
+	}
+
+	isInUse, err := u.leaveRepo.CheckIfLeaveTypeInUse(ctx, leaveTypeID)
+	if err != nil {
+		return err
+	}
+
+	if isInUse {
+		return errors.Wrap(customErrors.ErrConflict, "leave type has leave requests")
+	}
+
+	return u.leaveRepo.DeleteLeaveType(ctx, userID, leaveTypeID)
+}
+
+func (u *LeaveUsecase) CreateHoliday(ctx context.Context, userID int, payload *dto.CreateHolidayPayload) (*dto.Holiday, error) {
+	isHolidayExists, err := u.leaveRepo.CheckIfHolidayExists(ctx, userID, payload.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	if isHolidayExists {
+		return nil, errors.Wrap(customErrors.ErrConflict, "holiday already exists on this date")
+	}
+
+	return u.leaveRepo.CreateHoliday(ctx, userID, payload)
+}
+
+func (u *LeaveUsecase) GetListHoliday(ctx context.Context, userID int, params *dto.GetHolidayParams) ([]dto.Holiday, error) {
+	var from, to string
+	if params.Year != 0 {
+		from = time.Date(params.Year, time.January, 1, 0, 0, 0, 0, time.UTC).Format(DATE_LAYOUT)
+		to = time.Date(params.Year, time.December, 31, 0, 0, 0, 0, time.UTC).Format(DATE_LAYOUT)
+	}
+
+	return u.leaveRepo.GetListHoliday(ctx, userID, from, to)
+}
+
+func (u *LeaveUsecase) DeleteHoliday(ctx context.Context, userID int, holidayID int) error {
+	isDeleted, err := u.leaveRepo.DeleteHoliday(ctx, userID, holidayID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return errors.Wrap(customErrors.ErrNotFound, "holiday not found")
+	}
+
+	return nil
+}
+
+func (u *LeaveUsecase) SetEntitlement(ctx context.Context, userID int, payload *dto.SetEntitlementPayload) (*dto.Entitlement, error) {
+	if err := u.validateEmployeeExists(ctx, userID, payload.IdentityNumber); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.getLeaveType(ctx, userID, payload.LeaveTypeId); err != nil {
+		return nil, err
+	}
+
+	return u.leaveRepo.SetEntitlement(ctx, userID, payload)
+}
+
+func (u *LeaveUsecase) GetLeaveBalance(ctx context.Context, userID int, params *dto.GetLeaveBalanceParams) ([]dto.LeaveBalance, error) {
+	if err := u.validateEmployeeExists(ctx, userID, params.IdentityNumber); err != nil {
+		return nil, err
+	}
+
+	year := params.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	balances, err := u.leaveRepo.GetLeaveBalance(ctx, userID, params.IdentityNumber, year, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range balances {
+		accrue(&balances[i], now)
+	}
+
+	return balances, nil
+}
+
+// accrue fills in the accrued and available days of a balance. Monthly
+// accrual earns a twelfth of the entitlement at the start of every month,
+// rounded down to half days.
+func accrue(balance *dto.LeaveBalance, now time.Time) {
+	balance.Accrued = float64(balance.Entitlement)
+	if balance.Accrual == dto.AccrualMonthly {
+		months := 12
+		switch {
+		case balance.Year > now.Year():
+			months = 0
+		case balance.Year == now.Year():
+			months = int(now.Month())
+		}
+		balance.Accrued = math.Floor(float64(balance.Entitlement)*float64(months)/12*2) / 2
+	}
+
+	balance.Available = balance.Accrued - float64(balance.Used+balance.Pending)
+}
+
+// CreateLeaveRequest files a pending request. Its length is counted in
+// working days, leaving out weekends and the organization's holidays.
+func (u *LeaveUsecase) CreateLeaveRequest(ctx context.Context, userID int, payload *dto.CreateLeaveRequestPayload) (*dto.LeaveRequest, error) {
+	startDate, _ := time.Parse(DATE_LAYOUT, payload.StartDate)
+	endDate, _ := time.Parse(DATE_LAYOUT, payload.EndDate)
+	if endDate.Before(startDate) {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "end date is before start date")
+	}
+
+	if startDate.Year() != endDate.Year() {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "leave cannot span two years, split the request")
+	}
+
+	if err := u.validateEmployeeExists(ctx, userID, payload.IdentityNumber); err != nil {
+		return nil, err
+	}
+
+	holidays, err := u.leaveRepo.GetListHoliday(ctx, userID, payload.StartDate, payload.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	payload.Days = countWorkingDays(startDate, endDate, holidays)
+	if payload.Days == 0 {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "leave has no working days")
+	}
+
+	request, err := u.leaveRepo.CreateLeaveRequest(ctx, userID, payload, startDate.Year(), func(balance *dto.LeaveBalance) error {
+		if balance == nil {
+			return errors.Wrap(customErrors.ErrNotFound, "leave type not found")
+		}
+
+		accrue(balance, time.Now())
+		if balance.RequiresBalance && float64(payload.Days) > balance.Available {
+			return errors.Wrapf(customErrors.ErrBadRequest, "insufficient leave balance, %.1f days available", balance.Available)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrLeaveOverlaps) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "leave overlaps with another request")
+		}
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func countWorkingDays(startDate time.Time, endDate time.Time, holidays []dto.Holiday) int {
+	isHoliday := make(map[string]bool, len(holidays))
+	for _, holiday := range holidays {
+		isHoliday[holiday.Date] = true
+	}
+
+	days := 0
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+		if isHoliday[date.Format(DATE_LAYOUT)] {
+			continue
+		}
+		days++
+	}
+
+	return days
+}
+
+func (u *LeaveUsecase) GetListLeaveRequest(ctx context.Context, userID int, params *dto.GetLeaveRequestParams) ([]dto.LeaveRequest, error) {
+	if params.IdentityNumber != "" {
+		if err := u.validateEmployeeExists(ctx, userID, params.IdentityNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.leaveRepo.GetListLeaveRequest(ctx, userID, params)
+}
+
+func (u *LeaveUsecase) ApproveLeaveRequest(ctx context.Context, userID int, payload *dto.ReviewLeavePayload) (*dto.LeaveRequest, error) {
+	return u.reviewLeaveRequest(ctx, userID, dto.StatusApproved, payload)
+}
+
+func (u *LeaveUsecase) RejectLeaveRequest(ctx context.Context, userID int, payload *dto.ReviewLeavePayload) (*dto.LeaveRequest, error) {
+	return u.reviewLeaveRequest(ctx, userID, dto.StatusRejected, payload)
+}
+
+func (u *LeaveUsecase) reviewLeaveRequest(ctx context.Context, userID int, status dto.Status, payload *dto.ReviewLeavePayload) (*dto.LeaveRequest, error) {
+	request, err := u.getLeaveRequest(ctx, userID, payload.LeaveRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != dto.StatusPending {
+		return nil, errors.Wrapf(customErrors.ErrConflict, "leave request is already %s", request.Status)
+	}
+
+	// * A manager login is the approver itself, the approver in the body is only for the owner
+	claim, ok := jwt.FromContext(ctx)
+	isManager := ok && claim.IsManager()
+	if isManager {
+		if payload.ApproverIdentityNumber != "" {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, "approverIdentityNumber is only for the account owner")
+		}
+		payload.ApproverId = claim.EmployeeId
+	}
+
+	hasManager, isApprover, err := u.leaveRepo.CheckIfApprover(ctx, userID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if isManager {
+		if !hasManager || !isApprover {
+			return nil, errors.Wrap(customErrors.ErrForbidden, "only the employee's managers can review this request")
+		}
+	} else {
+		if hasManager && !isApprover {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, "approver must be one of the employee's managers")
+		}
+
+		if !hasManager && payload.ApproverIdentityNumber != "" {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, "employee has no manager")
+		}
+
+		payload.IsOwnerOverride = hasManager
+	}
+
+	if status == dto.StatusApproved {
+		err = u.approveLeaveRequest(ctx, userID, request, payload)
+	} else {
+		err = u.leaveRepo.ReviewLeaveRequest(ctx, userID, status, payload)
+	}
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "leave request is no longer pending")
+		}
+		return nil, err
+	}
+
+	return u.leaveRepo.GetLeaveRequest(ctx, userID, payload.LeaveRequestId)
+}
+
+// approveLeaveRequest approves the request if the balance still covers it,
+// as it may have been used up by requests approved in the meantime.
+func (u *LeaveUsecase) approveLeaveRequest(ctx context.Context, userID int, request *dto.LeaveRequest, payload *dto.ReviewLeavePayload) error {
+	startDate, err := time.Parse(DATE_LAYOUT, request.StartDate)
+	if err != nil {
+		return err
+	}
+
+	leaveTypeID, err := strconv.Atoi(request.LeaveTypeId)
+	if err != nil {
+		return err
+	}
+
+	return u.leaveRepo.ApproveLeaveRequest(ctx, userID, request, startDate.Year(), leaveTypeID, payload, func(balance *dto.LeaveBalance) error {
+		if balance == nil {
+			return nil
+		}
+
+		// * The request itself is still counted as pending
+		accrue(balance, time.Now())
+		if balance.RequiresBalance && balance.Accrued-float64(balance.Used) < float64(request.Days) {
+			return errors.Wrap(customErrors.ErrBadRequest, "insufficient leave balance")
+		}
+		return nil
+	})
+}
+
+// CancelLeaveRequest withdraws a pending request, or an approved one that has
+// not started yet.
+func (u *LeaveUsecase) CancelLeaveRequest(ctx context.Context, userID int, leaveRequestID int) (*dto.LeaveRequest, error) {
+	request, err := u.getLeaveRequest(ctx, userID, leaveRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch request.Status {
+	case dto.StatusPending:
+	case dto.StatusApproved:
+		if request.StartDate <= time.Now().Format(DATE_LAYOUT) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "leave has already started")
+		}
+	default:
+		return nil, errors.Wrapf(customErrors.ErrConflict, "leave request is already %s", request.Status)
+	}
+
+	if err := u.leaveRepo.CancelLeaveRequest(ctx, userID, leaveRequestID, request.Status); err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "leave request changed, please retry")
+		}
+		return nil, err
+	}
+
+	return u.leaveRepo.GetLeaveRequest(ctx, userID, leaveRequestID)
+}
+
+func (u *LeaveUsecase) GetTeamCalendar(ctx context.Context, userID int, params *dto.GetTeamCalendarParams) (*dto.TeamCalendar, error) {
+	from, _ := time.Parse(DATE_LAYOUT, params.From)
+	to, _ := time.Parse(DATE_LAYOUT, params.To)
+	if to.Before(from) {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "to is before from")
+	}
+
+	if to.Sub(from) > MAX_CALENDAR_DAYS*24*time.Hour {
+		return nil, errors.Wrapf(customErrors.ErrBadRequest, "calendar range is limited to %d days", MAX_CALENDAR_DAYS)
+	}
+
+	isDepartmentExists, err := u.leaveRepo.CheckIfDepartmentExists(ctx, userID, params.DepartmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDepartmentExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "department not found")
+	}
+
+	holidays, err := u.leaveRepo.GetListHoliday(ctx, userID, params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves, err := u.leaveRepo.GetDepartmentLeave(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TeamCalendar{
+		From:     params.From,
+		To:       params.To,
+		Holidays: holidays,
+		Leaves:   leaves,
+	}, nil
+}
+
+func (u *LeaveUsecase) getLeaveType(ctx context.Context, userID int, leaveTypeID int) (*dto.LeaveType, error) {
+	leaveType, err := u.leaveRepo.GetLeaveType(ctx, userID, leaveTypeID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "leave type not found")
+		}
+		return nil, err
+	}
+
+	return leaveType, nil
+}
+
+func (u *LeaveUsecase) getLeaveRequest(ctx context.Context, userID int, leaveRequestID int) (*dto.LeaveRequest, error) {
+	request, err := u.leaveRepo.GetLeaveRequest(ctx, userID, leaveRequestID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "leave request not found")
+		}
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func (u *LeaveUsecase) validateEmployeeExists(ctx context.Context, userID int, identityNumber string) error {
+	isEmployeeExists, err := u.leaveRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
+	if err != nil {
+		return err
+	}
+
+	if !isEmployeeExists {
+		return errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+// Location is an office of the organization. The geofence is optional, a
+// location without one leaves Latitude, Longitude and RadiusMeters nil.
+// Clock events are checked against the locations that have one.
+type Location struct {
+	LocationId    string    `json:"locationId"`
+	Name          string    `json:"name"`
+	Address       string    `json:"address"`
+	Timezone      string    `json:"timezone"`
+	Latitude      *float64  `json:"latitude"`
+	Longitude     *float64  `json:"longitude"`
+	RadiusMeters  *int      `json:"radiusMeters"`
+	EmployeeCount int       `json:"employeeCount"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type CreateLocationPayload struct {
+	Name         string   `json:"name" validate:"required,min=1,max=255"`
+	Address      string   `json:"address" validate:"omitempty,max=1000"`
+	Timezone     string   `json:"timezone" validate:"omitempty,timezone"`
+	Latitude     *float64 `json:"latitude" validate:"required_with=Longitude RadiusMeters,omitempty,min=-90,max=90"`
+	Longitude    *float64 `json:"longitude" validate:"required_with=Latitude RadiusMeters,omitempty,min=-180,max=180"`
+	RadiusMeters *int     `json:"radiusMeters" validate:"required_with=Latitude Longitude,omitempty,min=10,max=100000"`
+}
+
+type PatchLocationPayload struct {
+	Name         *string  `json:"name" validate:"omitempty,min=1,max=255"`
+	Address      *string  `json:"address" validate:"omitempty,max=1000"`
+	Timezone     *string  `json:"timezone" validate:"omitempty,timezone"`
+	Latitude     *float64 `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude    *float64 `json:"longitude" validate:"omitempty,min=-180,max=180"`
+	RadiusMeters *int     `json:"radiusMeters" validate:"omitempty,min=10,max=100000"`
+	// ClearGeofence removes the geofence of the location.
+	ClearGeofence bool `json:"clearGeofence" validate:"excluded_with=Latitude Longitude RadiusMeters"`
+}
+
+type GetLocationParams struct {
+	Name string `query:"name"`
+}
+
+type LocationPathParam struct {
+	LocationId int `param:"locationId" validate:"required,min=1"`
+}
+
+// GetBreakdownParams picks the payroll run the payroll cost is taken from,
+// the latest locked run when PayrollRunId is left out.
+type GetBreakdownParams struct {
+	PayrollRunId int `query:"payrollRunId" validate:"omitempty,min=1"`
+}
+
+// LocationBreakdown holds the headcount and the payroll cost of every
+// location. PayrollRunId and Period are empty when there is no run to take
+// the cost from.
+type LocationBreakdown struct {
+	PayrollRunId string         `json:"payrollRunId"`
+	Period       string         `json:"period"`
+	Locations    []LocationCost `json:"locations"`
+}
+
+// LocationCost is one row of the breakdown. The last row, with an empty
+// LocationId, holds the employees without a location. PayrollCost is the
+// gross pay plus the contributions paid by the employer.
+type LocationCost struct {
+	LocationId  string `json:"locationId"`
+	Name        string `json:"name"`
+	Headcount   int    `json:"headcount"`
+	PayrollCost int64  `json:"payrollCost"`
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/location/dto"
+	"ps-gogo-manajer/internal/location/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type LocationHandler struct {
+	locationUsecase usecase.LocationUsecase
+	validator       *validator.Validate
+}
+
+func NewLocationHandler(locationUsecase usecase.LocationUsecase, validator *validator.Validate) *LocationHandler {
+	return &LocationHandler{
+		locationUsecase: locationUsecase,
+		validator:       validator,
+	}
+}
+
+// bind binds the request into payload and validates it, wrapping any failure
+// as a bad request.
+func (h LocationHandler) bind(ctx echo.Context, payload any) error {
+	if err := ctx.Bind(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (h LocationHandler) bindPathParam(ctx echo.Context) (*dto.LocationPathParam, error) {
+	var pathParam dto.LocationPathParam
+	if err := (&echo.DefaultBinder{}).BindPathParams(ctx, &pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return &pathParam, nil
+}
+
+func (h LocationHandler) CreateLocation(ctx echo.Context) error {
+	var payload dto.CreateLocationPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	location, err := h.locationUsecase.CreateLocation(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, location)
+}
+
+func (h LocationHandler) GetListLocation(ctx echo.Context) error {
+	var params dto.GetLocationParams
+	if err := h.bind(ctx, &params); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	locations, err := h.locationUsecase.GetListLocation(ctx.Request().Context(), userData.Id, &params)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, locations)
+}
+
+func (h LocationHandler) GetLocation(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	location, err := h.locationUsecase.GetLocation(ctx.Request().Context(), userData.Id, pathParam.LocationId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, location)
+}
+
+func (h LocationHandler) UpdateLocation(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.PatchLocationPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	location, err := h.locationUsecase.UpdateLocation(ctx.Request().Context(), userData.Id, pathParam.LocationId, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, location)
+}
+
+func (h LocationHandler) DeleteLocation(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	if err := h.locationUsecase.DeleteLocation(ctx.Request().Context(), userData.Id, pathParam.LocationId); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h LocationHandler) GetLocationBreakdown(ctx echo.Context) error {
+	var params dto.GetBreakdownParams
+	if err := h.bind(ctx, &params); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	breakdown, err := h.locationUsecase.GetLocationBreakdown(ctx.Request().Context(), userData.Id, &params)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, breakdown)
+}
@@ -0,0 +1,345 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/location/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type LocationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewLocationRepository(pool *pgxpool.Pool) *LocationRepository {
+	return &LocationRepository{pool: pool}
+}
+
+const (
+	locationColumns = `
+		locations.id,
+		locations.name,
+		locations.address,
+		locations.timezone,
+		locations.latitude,
+		locations.longitude,
+		locations.radius_meters,
+		(
+			SELECT COUNT(*)
+			FROM employees
+			WHERE
+				employees.location_id = locations.id
+				AND employees.deleted_at IS NULL
+				AND employees.status <> 'terminated'
+		),
+		locations.created_at,
+		locations.updated_at`
+
+	queryCreateLocation = `
+	INSERT INTO locations(user_id, name, address, timezone, latitude, longitude, radius_meters)
+	VALUES (
+		@userID,
+		@name,
+		NULLIF(@address, ''),
+		COALESCE(NULLIF(@timezone, ''), 'Asia/Jakarta'),
+		@latitude,
+		@longitude,
+		@radiusMeters
+	)
+	RETURNING` + locationColumns + `;`
+	queryGetLocation = `
+	SELECT` + locationColumns + `
+	FROM locations
+	WHERE
+		user_id = @userID
+		AND id = @locationID;`
+	queryGetListLocation = `
+	SELECT` + locationColumns + `
+	FROM locations
+	WHERE
+		user_id = @userID
+		AND (NULLIF(@name, '') IS NULL OR name ILIKE '%' || NULLIF(@name, '') || '%')
+	ORDER BY name, id;`
+	queryUpdateLocation = `
+	UPDATE locations
+	SET
+		name = COALESCE(@name, name),
+		address = COALESCE(@address, address),
+		timezone = COALESCE(@timezone, timezone),
+		latitude = CASE WHEN @clearGeofence::boolean THEN NULL ELSE COALESCE(@latitude, latitude) END,
+		longitude = CASE WHEN @clearGeofence::boolean THEN NULL ELSE COALESCE(@longitude, longitude) END,
+		radius_meters = CASE WHEN @clearGeofence::boolean THEN NULL ELSE COALESCE(@radiusMeters, radius_meters) END,
+		updated_at = NOW()
+	WHERE
+		user_id = @userID
+		AND id = @locationID
+	RETURNING` + locationColumns + `;`
+	queryDeleteLocation = `
+	DELETE FROM locations
+	WHERE
+		user_id = @userID
+		AND id = @locationID;`
+	queryCheckIfLocationExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM locations
+		WHERE
+			user_id = @userID
+			AND name = @name
+			AND id <> @locationID
+	) is_exists;`
+	// The run is the one asked for, or the latest locked run of the user.
+	queryGetBreakdownRun = `
+	SELECT
+		id,
+		to_char(period, 'YYYY-MM')
+	FROM payroll_runs
+	WHERE
+		user_id = @userID
+		AND CASE
+			WHEN @payrollRunID::bigint = 0 THEN status = 'locked'
+			ELSE id = @payrollRunID::bigint
+		END
+	ORDER BY period DESC
+	LIMIT 1;`
+	// Payroll items keep the location of the employee at the time of the
+	// run, so the cost of a past run stays where it was spent.
+	queryGetLocationBreakdown = `
+	WITH
+	headcount AS (
+		SELECT location_id, COUNT(*) headcount
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND deleted_at IS NULL
+			AND status <> 'terminated'
+		GROUP BY location_id
+	),
+	cost AS (
+		SELECT
+			payroll_items.location_id,
+			SUM(
+				payroll_items.gross + COALESCE((
+					SELECT SUM(line.amount)
+					FROM jsonb_to_recordset(payroll_items.lines) AS line(type TEXT, amount BIGINT)
+					WHERE line.type = 'employer_contribution'
+				), 0)
+			)::bigint payroll_cost
+		FROM payroll_items
+		JOIN payroll_runs ON payroll_runs.id = payroll_items.payroll_run_id
+		WHERE
+			payroll_runs.user_id = @userID
+			AND payroll_runs.id = @payrollRunID::bigint
+		GROUP BY payroll_items.location_id
+	)
+	SELECT location_id, name, headcount, payroll_cost
+	FROM (
+		SELECT
+			locations.id::text location_id,
+			locations.name,
+			COALESCE(headcount.headcount, 0) headcount,
+			COALESCE(cost.payroll_cost, 0) payroll_cost
+		FROM locations
+		LEFT JOIN headcount ON headcount.location_id = locations.id
+		LEFT JOIN cost ON cost.location_id = locations.id
+		WHERE locations.user_id = @userID
+		UNION ALL
+		SELECT
+			NULL,
+			NULL,
+			COALESCE((SELECT headcount FROM headcount WHERE location_id IS NULL), 0),
+			COALESCE((SELECT payroll_cost FROM cost WHERE location_id IS NULL), 0)
+	) breakdown
+	ORDER BY location_id IS NULL, name, location_id;`
+)
+
+func scanLocation(row pgx.Row) (*dto.Location, error) {
+	var location dto.Location
+	address := new(pgtype.Text)
+
+	err := row.Scan(
+		&location.LocationId,
+		&location.Name,
+		address,
+		&location.Timezone,
+		&location.Latitude,
+		&location.Longitude,
+		&location.RadiusMeters,
+		&location.EmployeeCount,
+		&location.CreatedAt,
+		&location.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	location.Address = address.String
+	return &location, nil
+}
+
+func (r *LocationRepository) CreateLocation(ctx context.Context, userID int, payload *dto.CreateLocationPayload) (*dto.Location, error) {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"name":         payload.Name,
+		"address":      payload.Address,
+		"timezone":     payload.Timezone,
+		"latitude":     payload.Latitude,
+		"longitude":    payload.Longitude,
+		"radiusMeters": payload.RadiusMeters,
+	}
+
+	location, err := scanLocation(r.pool.QueryRow(ctx, queryCreateLocation, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create location")
+	}
+
+	return location, nil
+}
+
+func (r *LocationRepository) GetLocation(ctx context.Context, userID int, locationID int) (*dto.Location, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"locationID": locationID,
+	}
+
+	location, err := scanLocation(r.pool.QueryRow(ctx, queryGetLocation, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get location")
+	}
+
+	return location, nil
+}
+
+func (r *LocationRepository) GetListLocation(ctx context.Context, userID int, params *dto.GetLocationParams) ([]dto.Location, error) {
+	locations := make([]dto.Location, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"name":   params.Name,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListLocation, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list location")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		locations = append(locations, *location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get list location")
+	}
+
+	return locations, nil
+}
+
+func (r *LocationRepository) UpdateLocation(ctx context.Context, userID int, locationID int, payload *dto.PatchLocationPayload) (*dto.Location, error) {
+	args := pgx.NamedArgs{
+		"userID":        userID,
+		"locationID":    locationID,
+		"name":          payload.Name,
+		"address":       payload.Address,
+		"timezone":      payload.Timezone,
+		"latitude":      payload.Latitude,
+		"longitude":     payload.Longitude,
+		"radiusMeters":  payload.RadiusMeters,
+		"clearGeofence": payload.ClearGeofence,
+	}
+
+	location, err := scanLocation(r.pool.QueryRow(ctx, queryUpdateLocation, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update location")
+	}
+
+	return location, nil
+}
+
+func (r *LocationRepository) DeleteLocation(ctx context.Context, userID int, locationID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"locationID": locationID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeleteLocation, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete location")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// CheckIfLocationExists reports whether another location than locationID has
+// the name.
+func (r *LocationRepository) CheckIfLocationExists(ctx context.Context, userID int, name string, locationID int) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"name":       name,
+		"locationID": locationID,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCheckIfLocationExists, args).Scan(&isExists); err != nil {
+		return false, errors.Wrap(err, "failed to check is location exists")
+	}
+
+	return isExists, nil
+}
+
+// GetBreakdownRun returns the id and the period of the payroll run, the
+// latest locked run when payrollRunID is 0.
+func (r *LocationRepository) GetBreakdownRun(ctx context.Context, userID int, payrollRunID int) (int, string, error) {
+	var runID int
+	var period string
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"payrollRunID": payrollRunID,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryGetBreakdownRun, args).Scan(&runID, &period); err != nil {
+		return 0, "", errors.Wrap(err, "failed to get payroll run")
+	}
+
+	return runID, period, nil
+}
+
+func (r *LocationRepository) GetLocationBreakdown(ctx context.Context, userID int, payrollRunID int) ([]dto.LocationCost, error) {
+	locations := make([]dto.LocationCost, 0)
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"payrollRunID": payrollRunID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetLocationBreakdown, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get location breakdown")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var location dto.LocationCost
+		locationID := new(pgtype.Text)
+		name := new(pgtype.Text)
+
+		if err := rows.Scan(locationID, name, &location.Headcount, &location.PayrollCost); err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		location.LocationId = locationID.String
+		location.Name = name.String
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get location breakdown")
+	}
+
+	return locations, nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/location/dto"
+	"ps-gogo-manajer/internal/location/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+type LocationUsecase struct {
+	locationRepo repository.LocationRepository
+}
+
+func NewLocationUsecase(locationRepo repository.LocationRepository) *LocationUsecase {
+	return &LocationUsecase{
+		locationRepo: locationRepo,
+	}
+}
+
+func (u *LocationUsecase) CreateLocation(ctx context.Context, userID int, payload *dto.CreateLocationPayload) (*dto.Location, error) {
+	if err := u.validateUnique(ctx, userID, payload.Name, 0); err != nil {
+		return nil, err
+	}
+
+	return u.locationRepo.CreateLocation(ctx, userID, payload)
+}
+
+func (u *LocationUsecase) GetListLocation(ctx context.Context, userID int, params *dto.GetLocationParams) ([]dto.Location, error) {
+	return u.locationRepo.GetListLocation(ctx, userID, params)
+}
+
+func (u *LocationUsecase) GetLocation(ctx context.Context, userID int, locationID int) (*dto.Location, error) {
+	location, err := u.locationRepo.GetLocation(ctx, userID, locationID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "location not found")
+		}
+		return nil, err
+	}
+
+	return location, nil
+}
+
+func (u *LocationUsecase) UpdateLocation(ctx context.Context, userID int, locationID int, payload *dto.PatchLocationPayload) (*dto.Location, error) {
+	location, err := u.GetLocation(ctx, userID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Name != nil && *payload.Name != location.Name {
+		if err := u.validateUnique(ctx, userID, *payload.Name, locationID); err != nil {
+			return nil, err
+		}
+	}
+
+	// * A geofence is set as a whole, a location without one takes all three values at once
+	if location.Latitude == nil && !payload.ClearGeofence {
+		isPartial := payload.Latitude != nil || payload.Longitude != nil || payload.RadiusMeters != nil
+		isComplete := payload.Latitude != nil && payload.Longitude != nil && payload.RadiusMeters != nil
+		if isPartial && !isComplete {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, "latitude, longitude and radiusMeters are required to add a geofence")
+		}
+	}
+
+	location, err = u.locationRepo.UpdateLocation(ctx, userID, locationID, payload)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "location not found")
+		}
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// DeleteLocation removes a location nobody works at anymore. Terminated
+// employees and past payroll runs lose the location.
+func (u *LocationUsecase) DeleteLocation(ctx context.Context, userID int, locationID int) error {
+	location, err := u.GetLocation(ctx, userID, locationID)
+	if err != nil {
+		return err
+	}
+
+	if location.EmployeeCount > 0 {
+		return errors.Wrap(customErrors.ErrConflict, "still containing employee")
+	}
+
+	isDeleted, err := u.locationRepo.DeleteLocation(ctx, userID, locationID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return errors.Wrap(customErrors.ErrNotFound, "location not found")
+	}
+
+	return nil
+}
+
+// GetLocationBreakdown returns the headcount of every location along with
+// the payroll cost of the run asked for, or of the latest locked run.
+func (u *LocationUsecase) GetLocationBreakdown(ctx context.Context, userID int, params *dto.GetBreakdownParams) (*dto.LocationBreakdown, error) {
+	var breakdown dto.LocationBreakdown
+	runID, period, err := u.locationRepo.GetBreakdownRun(ctx, userID, params.PayrollRunId)
+	if err != nil {
+		if !errors.Is(err, customErrors.ErrNotFound) {
+			return nil, err
+		}
+		// * Without any locked run yet the breakdown only holds the headcount
+		if params.PayrollRunId != 0 {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "payroll run not found")
+		}
+	}
+
+	if runID != 0 {
+		breakdown.PayrollRunId = strconv.Itoa(runID)
+		breakdown.Period = period
+	}
+
+	breakdown.Locations, err = u.locationRepo.GetLocationBreakdown(ctx, userID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &breakdown, nil
+}
+
+func (u *LocationUsecase) validateUnique(ctx context.Context, userID int, name string, locationID int) error {
+	isExists, err := u.locationRepo.CheckIfLocationExists(ctx, userID, name, locationID)
+	if err != nil {
+		return err
+	}
+
+	if isExists {
+		return errors.Wrap(customErrors.ErrConflict, "location already exists")
+	}
+
+	return nil
+}
@@ -11,7 +11,18 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Auth only lets the user owning the account through.
 func Auth() echo.MiddlewareFunc {
+	return auth(false)
+}
+
+// ManagerAuth also lets department heads through. The repositories behind
+// these routes limit what a head can reach to their own departments.
+func ManagerAuth() echo.MiddlewareFunc {
+	return auth(true)
+}
+
+func auth(allowManager bool) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
 			jwtToken, err := extractJWTTokenFromHeader(ctx.Request())
@@ -25,7 +36,13 @@ func Auth() echo.MiddlewareFunc {
 				return ctx.JSON(response.WriteErrorResponse(err))
 			}
 
+			if claim.IsManager() && !allowManager {
+				err = errors.Wrap(customErrors.ErrForbidden, "not available to manager logins")
+				return ctx.JSON(response.WriteErrorResponse(err))
+			}
+
 			ctx.Set("user", claim)
+			ctx.SetRequest(ctx.Request().WithContext(jwt.NewContext(ctx.Request().Context(), claim)))
 
 			// default user passing middleware if token is valid
 			return next(ctx)
@@ -0,0 +1,224 @@
+package dto
+
+import (
+	"ps-gogo-manajer/internal/payroll/statutory"
+	"time"
+)
+
+type ComponentType string
+
+const (
+	ComponentTypeEarning   ComponentType = "earning"
+	ComponentTypeDeduction ComponentType = "deduction"
+	// ComponentTypeEmployerContribution lines are paid by the employer on
+	// top of the salary, they are neither part of the gross nor of the net.
+	ComponentTypeEmployerContribution ComponentType = "employer_contribution"
+)
+
+type RunStatus string
+
+const (
+	RunStatusDraft  RunStatus = "draft"
+	RunStatusLocked RunStatus = "locked"
+)
+
+// SalaryComponent is a recurring monthly allowance or deduction. Amounts are
+// in whole rupiah.
+type SalaryComponent struct {
+	Code      string        `json:"code" validate:"required,max=32,fieldkey"`
+	Name      string        `json:"name" validate:"required,min=1,max=255"`
+	Type      ComponentType `json:"type" validate:"required,oneof=earning deduction"`
+	Amount    int64         `json:"amount" validate:"min=0"`
+	IsTaxable bool          `json:"isTaxable"`
+}
+
+type SalaryStructure struct {
+	IdentityNumber string            `json:"identityNumber"`
+	BaseSalary     int64             `json:"baseSalary"`
+	Components     []SalaryComponent `json:"components"`
+	PTKPStatus     string            `json:"ptkpStatus"`
+	IsBPJSEnrolled bool              `json:"isBpjsEnrolled"`
+	JKKRiskClass   int               `json:"jkkRiskClass"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+}
+
+// SetSalaryStructurePayload replaces the salary of an employee. Left out, the
+// PTKP status is TK/0, the employee is enrolled in BPJS and the JKK risk
+// class is the lowest.
+type SetSalaryStructurePayload struct {
+	IdentityNumber string            `param:"identityNumber" validate:"required"`
+	BaseSalary     int64             `json:"baseSalary" validate:"min=0"`
+	Components     []SalaryComponent `json:"components" validate:"max=50,unique=Code,dive"`
+	PTKPStatus     string            `json:"ptkpStatus" validate:"omitempty,oneof=TK/0 TK/1 TK/2 TK/3 K/0 K/1 K/2 K/3"`
+	IsBPJSEnrolled *bool             `json:"isBpjsEnrolled"`
+	JKKRiskClass   int               `json:"jkkRiskClass" validate:"omitempty,min=1,max=5"`
+}
+
+type GetSalaryStructureParams struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+}
+
+// PayrollEmployee is everything a run knows about an employee when it is
+// calculated.
+type PayrollEmployee struct {
+	EmployeeId      int64
+	IdentityNumber  string
+	Name            string
+	DepartmentName  string
+	JobTitle        string
+	BaseSalary      int64
+	Components      []SalaryComponent
+	UnpaidLeaveDays int
+	PTKPStatus      string
+	IsBPJSEnrolled  bool
+	JKKRiskClass    int
+	// IsLeaving is set when the employee is terminated within the period,
+	// which makes it the last month of their tax year.
+	IsLeaving bool
+	// YearToDate sums the locked runs of the earlier months of the year.
+	YearToDate YearToDate
+}
+
+type YearToDate struct {
+	Months            int
+	TaxableIncome     int64
+	TaxDeductible     int64
+	IncomeTaxWithheld int64
+}
+
+// PayrollLine is one earning, deduction or employer contribution of a
+// payroll item. IsTaxable marks earnings and employer contributions that are
+// taxable income, and deductions that are deductible from it.
+type PayrollLine struct {
+	Code      string        `json:"code"`
+	Name      string        `json:"name"`
+	Type      ComponentType `json:"type"`
+	Amount    int64         `json:"amount"`
+	IsTaxable bool          `json:"isTaxable"`
+}
+
+type PayrollItem struct {
+	PayrollItemId   string        `json:"payrollItemId"`
+	EmployeeId      int64         `json:"-"`
+	IdentityNumber  string        `json:"identityNumber"`
+	Name            string        `json:"name"`
+	DepartmentName  string        `json:"departmentName"`
+	JobTitle        string        `json:"jobTitle"`
+	BaseSalary      int64         `json:"baseSalary"`
+	Gross           int64         `json:"gross"`
+	TotalDeductions int64         `json:"totalDeductions"`
+	Net             int64         `json:"net"`
+	Lines           []PayrollLine `json:"lines"`
+}
+
+// Total sums the lines of the given type.
+func (i *PayrollItem) Total(lineType ComponentType) int64 {
+	var total int64
+	for _, line := range i.Lines {
+		if line.Type == lineType {
+			total += line.Amount
+		}
+	}
+
+	return total
+}
+
+// TaxableIncome sums the taxable earnings and employer contributions.
+func (i *PayrollItem) TaxableIncome() int64 {
+	var total int64
+	for _, line := range i.Lines {
+		if line.Type != ComponentTypeDeduction && line.IsTaxable {
+			total += line.Amount
+		}
+	}
+
+	return total
+}
+
+// TaxDeductible sums the deductions that are deductible from taxable income.
+func (i *PayrollItem) TaxDeductible() int64 {
+	var total int64
+	for _, line := range i.Lines {
+		if line.Type == ComponentTypeDeduction && line.IsTaxable {
+			total += line.Amount
+		}
+	}
+
+	return total
+}
+
+type PayrollRun struct {
+	PayrollRunId    string        `json:"payrollRunId"`
+	Period          string        `json:"period"`
+	Status          RunStatus     `json:"status"`
+	EmployeeCount   int           `json:"employeeCount"`
+	Gross           int64         `json:"gross"`
+	TotalDeductions int64         `json:"totalDeductions"`
+	Net             int64         `json:"net"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	CalculatedAt    time.Time     `json:"calculatedAt"`
+	LockedAt        *time.Time    `json:"lockedAt"`
+	LockedBy        string        `json:"lockedBy"`
+	Items           []PayrollItem `json:"items,omitempty"`
+}
+
+// Payslip is the payroll item of one employee with the run it belongs to.
+type Payslip struct {
+	Period string
+	Status RunStatus
+	Item   PayrollItem
+}
+
+type PayrollPeriodPayload struct {
+	Period string `json:"period" query:"period" validate:"required,datetime=2006-01"`
+}
+
+type GetPayrollRunParams struct {
+	Limit  int
+	Offset int
+}
+
+type PayrollRunPathParam struct {
+	PayrollRunId int `param:"payrollRunId" validate:"required,min=1"`
+}
+
+type PayrollItemPathParam struct {
+	PayrollRunId   int    `param:"payrollRunId" validate:"required,min=1"`
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+}
+
+// CalculateMonthlyPayload is the input of the standalone calculator. Gross is
+// the monthly taxable income, which is also taken as the BPJS wage. The rules
+// are those of Version, or else those in force in Period, or else today.
+type CalculateMonthlyPayload struct {
+	Period         string `json:"period" validate:"omitempty,datetime=2006-01"`
+	Version        string `json:"version" validate:"omitempty,max=16"`
+	PTKPStatus     string `json:"ptkpStatus" validate:"required,oneof=TK/0 TK/1 TK/2 TK/3 K/0 K/1 K/2 K/3"`
+	Gross          int64  `json:"gross" validate:"min=0"`
+	IsBPJSEnrolled *bool  `json:"isBpjsEnrolled"`
+	JKKRiskClass   int    `json:"jkkRiskClass" validate:"omitempty,min=1,max=5"`
+}
+
+type MonthlyCalculation struct {
+	Version       string                       `json:"version"`
+	Gross         int64                        `json:"gross"`
+	BPJS          *statutory.BPJSContributions `json:"bpjs"`
+	TaxableIncome int64                        `json:"taxableIncome"`
+	PPh21         *statutory.MonthlyTax        `json:"pph21"`
+	TakeHomePay   int64                        `json:"takeHomePay"`
+}
+
+type CalculateAnnualPayload struct {
+	Year                 int    `json:"year" validate:"omitempty,min=2024,max=2200"`
+	Version              string `json:"version" validate:"omitempty,max=16"`
+	PTKPStatus           string `json:"ptkpStatus" validate:"required,oneof=TK/0 TK/1 TK/2 TK/3 K/0 K/1 K/2 K/3"`
+	Months               int    `json:"months" validate:"omitempty,min=1,max=12"`
+	Gross                int64  `json:"gross" validate:"min=0"`
+	PensionContributions int64  `json:"pensionContributions" validate:"min=0"`
+	Withheld             int64  `json:"withheld" validate:"min=0"`
+}
+
+type AnnualCalculation struct {
+	Version string `json:"version"`
+	statutory.AnnualTax
+}
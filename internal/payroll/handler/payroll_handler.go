@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"ps-gogo-manajer/internal/payroll/dto"
+	"ps-gogo-manajer/internal/payroll/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	customValidators "ps-gogo-manajer/pkg/custom-validators"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type PayrollHandler struct {
+	payrollUsecase usecase.PayrollUsecase
+	validator      *validator.Validate
+}
+
+const (
+	DEFAULT_LIMIT  = 5
+	DEFAULT_OFFSET = 0
+)
+
+func NewPayrollHandler(payrollUsecase usecase.PayrollUsecase, validator *validator.Validate) *PayrollHandler {
+	return &PayrollHandler{
+		payrollUsecase: payrollUsecase,
+		validator:      validator,
+	}
+}
+
+// bind binds the request into payload and validates it, wrapping any failure
+// as a bad request.
+func (h PayrollHandler) bind(ctx echo.Context, payload any) error {
+	if err := ctx.Bind(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (h PayrollHandler) SetSalaryStructure(ctx echo.Context) error {
+	var payload dto.SetSalaryStructurePayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	structure, err := h.payrollUsecase.SetSalaryStructure(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, structure)
+}
+
+func (h PayrollHandler) GetSalaryStructure(ctx echo.Context) error {
+	var payload dto.GetSalaryStructureParams
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	structure, err := h.payrollUsecase.GetSalaryStructure(ctx.Request().Context(), userData.Id, payload.IdentityNumber)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, structure)
+}
+
+func (h PayrollHandler) PreviewPayroll(ctx echo.Context) error {
+	var payload dto.PayrollPeriodPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	run, err := h.payrollUsecase.PreviewPayroll(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, run)
+}
+
+func (h PayrollHandler) CreatePayrollRun(ctx echo.Context) error {
+	var payload dto.PayrollPeriodPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	run, err := h.payrollUsecase.CreatePayrollRun(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, run)
+}
+
+func (h PayrollHandler) GetListPayrollRun(ctx echo.Context) error {
+	limitStr := ctx.QueryParam("limit")
+	offsetStr := ctx.QueryParam("offset")
+
+	params := dto.GetPayrollRunParams{
+		Limit:  customValidators.ParseLimitOffset(limitStr, DEFAULT_LIMIT),
+		Offset: customValidators.ParseLimitOffset(offsetStr, DEFAULT_OFFSET),
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	runs, err := h.payrollUsecase.GetListPayrollRun(ctx.Request().Context(), userData.Id, &params)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, runs)
+}
+
+func (h PayrollHandler) GetPayrollRun(ctx echo.Context) error {
+	var pathParam dto.PayrollRunPathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	run, err := h.payrollUsecase.GetPayrollRun(ctx.Request().Context(), userData.Id, pathParam.PayrollRunId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, run)
+}
+
+func (h PayrollHandler) RecalculatePayrollRun(ctx echo.Context) error {
+	var pathParam dto.PayrollRunPathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	run, err := h.payrollUsecase.RecalculatePayrollRun(ctx.Request().Context(), userData.Id, pathParam.PayrollRunId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, run)
+}
+
+func (h PayrollHandler) LockPayrollRun(ctx echo.Context) error {
+	var pathParam dto.PayrollRunPathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	run, err := h.payrollUsecase.LockPayrollRun(ctx.Request().Context(), userData.Id, pathParam.PayrollRunId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, run)
+}
+
+func (h PayrollHandler) ReopenPayrollRun(ctx echo.Context) error {
+	var pathParam dto.PayrollRunPathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	run, err := h.payrollUsecase.ReopenPayrollRun(ctx.Request().Context(), userData.Id, pathParam.PayrollRunId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, run)
+}
+
+func (h PayrollHandler) DeletePayrollRun(ctx echo.Context) error {
+	var pathParam dto.PayrollRunPathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	err := h.payrollUsecase.DeletePayrollRun(ctx.Request().Context(), userData.Id, pathParam.PayrollRunId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h PayrollHandler) GetPayrollItem(ctx echo.Context) error {
+	var pathParam dto.PayrollItemPathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	item, err := h.payrollUsecase.GetPayrollItem(ctx.Request().Context(), userData.Id, &pathParam)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, item)
+}
+
+func (h PayrollHandler) ExportPayrollRun(ctx echo.Context) error {
+	var pathParam dto.PayrollRunPathParam
+	if err := h.bind(ctx, &pathParam); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	fileName, records, err := h.payrollUsecase.ExportPayrollRun(ctx.Request().Context(), userData.Id, pathParam.PayrollRunId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	res.WriteHeader(http.StatusOK)
+
+	for _, record := range records {
+		for i := range record {
+			record[i] = escapeCSVCell(record[i])
+		}
+	}
+
+	writer := csv.NewWriter(res)
+	return writer.WriteAll(records)
+}
+
+// escapeCSVCell keeps spreadsheets from reading a cell as a formula by
+// prefixing it with a quote. Amounts are left as they are, a negative number
+// is not a formula.
+func escapeCSVCell(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return value
+	}
+
+	return "'" + value
+}
+
+func (h PayrollHandler) CalculateMonthly(ctx echo.Context) error {
+	var payload dto.CalculateMonthlyPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	result, err := h.payrollUsecase.CalculateMonthly(&payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h PayrollHandler) CalculateAnnual(ctx echo.Context) error {
+	var payload dto.CalculateAnnualPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	result, err := h.payrollUsecase.CalculateAnnual(&payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (h PayrollHandler) GetStatutoryVersions(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, h.payrollUsecase.GetStatutoryVersions())
+}
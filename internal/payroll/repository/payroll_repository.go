@@ -0,0 +1,713 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/payroll/dto"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type PayrollRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPayrollRepository(pool *pgxpool.Pool) *PayrollRepository {
+	return &PayrollRepository{pool: pool}
+}
+
+const (
+	// payrollRunColumns is the column list scanned by scanPayrollRun,
+	// selected from payrollRunFrom.
+	payrollRunColumns = `
+		payroll_runs.id,
+		to_char(payroll_runs.period, 'YYYY-MM'),
+		payroll_runs.status,
+		COUNT(payroll_items.id),
+		COALESCE(SUM(payroll_items.gross), 0)::bigint,
+		COALESCE(SUM(payroll_items.total_deductions), 0)::bigint,
+		COALESCE(SUM(payroll_items.net), 0)::bigint,
+		payroll_runs.created_at,
+		payroll_runs.calculated_at,
+		payroll_runs.locked_at,
+		users.email`
+	payrollRunFrom = `
+	FROM payroll_runs
+	LEFT JOIN payroll_items ON payroll_items.payroll_run_id = payroll_runs.id
+	LEFT JOIN users ON users.id = payroll_runs.locked_by`
+	payrollRunGroupBy = `
+	GROUP BY payroll_runs.id, users.email`
+	payrollItemColumns = `
+		id,
+		employee_id,
+		identity_number,
+		name,
+		department_name,
+		job_title,
+		base_salary,
+		gross,
+		total_deductions,
+		net,
+		lines`
+
+	queryCheckIfEmployeeExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND identity_number = @identityNumber
+			AND deleted_at IS NULL
+	) is_exists;`
+
+	queryGetJobTitleSalaryRange = `
+	SELECT
+		job_titles.name,
+		job_titles.salary_min,
+		job_titles.salary_max
+	FROM employees
+	JOIN job_titles ON job_titles.id = employees.job_title_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL;`
+
+	queryUpsertSalaryStructure = `
+	INSERT INTO salary_structures(employee_id, base_salary, ptkp_status, is_bpjs_enrolled, jkk_risk_class)
+	SELECT id, @baseSalary, @ptkpStatus, @isBpjsEnrolled, @jkkRiskClass
+	FROM employees
+	WHERE
+		user_id = @userID
+		AND identity_number = @identityNumber
+		AND deleted_at IS NULL
+	ON CONFLICT (employee_id) DO UPDATE
+	SET
+		base_salary = EXCLUDED.base_salary,
+		ptkp_status = EXCLUDED.ptkp_status,
+		is_bpjs_enrolled = EXCLUDED.is_bpjs_enrolled,
+		jkk_risk_class = EXCLUDED.jkk_risk_class,
+		updated_at = NOW()
+	RETURNING employee_id;`
+	queryDeleteSalaryComponents = `
+	DELETE FROM salary_components
+	WHERE employee_id = @employeeID;`
+	queryInsertSalaryComponent = `
+	INSERT INTO salary_components(employee_id, code, name, type, amount, is_taxable, position)
+	VALUES (@employeeID, @code, @name, @type, @amount, @isTaxable, @position);`
+	queryGetSalaryStructure = `
+	SELECT
+		salary_structures.employee_id,
+		employees.identity_number,
+		salary_structures.base_salary,
+		salary_structures.ptkp_status,
+		salary_structures.is_bpjs_enrolled,
+		salary_structures.jkk_risk_class,
+		salary_structures.updated_at
+	FROM salary_structures
+	JOIN employees ON employees.id = salary_structures.employee_id
+	WHERE
+		employees.user_id = @userID
+		AND employees.identity_number = @identityNumber
+		AND employees.deleted_at IS NULL;`
+	queryGetSalaryComponents = `
+	SELECT employee_id, code, name, type, amount, is_taxable
+	FROM salary_components
+	WHERE employee_id = ANY(@employeeIDs)
+	ORDER BY employee_id, position;`
+
+	// queryGetPayrollEmployees returns the employees with a salary who are
+	// employed during the period, with the working days of approved unpaid
+	// leave that fall within it and the totals of the locked runs of the
+	// earlier months of the year.
+	queryGetPayrollEmployees = `
+	SELECT
+		employees.id,
+		employees.identity_number,
+		employees.name,
+		departments.name,
+		COALESCE(employees.job_title, ''),
+		salary_structures.base_salary,
+		(
+			SELECT COUNT(*)
+			FROM leave_requests
+			JOIN leave_types ON leave_types.id = leave_requests.leave_type_id
+			CROSS JOIN LATERAL generate_series(
+				GREATEST(leave_requests.start_date, @periodStart::date),
+				LEAST(leave_requests.end_date, @periodEnd::date),
+				INTERVAL '1 day'
+			) day
+			WHERE
+				leave_requests.employee_id = employees.id
+				AND leave_requests.status = 'approved'
+				AND NOT leave_types.is_paid
+				AND EXTRACT(ISODOW FROM day) < 6
+				AND NOT EXISTS (
+					SELECT id
+					FROM holidays
+					WHERE
+						holidays.user_id = employees.user_id
+						AND holidays.date = day::date
+				)
+		),
+		salary_structures.ptkp_status,
+		salary_structures.is_bpjs_enrolled,
+		salary_structures.jkk_risk_class,
+		employees.status = 'terminated' AND employees.status_effective_date <= @periodEnd::date,
+		year_to_date.months,
+		year_to_date.taxable_income,
+		year_to_date.tax_deductible,
+		year_to_date.income_tax_withheld
+	FROM employees
+	JOIN salary_structures ON salary_structures.employee_id = employees.id
+	JOIN departments ON departments.id = employees.department_id
+	CROSS JOIN LATERAL (
+		SELECT
+			COUNT(DISTINCT payroll_items.id) months,
+			COALESCE(SUM(line.amount) FILTER (WHERE line.type <> 'deduction' AND line."isTaxable"), 0)::bigint taxable_income,
+			COALESCE(SUM(line.amount) FILTER (WHERE line.type = 'deduction' AND line."isTaxable"), 0)::bigint tax_deductible,
+			(
+				COALESCE(SUM(line.amount) FILTER (WHERE line.code = @incomeTaxCode), 0)
+				- COALESCE(SUM(line.amount) FILTER (WHERE line.code = @incomeTaxRefundCode), 0)
+			)::bigint income_tax_withheld
+		FROM payroll_items
+		JOIN payroll_runs ON payroll_runs.id = payroll_items.payroll_run_id
+		CROSS JOIN LATERAL jsonb_to_recordset(payroll_items.lines) AS line(code TEXT, type TEXT, amount BIGINT, "isTaxable" BOOLEAN)
+		WHERE
+			payroll_items.employee_id = employees.id
+			AND payroll_runs.status = 'locked'
+			AND payroll_runs.period >= date_trunc('year', @periodStart::date)
+			AND payroll_runs.period < @periodStart::date
+	) year_to_date
+	WHERE
+		employees.user_id = @userID
+		AND employees.deleted_at IS NULL
+		AND employees.status <> 'candidate'
+		AND NOT (employees.status = 'terminated' AND employees.status_effective_date < @periodStart::date)
+	ORDER BY employees.identity_number;`
+
+	queryCheckIfPayrollRunExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM payroll_runs
+		WHERE
+			user_id = @userID
+			AND period = @period::date
+	) is_exists;`
+	queryCreatePayrollRun = `
+	INSERT INTO payroll_runs(user_id, period)
+	VALUES (@userID, @period::date)
+	RETURNING id;`
+	// The location and the cost center are kept as they are at the time of
+	// the run, for the cost breakdowns.
+	queryInsertPayrollItem = `
+	INSERT INTO payroll_items(payroll_run_id, employee_id, identity_number, name, department_name, job_title, base_salary, gross, total_deductions, net, lines, location_id, cost_center_id)
+	SELECT @payrollRunID, @employeeID, @identityNumber, @name, @departmentName, NULLIF(@jobTitle, ''), @baseSalary, @gross, @totalDeductions, @net, @lines::jsonb, location_id, cost_center_id
+	FROM employees
+	WHERE id = @employeeID;`
+	queryTouchDraftPayrollRun = `
+	UPDATE payroll_runs
+	SET calculated_at = NOW()
+	WHERE
+		user_id = @userID
+		AND id = @payrollRunID
+		AND status = 'draft'
+	RETURNING id;`
+	queryDeletePayrollItems = `
+	DELETE FROM payroll_items
+	WHERE payroll_run_id = @payrollRunID;`
+	queryGetListPayrollRun = `
+	SELECT` + payrollRunColumns + payrollRunFrom + `
+	WHERE payroll_runs.user_id = @userID` + payrollRunGroupBy + `
+	ORDER BY payroll_runs.period DESC
+	OFFSET @offset
+	LIMIT @limit;`
+	queryGetPayrollRun = `
+	SELECT` + payrollRunColumns + payrollRunFrom + `
+	WHERE
+		payroll_runs.user_id = @userID
+		AND payroll_runs.id = @payrollRunID` + payrollRunGroupBy + `;`
+	queryGetPayrollItems = `
+	SELECT` + payrollItemColumns + `
+	FROM payroll_items
+	WHERE
+		payroll_run_id = @payrollRunID
+		AND (NULLIF(@identityNumber, '') IS NULL OR identity_number = @identityNumber)
+	ORDER BY identity_number;`
+	querySetPayrollRunStatus = `
+	UPDATE payroll_runs
+	SET
+		status = @toStatus,
+		locked_at = CASE WHEN @toStatus = 'locked' THEN NOW() END,
+		locked_by = CASE WHEN @toStatus = 'locked' THEN @userID::bigint END
+	WHERE
+		user_id = @userID
+		AND id = @payrollRunID
+		AND status = @fromStatus
+	RETURNING id;`
+	queryDeletePayrollRun = `
+	DELETE FROM payroll_runs
+	WHERE
+		user_id = @userID
+		AND id = @payrollRunID
+		AND status = 'draft';`
+)
+
+func scanPayrollRun(row pgx.Row) (*dto.PayrollRun, error) {
+	var run dto.PayrollRun
+	lockedBy := new(pgtype.Text)
+
+	err := row.Scan(
+		&run.PayrollRunId,
+		&run.Period,
+		&run.Status,
+		&run.EmployeeCount,
+		&run.Gross,
+		&run.TotalDeductions,
+		&run.Net,
+		&run.CreatedAt,
+		&run.CalculatedAt,
+		&run.LockedAt,
+		lockedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	run.LockedBy = lockedBy.String
+	return &run, nil
+}
+
+func scanPayrollItem(row pgx.Row) (*dto.PayrollItem, error) {
+	var item dto.PayrollItem
+	employeeID := new(pgtype.Int8)
+	jobTitle := new(pgtype.Text)
+
+	err := row.Scan(
+		&item.PayrollItemId,
+		employeeID,
+		&item.IdentityNumber,
+		&item.Name,
+		&item.DepartmentName,
+		jobTitle,
+		&item.BaseSalary,
+		&item.Gross,
+		&item.TotalDeductions,
+		&item.Net,
+		&item.Lines,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	item.EmployeeId = employeeID.Int64
+	item.JobTitle = jobTitle.String
+	return &item, nil
+}
+
+func (r *PayrollRepository) CheckIfEmployeeExists(ctx context.Context, userID int, identityNumber string) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCheckIfEmployeeExists, args).Scan(&isExists); err != nil {
+		return false, errors.Wrap(err, "failed to check employee")
+	}
+
+	return isExists, nil
+}
+
+// GetJobTitleSalaryRange returns the job title of the employee with its
+// salary range. It returns pgx.ErrNoRows when the employee holds no title of
+// the catalog.
+func (r *PayrollRepository) GetJobTitleSalaryRange(ctx context.Context, userID int, identityNumber string) (string, *int64, *int64, error) {
+	var name string
+	var salaryMin, salaryMax *int64
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryGetJobTitleSalaryRange, args).Scan(&name, &salaryMin, &salaryMax)
+	if err != nil {
+		return "", nil, nil, errors.Wrap(err, "failed to get job title salary range")
+	}
+
+	return name, salaryMin, salaryMax, nil
+}
+
+// SetSalaryStructure replaces the base salary and components of an employee.
+// It returns pgx.ErrNoRows when the employee does not exist.
+func (r *PayrollRepository) SetSalaryStructure(ctx context.Context, userID int, payload *dto.SetSalaryStructurePayload) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var employeeID int64
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": payload.IdentityNumber,
+		"baseSalary":     payload.BaseSalary,
+		"ptkpStatus":     payload.PTKPStatus,
+		"isBpjsEnrolled": payload.IsBPJSEnrolled,
+		"jkkRiskClass":   payload.JKKRiskClass,
+	}
+	if err := tx.QueryRow(ctx, queryUpsertSalaryStructure, args).Scan(&employeeID); err != nil {
+		return errors.Wrap(err, "failed to set salary structure")
+	}
+
+	batch := &pgx.Batch{}
+	batch.Queue(queryDeleteSalaryComponents, pgx.NamedArgs{"employeeID": employeeID})
+	for position, component := range payload.Components {
+		batch.Queue(queryInsertSalaryComponent, pgx.NamedArgs{
+			"employeeID": employeeID,
+			"code":       component.Code,
+			"name":       component.Name,
+			"type":       component.Type,
+			"amount":     component.Amount,
+			"isTaxable":  component.IsTaxable,
+			"position":   position,
+		})
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return errors.Wrap(err, "failed to set salary components")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to commit salary structure")
+	}
+
+	return nil
+}
+
+// GetSalaryStructure returns pgx.ErrNoRows when the employee has no salary
+// structure yet.
+func (r *PayrollRepository) GetSalaryStructure(ctx context.Context, userID int, identityNumber string) (*dto.SalaryStructure, error) {
+	var employeeID int64
+	var structure dto.SalaryStructure
+	args := pgx.NamedArgs{
+		"userID":         userID,
+		"identityNumber": identityNumber,
+	}
+
+	err := r.pool.QueryRow(ctx, queryGetSalaryStructure, args).Scan(
+		&employeeID,
+		&structure.IdentityNumber,
+		&structure.BaseSalary,
+		&structure.PTKPStatus,
+		&structure.IsBPJSEnrolled,
+		&structure.JKKRiskClass,
+		&structure.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get salary structure")
+	}
+
+	components, err := r.getSalaryComponents(ctx, []int64{employeeID})
+	if err != nil {
+		return nil, err
+	}
+
+	structure.Components = components[employeeID]
+	if structure.Components == nil {
+		structure.Components = make([]dto.SalaryComponent, 0)
+	}
+
+	return &structure, nil
+}
+
+func (r *PayrollRepository) getSalaryComponents(ctx context.Context, employeeIDs []int64) (map[int64][]dto.SalaryComponent, error) {
+	components := make(map[int64][]dto.SalaryComponent, len(employeeIDs))
+	args := pgx.NamedArgs{
+		"employeeIDs": employeeIDs,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetSalaryComponents, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get salary components")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var employeeID int64
+		var component dto.SalaryComponent
+		err := rows.Scan(
+			&employeeID,
+			&component.Code,
+			&component.Name,
+			&component.Type,
+			&component.Amount,
+			&component.IsTaxable,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		components[employeeID] = append(components[employeeID], component)
+	}
+
+	return components, nil
+}
+
+// GetPayrollEmployees returns the employees to be paid for the period that
+// starts at periodStart, together with their salary structure. The income tax
+// withheld so far is summed from the lines with the given codes.
+func (r *PayrollRepository) GetPayrollEmployees(ctx context.Context, userID int, periodStart time.Time, periodEnd time.Time, incomeTaxCode string, incomeTaxRefundCode string) ([]dto.PayrollEmployee, error) {
+	employees := make([]dto.PayrollEmployee, 0)
+	args := pgx.NamedArgs{
+		"userID":              userID,
+		"periodStart":         periodStart,
+		"periodEnd":           periodEnd,
+		"incomeTaxCode":       incomeTaxCode,
+		"incomeTaxRefundCode": incomeTaxRefundCode,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetPayrollEmployees, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get payroll employees")
+	}
+	defer rows.Close()
+
+	employeeIDs := make([]int64, 0)
+	for rows.Next() {
+		var employee dto.PayrollEmployee
+		err := rows.Scan(
+			&employee.EmployeeId,
+			&employee.IdentityNumber,
+			&employee.Name,
+			&employee.DepartmentName,
+			&employee.JobTitle,
+			&employee.BaseSalary,
+			&employee.UnpaidLeaveDays,
+			&employee.PTKPStatus,
+			&employee.IsBPJSEnrolled,
+			&employee.JKKRiskClass,
+			&employee.IsLeaving,
+			&employee.YearToDate.Months,
+			&employee.YearToDate.TaxableIncome,
+			&employee.YearToDate.TaxDeductible,
+			&employee.YearToDate.IncomeTaxWithheld,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		employees = append(employees, employee)
+		employeeIDs = append(employeeIDs, employee.EmployeeId)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get payroll employees")
+	}
+
+	components, err := r.getSalaryComponents(ctx, employeeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range employees {
+		employees[i].Components = components[employees[i].EmployeeId]
+	}
+
+	return employees, nil
+}
+
+func (r *PayrollRepository) CheckIfPayrollRunExists(ctx context.Context, userID int, period time.Time) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"period": period,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCheckIfPayrollRunExists, args).Scan(&isExists); err != nil {
+		return false, errors.Wrap(err, "failed to check payroll run")
+	}
+
+	return isExists, nil
+}
+
+func (r *PayrollRepository) CreatePayrollRun(ctx context.Context, userID int, period time.Time, items []dto.PayrollItem) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	var payrollRunID int
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"period": period,
+	}
+	if err := tx.QueryRow(ctx, queryCreatePayrollRun, args).Scan(&payrollRunID); err != nil {
+		return 0, errors.Wrap(err, "failed to create payroll run")
+	}
+
+	if err := r.insertPayrollItems(ctx, tx, payrollRunID, items); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, errors.Wrap(err, "failed to commit payroll run")
+	}
+
+	return payrollRunID, nil
+}
+
+// ReplacePayrollItems swaps the items of a draft run for freshly calculated
+// ones. It returns pgx.ErrNoRows when the run is not a draft.
+func (r *PayrollRepository) ReplacePayrollItems(ctx context.Context, userID int, payrollRunID int, items []dto.PayrollItem) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"payrollRunID": payrollRunID,
+	}
+	if err := tx.QueryRow(ctx, queryTouchDraftPayrollRun, args).Scan(&payrollRunID); err != nil {
+		return errors.Wrap(err, "failed to recalculate payroll run")
+	}
+
+	if _, err := tx.Exec(ctx, queryDeletePayrollItems, args); err != nil {
+		return errors.Wrap(err, "failed to delete payroll items")
+	}
+
+	if err := r.insertPayrollItems(ctx, tx, payrollRunID, items); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to commit payroll run")
+	}
+
+	return nil
+}
+
+func (r *PayrollRepository) insertPayrollItems(ctx context.Context, tx pgx.Tx, payrollRunID int, items []dto.PayrollItem) error {
+	batch := &pgx.Batch{}
+	for _, item := range items {
+		batch.Queue(queryInsertPayrollItem, pgx.NamedArgs{
+			"payrollRunID":    payrollRunID,
+			"employeeID":      item.EmployeeId,
+			"identityNumber":  item.IdentityNumber,
+			"name":            item.Name,
+			"departmentName":  item.DepartmentName,
+			"jobTitle":        item.JobTitle,
+			"baseSalary":      item.BaseSalary,
+			"gross":           item.Gross,
+			"totalDeductions": item.TotalDeductions,
+			"net":             item.Net,
+			"lines":           item.Lines,
+		})
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return errors.Wrap(err, "failed to create payroll items")
+	}
+
+	return nil
+}
+
+func (r *PayrollRepository) GetListPayrollRun(ctx context.Context, userID int, params *dto.GetPayrollRunParams) ([]dto.PayrollRun, error) {
+	runs := make([]dto.PayrollRun, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"offset": params.Offset,
+		"limit":  params.Limit,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListPayrollRun, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list payroll run")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		run, err := scanPayrollRun(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		runs = append(runs, *run)
+	}
+
+	return runs, nil
+}
+
+func (r *PayrollRepository) GetPayrollRun(ctx context.Context, userID int, payrollRunID int) (*dto.PayrollRun, error) {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"payrollRunID": payrollRunID,
+	}
+
+	run, err := scanPayrollRun(r.pool.QueryRow(ctx, queryGetPayrollRun, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get payroll run")
+	}
+
+	return run, nil
+}
+
+// GetPayrollItems returns the items of a run, or only the item of the given
+// employee when identityNumber is set.
+func (r *PayrollRepository) GetPayrollItems(ctx context.Context, payrollRunID int, identityNumber string) ([]dto.PayrollItem, error) {
+	items := make([]dto.PayrollItem, 0)
+	args := pgx.NamedArgs{
+		"payrollRunID":   payrollRunID,
+		"identityNumber": identityNumber,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetPayrollItems, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get payroll items")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanPayrollItem(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		items = append(items, *item)
+	}
+
+	return items, nil
+}
+
+// SetPayrollRunStatus moves a run from one status to another. It returns
+// pgx.ErrNoRows when the run does not have the from status.
+func (r *PayrollRepository) SetPayrollRunStatus(ctx context.Context, userID int, payrollRunID int, fromStatus dto.RunStatus, toStatus dto.RunStatus) error {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"payrollRunID": payrollRunID,
+		"fromStatus":   fromStatus,
+		"toStatus":     toStatus,
+	}
+
+	if err := r.pool.QueryRow(ctx, querySetPayrollRunStatus, args).Scan(&payrollRunID); err != nil {
+		return errors.Wrap(err, "failed to set payroll run status")
+	}
+
+	return nil
+}
+
+// DeletePayrollRun deletes a draft run and reports whether it did.
+func (r *PayrollRepository) DeletePayrollRun(ctx context.Context, userID int, payrollRunID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"payrollRunID": payrollRunID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeletePayrollRun, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete payroll run")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
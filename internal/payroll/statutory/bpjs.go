@@ -0,0 +1,58 @@
+package statutory
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// BPJSContributions are the monthly BPJS Kesehatan (health) and BPJS
+// Ketenagakerjaan (JHT old age, JP pension, JKK work accident and JKM death)
+// contributions on a wage.
+type BPJSContributions struct {
+	HealthEmployer int64 `json:"healthEmployer"`
+	HealthEmployee int64 `json:"healthEmployee"`
+	JHTEmployer    int64 `json:"jhtEmployer"`
+	JHTEmployee    int64 `json:"jhtEmployee"`
+	JPEmployer     int64 `json:"jpEmployer"`
+	JPEmployee     int64 `json:"jpEmployee"`
+	JKK            int64 `json:"jkk"`
+	JKM            int64 `json:"jkm"`
+}
+
+// BPJSContributions calculates the contributions on the monthly wage, base
+// salary plus fixed allowances. Health and JP contributions are capped at
+// their wage caps.
+func (r *RuleSet) BPJSContributions(wage int64, riskClass int) (*BPJSContributions, error) {
+	jkkRate, ok := r.BPJS.JKK[riskClass]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownRiskClass, strconv.Itoa(riskClass))
+	}
+
+	wage = max(wage, 0)
+	healthWage := min(wage, r.BPJS.HealthWageCap)
+	jpWage := min(wage, r.BPJS.JPWageCap)
+
+	return &BPJSContributions{
+		HealthEmployer: percent(healthWage, r.BPJS.HealthEmployer),
+		HealthEmployee: percent(healthWage, r.BPJS.HealthEmployee),
+		JHTEmployer:    percent(wage, r.BPJS.JHTEmployer),
+		JHTEmployee:    percent(wage, r.BPJS.JHTEmployee),
+		JPEmployer:     percent(jpWage, r.BPJS.JPEmployer),
+		JPEmployee:     percent(jpWage, r.BPJS.JPEmployee),
+		JKK:            percent(wage, jkkRate),
+		JKM:            percent(wage, r.BPJS.JKM),
+	}, nil
+}
+
+// TaxableBenefits are the contributions paid by the employer that count as
+// taxable income of the employee.
+func (c *BPJSContributions) TaxableBenefits() int64 {
+	return c.HealthEmployer + c.JKK + c.JKM
+}
+
+// PensionContributions are the contributions paid by the employee that are
+// deductible from their taxable income.
+func (c *BPJSContributions) PensionContributions() int64 {
+	return c.JHTEmployee + c.JPEmployee
+}
@@ -0,0 +1,179 @@
+package statutory
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBPJSContributions(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		wage      int64
+		riskClass int
+		want      BPJSContributions
+	}{
+		{
+			name:      "below the wage caps",
+			version:   "2025.03",
+			wage:      5_000_000,
+			riskClass: 3,
+			want: BPJSContributions{
+				HealthEmployer: 200_000,
+				HealthEmployee: 50_000,
+				JHTEmployer:    185_000,
+				JHTEmployee:    100_000,
+				JPEmployer:     100_000,
+				JPEmployee:     50_000,
+				JKK:            44_500,
+				JKM:            15_000,
+			},
+		},
+		{
+			name:      "above the wage caps",
+			version:   "2025.03",
+			wage:      20_000_000,
+			riskClass: 1,
+			want: BPJSContributions{
+				HealthEmployer: 480_000,
+				HealthEmployee: 120_000,
+				JHTEmployer:    740_000,
+				JHTEmployee:    400_000,
+				JPEmployer:     210_948,
+				JPEmployee:     105_474,
+				JKK:            48_000,
+				JKM:            60_000,
+			},
+		},
+		{
+			name:      "at the health wage cap",
+			version:   "2024.01",
+			wage:      12_000_000,
+			riskClass: 1,
+			want: BPJSContributions{
+				HealthEmployer: 480_000,
+				HealthEmployee: 120_000,
+				JHTEmployer:    444_000,
+				JHTEmployee:    240_000,
+				JPEmployer:     191_192,
+				JPEmployee:     95_596,
+				JKK:            28_800,
+				JKM:            36_000,
+			},
+		},
+		{
+			name:      "at the JP wage cap",
+			version:   "2024.01",
+			wage:      9_559_600,
+			riskClass: 1,
+			want: BPJSContributions{
+				HealthEmployer: 382_384,
+				HealthEmployee: 95_596,
+				JHTEmployer:    353_705,
+				JHTEmployee:    191_192,
+				JPEmployer:     191_192,
+				JPEmployee:     95_596,
+				JKK:            22_943,
+				JKM:            28_678,
+			},
+		},
+		{
+			name:      "JP wage cap of the version",
+			version:   "2024.03",
+			wage:      20_000_000,
+			riskClass: 1,
+			want: BPJSContributions{
+				HealthEmployer: 480_000,
+				HealthEmployee: 120_000,
+				JHTEmployer:    740_000,
+				JHTEmployee:    400_000,
+				JPEmployer:     200_846,
+				JPEmployee:     100_423,
+				JKK:            48_000,
+				JKM:            60_000,
+			},
+		},
+		{
+			name:      "negative wage",
+			version:   "2025.03",
+			wage:      -1_000_000,
+			riskClass: 1,
+			want:      BPJSContributions{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ruleSet, err := ByVersion(test.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ruleSet.BPJSContributions(test.wage, test.riskClass)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != test.want {
+				t.Errorf("got %+v, want %+v", *got, test.want)
+			}
+		})
+	}
+}
+
+func TestBPJSContributionsRiskClass(t *testing.T) {
+	ruleSet, err := ByVersion("2025.03")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		riskClass int
+		jkk       int64
+		err       error
+	}{
+		{riskClass: 0, err: ErrUnknownRiskClass},
+		{riskClass: 1, jkk: 24_000},
+		{riskClass: 2, jkk: 54_000},
+		{riskClass: 3, jkk: 89_000},
+		{riskClass: 4, jkk: 127_000},
+		{riskClass: 5, jkk: 174_000},
+		{riskClass: 6, err: ErrUnknownRiskClass},
+	}
+
+	for _, test := range tests {
+		got, err := ruleSet.BPJSContributions(10_000_000, test.riskClass)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("risk class %d: got error %v, want %v", test.riskClass, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("risk class %d: unexpected error: %v", test.riskClass, err)
+			continue
+		}
+		if got.JKK != test.jkk {
+			t.Errorf("risk class %d: got JKK %d, want %d", test.riskClass, got.JKK, test.jkk)
+		}
+	}
+}
+
+func TestBPJSContributionsTotals(t *testing.T) {
+	contributions := BPJSContributions{
+		HealthEmployer: 480_000,
+		HealthEmployee: 120_000,
+		JHTEmployer:    740_000,
+		JHTEmployee:    400_000,
+		JPEmployer:     210_948,
+		JPEmployee:     105_474,
+		JKK:            48_000,
+		JKM:            60_000,
+	}
+
+	if got, want := contributions.TaxableBenefits(), int64(588_000); got != want {
+		t.Errorf("got taxable benefits %d, want %d", got, want)
+	}
+	if got, want := contributions.PensionContributions(), int64(505_474); got != want {
+		t.Errorf("got pension contributions %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+package statutory
+
+import "github.com/pkg/errors"
+
+type MonthlyTax struct {
+	Category TERCategory `json:"terCategory"`
+	Rate     int64       `json:"terRateBasisPoints"`
+	Tax      int64       `json:"tax"`
+}
+
+// MonthlyTax withholds PPh 21 for January to November, or any month that is
+// not the last of the tax year, with the effective rate of the gross monthly
+// income.
+func (r *RuleSet) MonthlyTax(status PTKPStatus, gross int64) (*MonthlyTax, error) {
+	category, ok := r.TERCategories[status]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownPTKPStatus, string(status))
+	}
+
+	rate := bracketRate(r.TER[category], gross)
+	return &MonthlyTax{
+		Category: category,
+		Rate:     rate,
+		Tax:      percent(max(gross, 0), rate),
+	}, nil
+}
+
+// AnnualInput is the income of an employee over the months of the tax year
+// they worked, including the last one.
+type AnnualInput struct {
+	Status PTKPStatus
+	Months int
+	// Gross is the taxable income, including taxable benefits paid by the
+	// employer such as the JKK, JKM and health premiums.
+	Gross int64
+	// PensionContributions are the JHT and JP contributions paid by the
+	// employee, which are deductible.
+	PensionContributions int64
+	// Withheld is the PPh 21 withheld in the earlier months of the year.
+	Withheld int64
+}
+
+type AnnualTax struct {
+	Gross                int64 `json:"gross"`
+	OccupationalCost     int64 `json:"occupationalCost"`
+	PensionContributions int64 `json:"pensionContributions"`
+	NetIncome            int64 `json:"netIncome"`
+	PTKP                 int64 `json:"ptkp"`
+	TaxableIncome        int64 `json:"taxableIncome"`
+	Tax                  int64 `json:"tax"`
+	Withheld             int64 `json:"withheld"`
+	// Due is what is left to withhold in the last month, negative when too
+	// much was withheld and the difference has to be refunded.
+	Due int64 `json:"due"`
+}
+
+// AnnualTax reconciles the PPh 21 of a tax year in its last month, December
+// or the month the employee leaves, with the progressive rates of article 17.
+func (r *RuleSet) AnnualTax(input AnnualInput) (*AnnualTax, error) {
+	ptkp, ok := r.PTKP[input.Status]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownPTKPStatus, string(input.Status))
+	}
+
+	months := int64(min(max(input.Months, 1), 12))
+	result := AnnualTax{
+		Gross:                input.Gross,
+		OccupationalCost:     min(percent(input.Gross, r.OccupationalCostRate), r.OccupationalCostMonthlyCap*months),
+		PensionContributions: input.PensionContributions,
+		PTKP:                 ptkp,
+		Withheld:             input.Withheld,
+	}
+	result.NetIncome = max(result.Gross-result.OccupationalCost-result.PensionContributions, 0)
+
+	// Taxable income is rounded down to whole thousands of rupiah.
+	result.TaxableIncome = max(result.NetIncome-result.PTKP, 0) / 1000 * 1000
+	result.Tax = progressiveTax(r.IncomeTax, result.TaxableIncome)
+	result.Due = result.Tax - result.Withheld
+	return &result, nil
+}
+
+// bracketRate returns the rate of the bracket the amount falls in.
+func bracketRate(brackets []Bracket, amount int64) int64 {
+	for _, bracket := range brackets {
+		if bracket.UpTo == 0 || amount <= bracket.UpTo {
+			return bracket.Rate
+		}
+	}
+
+	return 0
+}
+
+// progressiveTax taxes every slice of the amount at the rate of its bracket.
+func progressiveTax(brackets []Bracket, amount int64) int64 {
+	var tax, lowerBound int64
+	for _, bracket := range brackets {
+		if amount <= lowerBound {
+			break
+		}
+
+		upperBound := amount
+		if bracket.UpTo != 0 && bracket.UpTo < amount {
+			upperBound = bracket.UpTo
+		}
+
+		tax += percent(upperBound-lowerBound, bracket.Rate)
+		lowerBound = bracket.UpTo
+		if bracket.UpTo == 0 {
+			break
+		}
+	}
+
+	return tax
+}
@@ -0,0 +1,269 @@
+package statutory
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTERCategories(t *testing.T) {
+	want := map[PTKPStatus]TERCategory{
+		PTKPStatusTK0: TERCategoryA,
+		PTKPStatusTK1: TERCategoryA,
+		PTKPStatusK0:  TERCategoryA,
+		PTKPStatusTK2: TERCategoryB,
+		PTKPStatusTK3: TERCategoryB,
+		PTKPStatusK1:  TERCategoryB,
+		PTKPStatusK2:  TERCategoryB,
+		PTKPStatusK3:  TERCategoryC,
+	}
+
+	for _, ruleSet := range ruleSets {
+		for status, category := range want {
+			got, err := ruleSet.MonthlyTax(status, 10_000_000)
+			if err != nil {
+				t.Errorf("%s %s: unexpected error: %v", ruleSet.Version, status, err)
+				continue
+			}
+			if got.Category != category {
+				t.Errorf("%s %s: got category %s, want %s", ruleSet.Version, status, got.Category, category)
+			}
+		}
+
+		if _, err := ruleSet.MonthlyTax("X/0", 10_000_000); !errors.Is(err, ErrUnknownPTKPStatus) {
+			t.Errorf("%s: got error %v, want %v", ruleSet.Version, err, ErrUnknownPTKPStatus)
+		}
+	}
+}
+
+func TestMonthlyTaxBrackets(t *testing.T) {
+	ruleSet, err := ByVersion("2025.03")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		status PTKPStatus
+		gross  int64
+		rate   int64
+		tax    int64
+	}{
+		{name: "A zero income", status: PTKPStatusTK0, gross: 0, rate: 0, tax: 0},
+		{name: "A top of the first bracket", status: PTKPStatusTK0, gross: 5_400_000, rate: 0, tax: 0},
+		{name: "A above the first bracket", status: PTKPStatusTK0, gross: 5_400_001, rate: 25, tax: 13_500},
+		{name: "A top of the second bracket", status: PTKPStatusTK1, gross: 5_650_000, rate: 25, tax: 14_125},
+		{name: "A above the second bracket", status: PTKPStatusK0, gross: 5_650_001, rate: 50, tax: 28_250},
+		{name: "A middle bracket", status: PTKPStatusTK0, gross: 10_000_000, rate: 200, tax: 200_000},
+		{name: "A top of the last bounded bracket", status: PTKPStatusTK0, gross: 1_400_000_000, rate: 3300, tax: 462_000_000},
+		{name: "A unbounded bracket", status: PTKPStatusTK0, gross: 1_400_000_001, rate: 3400, tax: 476_000_000},
+		{name: "B top of the first bracket", status: PTKPStatusTK2, gross: 6_200_000, rate: 0, tax: 0},
+		{name: "B above the first bracket", status: PTKPStatusTK2, gross: 6_200_001, rate: 25, tax: 15_500},
+		{name: "B top of the second bracket", status: PTKPStatusK1, gross: 6_500_000, rate: 25, tax: 16_250},
+		{name: "B above the second bracket", status: PTKPStatusK2, gross: 6_500_001, rate: 50, tax: 32_500},
+		{name: "B top of the last bounded bracket", status: PTKPStatusTK3, gross: 1_405_000_000, rate: 3300, tax: 463_650_000},
+		{name: "B unbounded bracket", status: PTKPStatusTK3, gross: 1_405_000_001, rate: 3400, tax: 477_700_000},
+		{name: "C top of the first bracket", status: PTKPStatusK3, gross: 6_600_000, rate: 0, tax: 0},
+		{name: "C above the first bracket", status: PTKPStatusK3, gross: 6_600_001, rate: 25, tax: 16_500},
+		{name: "C top of the second bracket", status: PTKPStatusK3, gross: 6_950_000, rate: 25, tax: 17_375},
+		{name: "C above the second bracket", status: PTKPStatusK3, gross: 6_950_001, rate: 50, tax: 34_750},
+		{name: "C top of the last bounded bracket", status: PTKPStatusK3, gross: 1_419_000_000, rate: 3300, tax: 468_270_000},
+		{name: "C unbounded bracket", status: PTKPStatusK3, gross: 1_419_000_001, rate: 3400, tax: 482_460_000},
+		{name: "negative income", status: PTKPStatusTK0, gross: -1, rate: 0, tax: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ruleSet.MonthlyTax(test.status, test.gross)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Rate != test.rate {
+				t.Errorf("got rate %d, want %d", got.Rate, test.rate)
+			}
+			if got.Tax != test.tax {
+				t.Errorf("got tax %d, want %d", got.Tax, test.tax)
+			}
+		})
+	}
+}
+
+// TestTERTables checks every boundary of the tables: the top of a bracket
+// takes its rate and the next rupiah the rate of the next bracket.
+func TestTERTables(t *testing.T) {
+	tables := map[TERCategory][]Bracket{
+		TERCategoryA: terA,
+		TERCategoryB: terB,
+		TERCategoryC: terC,
+	}
+
+	for category, brackets := range tables {
+		last := brackets[len(brackets)-1]
+		if last.UpTo != 0 {
+			t.Errorf("%s: last bracket is bounded at %d", category, last.UpTo)
+		}
+
+		for i, bracket := range brackets[:len(brackets)-1] {
+			next := brackets[i+1]
+			if next.UpTo != 0 && next.UpTo <= bracket.UpTo {
+				t.Errorf("%s: bracket %d is not above bracket %d", category, i+1, i)
+			}
+			if next.Rate <= bracket.Rate {
+				t.Errorf("%s: rate of bracket %d is not above bracket %d", category, i+1, i)
+			}
+
+			if got := bracketRate(brackets, bracket.UpTo); got != bracket.Rate {
+				t.Errorf("%s: rate at %d is %d, want %d", category, bracket.UpTo, got, bracket.Rate)
+			}
+			if got := bracketRate(brackets, bracket.UpTo+1); got != next.Rate {
+				t.Errorf("%s: rate at %d is %d, want %d", category, bracket.UpTo+1, got, next.Rate)
+			}
+		}
+	}
+}
+
+func TestProgressiveTax(t *testing.T) {
+	ruleSet, err := ByVersion("2025.03")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		amount int64
+		want   int64
+	}{
+		{amount: 0, want: 0},
+		{amount: 1_000, want: 50},
+		{amount: 60_000_000, want: 3_000_000},
+		{amount: 60_001_000, want: 3_000_150},
+		{amount: 250_000_000, want: 31_500_000},
+		{amount: 250_001_000, want: 31_500_250},
+		{amount: 500_000_000, want: 94_000_000},
+		{amount: 500_001_000, want: 94_000_300},
+		{amount: 5_000_000_000, want: 1_444_000_000},
+		{amount: 5_000_001_000, want: 1_444_000_350},
+		{amount: 6_000_000_000, want: 1_794_000_000},
+	}
+
+	for _, test := range tests {
+		if got := progressiveTax(ruleSet.IncomeTax, test.amount); got != test.want {
+			t.Errorf("progressiveTax(%d) = %d, want %d", test.amount, got, test.want)
+		}
+	}
+}
+
+func TestAnnualTax(t *testing.T) {
+	ruleSet, err := ByVersion("2025.03")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		input AnnualInput
+		want  AnnualTax
+	}{
+		{
+			name: "over withheld is refunded",
+			input: AnnualInput{
+				Status:               PTKPStatusTK0,
+				Months:               12,
+				Gross:                120_000_000,
+				PensionContributions: 2_400_000,
+				Withheld:             10_000_000,
+			},
+			want: AnnualTax{
+				Gross:                120_000_000,
+				OccupationalCost:     6_000_000,
+				PensionContributions: 2_400_000,
+				NetIncome:            111_600_000,
+				PTKP:                 54_000_000,
+				TaxableIncome:        57_600_000,
+				Tax:                  2_880_000,
+				Withheld:             10_000_000,
+				Due:                  -7_120_000,
+			},
+		},
+		{
+			name: "under withheld is due",
+			input: AnnualInput{
+				Status:   PTKPStatusK1,
+				Months:   6,
+				Gross:    150_000_000,
+				Withheld: 6_000_000,
+			},
+			want: AnnualTax{
+				Gross:            150_000_000,
+				OccupationalCost: 3_000_000,
+				NetIncome:        147_000_000,
+				PTKP:             63_000_000,
+				TaxableIncome:    84_000_000,
+				Tax:              6_600_000,
+				Withheld:         6_000_000,
+				Due:              600_000,
+			},
+		},
+		{
+			name: "taxable income rounded down to thousands",
+			input: AnnualInput{
+				Status: PTKPStatusTK0,
+				Months: 12,
+				Gross:  100_000_999,
+			},
+			want: AnnualTax{
+				Gross:            100_000_999,
+				OccupationalCost: 5_000_049,
+				NetIncome:        95_000_950,
+				PTKP:             54_000_000,
+				TaxableIncome:    41_000_000,
+				Tax:              2_050_000,
+				Due:              2_050_000,
+			},
+		},
+		{
+			name: "income below PTKP refunds everything withheld",
+			input: AnnualInput{
+				Status:   PTKPStatusK3,
+				Months:   12,
+				Gross:    60_000_000,
+				Withheld: 150_000,
+			},
+			want: AnnualTax{
+				Gross:            60_000_000,
+				OccupationalCost: 3_000_000,
+				NetIncome:        57_000_000,
+				PTKP:             72_000_000,
+				Withheld:         150_000,
+				Due:              -150_000,
+			},
+		},
+		{
+			name: "months are at least one",
+			input: AnnualInput{
+				Status: PTKPStatusTK0,
+				Gross:  12_000_000,
+			},
+			want: AnnualTax{
+				Gross:            12_000_000,
+				OccupationalCost: 500_000,
+				NetIncome:        11_500_000,
+				PTKP:             54_000_000,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ruleSet.AnnualTax(test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != test.want {
+				t.Errorf("got %+v, want %+v", *got, test.want)
+			}
+		})
+	}
+
+	if _, err := ruleSet.AnnualTax(AnnualInput{Status: "X/0"}); !errors.Is(err, ErrUnknownPTKPStatus) {
+		t.Errorf("got error %v, want %v", err, ErrUnknownPTKPStatus)
+	}
+}
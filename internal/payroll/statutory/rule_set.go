@@ -0,0 +1,187 @@
+// Package statutory holds the Indonesian statutory payroll rules: PPh 21
+// income tax and BPJS contributions. Rates are kept in versioned rule sets so
+// a period is always calculated with the rules that were in force then.
+package statutory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnknownVersion    = errors.New("unknown statutory rule version")
+	ErrNoRuleSet         = errors.New("no statutory rules in force for the date")
+	ErrUnknownPTKPStatus = errors.New("unknown PTKP status")
+	ErrUnknownRiskClass  = errors.New("unknown JKK risk class")
+)
+
+// PTKPStatus is the marital status and number of dependents that set the
+// non-taxable income, TK for single and K for married.
+type PTKPStatus string
+
+const (
+	PTKPStatusTK0 PTKPStatus = "TK/0"
+	PTKPStatusTK1 PTKPStatus = "TK/1"
+	PTKPStatusTK2 PTKPStatus = "TK/2"
+	PTKPStatusTK3 PTKPStatus = "TK/3"
+	PTKPStatusK0  PTKPStatus = "K/0"
+	PTKPStatusK1  PTKPStatus = "K/1"
+	PTKPStatusK2  PTKPStatus = "K/2"
+	PTKPStatusK3  PTKPStatus = "K/3"
+)
+
+// TERCategory selects the table of effective monthly rates.
+type TERCategory string
+
+const (
+	TERCategoryA TERCategory = "A"
+	TERCategoryB TERCategory = "B"
+	TERCategoryC TERCategory = "C"
+)
+
+// Bracket applies Rate, in basis points, to amounts up to and including UpTo.
+// The last bracket of a table has no upper bound and leaves UpTo zero.
+type Bracket struct {
+	UpTo int64
+	Rate int64
+}
+
+type BPJSRates struct {
+	HealthEmployer int64
+	HealthEmployee int64
+	HealthWageCap  int64
+	JHTEmployer    int64
+	JHTEmployee    int64
+	JPEmployer     int64
+	JPEmployee     int64
+	JPWageCap      int64
+	JKM            int64
+	// JKK is the work accident rate by risk class, 1 lowest to 5 highest.
+	JKK map[int]int64
+}
+
+// RuleSet is one version of the statutory rules. Rates are in basis points
+// and amounts in rupiah.
+type RuleSet struct {
+	Version       string
+	EffectiveFrom time.Time
+	PTKP          map[PTKPStatus]int64
+	TERCategories map[PTKPStatus]TERCategory
+	TER           map[TERCategory][]Bracket
+	// IncomeTax is the progressive annual rate of article 17.
+	IncomeTax []Bracket
+	// OccupationalCost is the biaya jabatan deducted from gross income in
+	// the annual calculation, capped per month worked.
+	OccupationalCostRate       int64
+	OccupationalCostMonthlyCap int64
+	BPJS                       BPJSRates
+}
+
+// ruleSets is sorted by EffectiveFrom, oldest first.
+var ruleSets = []RuleSet{
+	newRuleSet("2024.01", "2024-01-01", 9_559_600),
+	newRuleSet("2024.03", "2024-03-01", 10_042_300),
+	newRuleSet("2025.03", "2025-03-01", 10_547_400),
+}
+
+// newRuleSet builds a version of the rules of PP 58/2023 and PMK 168/2023,
+// which only differ in the yearly indexed JP wage cap.
+func newRuleSet(version string, effectiveFrom string, jpWageCap int64) RuleSet {
+	date, _ := time.Parse("2006-01-02", effectiveFrom)
+
+	return RuleSet{
+		Version:       version,
+		EffectiveFrom: date,
+		PTKP: map[PTKPStatus]int64{
+			PTKPStatusTK0: 54_000_000,
+			PTKPStatusTK1: 58_500_000,
+			PTKPStatusTK2: 63_000_000,
+			PTKPStatusTK3: 67_500_000,
+			PTKPStatusK0:  58_500_000,
+			PTKPStatusK1:  63_000_000,
+			PTKPStatusK2:  67_500_000,
+			PTKPStatusK3:  72_000_000,
+		},
+		TERCategories: map[PTKPStatus]TERCategory{
+			PTKPStatusTK0: TERCategoryA,
+			PTKPStatusTK1: TERCategoryA,
+			PTKPStatusK0:  TERCategoryA,
+			PTKPStatusTK2: TERCategoryB,
+			PTKPStatusTK3: TERCategoryB,
+			PTKPStatusK1:  TERCategoryB,
+			PTKPStatusK2:  TERCategoryB,
+			PTKPStatusK3:  TERCategoryC,
+		},
+		TER: map[TERCategory][]Bracket{
+			TERCategoryA: terA,
+			TERCategoryB: terB,
+			TERCategoryC: terC,
+		},
+		IncomeTax: []Bracket{
+			{UpTo: 60_000_000, Rate: 500},
+			{UpTo: 250_000_000, Rate: 1500},
+			{UpTo: 500_000_000, Rate: 2500},
+			{UpTo: 5_000_000_000, Rate: 3000},
+			{Rate: 3500},
+		},
+		OccupationalCostRate:       500,
+		OccupationalCostMonthlyCap: 500_000,
+		BPJS: BPJSRates{
+			HealthEmployer: 400,
+			HealthEmployee: 100,
+			HealthWageCap:  12_000_000,
+			JHTEmployer:    370,
+			JHTEmployee:    200,
+			JPEmployer:     200,
+			JPEmployee:     100,
+			JPWageCap:      jpWageCap,
+			JKM:            30,
+			JKK: map[int]int64{
+				1: 24,
+				2: 54,
+				3: 89,
+				4: 127,
+				5: 174,
+			},
+		},
+	}
+}
+
+// ForDate returns the rule set in force on the given date.
+func ForDate(date time.Time) (*RuleSet, error) {
+	index := sort.Search(len(ruleSets), func(i int) bool {
+		return ruleSets[i].EffectiveFrom.After(date)
+	})
+	if index == 0 {
+		return nil, errors.Wrap(ErrNoRuleSet, date.Format("2006-01-02"))
+	}
+
+	return &ruleSets[index-1], nil
+}
+
+func ByVersion(version string) (*RuleSet, error) {
+	for i := range ruleSets {
+		if ruleSets[i].Version == version {
+			return &ruleSets[i], nil
+		}
+	}
+
+	return nil, errors.Wrap(ErrUnknownVersion, version)
+}
+
+// Versions lists the versions of the rules, oldest first.
+func Versions() []string {
+	versions := make([]string, 0, len(ruleSets))
+	for _, ruleSet := range ruleSets {
+		versions = append(versions, ruleSet.Version)
+	}
+
+	return versions
+}
+
+// percent applies a rate in basis points, rounding down to whole rupiah.
+func percent(amount int64, rate int64) int64 {
+	return amount * rate / 10000
+}
@@ -0,0 +1,125 @@
+package statutory
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestForDate(t *testing.T) {
+	tests := []struct {
+		date    string
+		version string
+		err     error
+	}{
+		{date: "2023-12-31T23:59:59Z", err: ErrNoRuleSet},
+		{date: "2024-01-01T00:00:00Z", version: "2024.01"},
+		{date: "2024-02-29T23:59:59Z", version: "2024.01"},
+		{date: "2024-03-01T00:00:00Z", version: "2024.03"},
+		{date: "2025-02-28T23:59:59Z", version: "2024.03"},
+		{date: "2025-03-01T00:00:00Z", version: "2025.03"},
+		{date: "2030-06-15T00:00:00Z", version: "2025.03"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.date, func(t *testing.T) {
+			date, err := time.Parse(time.RFC3339, test.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ruleSet, err := ForDate(date)
+			if test.err != nil {
+				if !errors.Is(err, test.err) {
+					t.Fatalf("got error %v, want %v", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ruleSet.Version != test.version {
+				t.Errorf("got version %s, want %s", ruleSet.Version, test.version)
+			}
+		})
+	}
+}
+
+func TestByVersion(t *testing.T) {
+	tests := []struct {
+		version   string
+		jpWageCap int64
+		err       error
+	}{
+		{version: "2024.01", jpWageCap: 9_559_600},
+		{version: "2024.03", jpWageCap: 10_042_300},
+		{version: "2025.03", jpWageCap: 10_547_400},
+		{version: "2023.01", err: ErrUnknownVersion},
+		{version: "", err: ErrUnknownVersion},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			ruleSet, err := ByVersion(test.version)
+			if test.err != nil {
+				if !errors.Is(err, test.err) {
+					t.Fatalf("got error %v, want %v", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ruleSet.Version != test.version {
+				t.Errorf("got version %s, want %s", ruleSet.Version, test.version)
+			}
+			if ruleSet.BPJS.JPWageCap != test.jpWageCap {
+				t.Errorf("got JP wage cap %d, want %d", ruleSet.BPJS.JPWageCap, test.jpWageCap)
+			}
+		})
+	}
+}
+
+func TestVersions(t *testing.T) {
+	want := []string{"2024.01", "2024.03", "2025.03"}
+
+	got := Versions()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRuleSetsAreSorted(t *testing.T) {
+	for i := 1; i < len(ruleSets); i++ {
+		if !ruleSets[i-1].EffectiveFrom.Before(ruleSets[i].EffectiveFrom) {
+			t.Errorf("%s is not effective before %s", ruleSets[i-1].Version, ruleSets[i].Version)
+		}
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		amount int64
+		rate   int64
+		want   int64
+	}{
+		{amount: 0, rate: 500, want: 0},
+		{amount: 10_000, rate: 0, want: 0},
+		{amount: 10_000, rate: 10000, want: 10_000},
+		{amount: 1_000_000, rate: 25, want: 2_500},
+		// * Rounded down to whole rupiah
+		{amount: 199, rate: 50, want: 0},
+		{amount: 100_000_999, rate: 500, want: 5_000_049},
+	}
+
+	for _, test := range tests {
+		if got := percent(test.amount, test.rate); got != test.want {
+			t.Errorf("percent(%d, %d) = %d, want %d", test.amount, test.rate, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package statutory
+
+// Effective monthly rates (tarif efektif rata-rata) of PP 58/2023 by gross
+// monthly income.
+var (
+	terA = []Bracket{
+		{UpTo: 5_400_000, Rate: 0},
+		{UpTo: 5_650_000, Rate: 25},
+		{UpTo: 5_950_000, Rate: 50},
+		{UpTo: 6_300_000, Rate: 75},
+		{UpTo: 6_750_000, Rate: 100},
+		{UpTo: 7_500_000, Rate: 125},
+		{UpTo: 8_550_000, Rate: 150},
+		{UpTo: 9_650_000, Rate: 175},
+		{UpTo: 10_050_000, Rate: 200},
+		{UpTo: 10_350_000, Rate: 225},
+		{UpTo: 10_700_000, Rate: 250},
+		{UpTo: 11_050_000, Rate: 300},
+		{UpTo: 11_600_000, Rate: 350},
+		{UpTo: 12_500_000, Rate: 400},
+		{UpTo: 13_750_000, Rate: 500},
+		{UpTo: 15_100_000, Rate: 600},
+		{UpTo: 16_950_000, Rate: 700},
+		{UpTo: 19_750_000, Rate: 800},
+		{UpTo: 24_150_000, Rate: 900},
+		{UpTo: 26_450_000, Rate: 1000},
+		{UpTo: 28_000_000, Rate: 1100},
+		{UpTo: 30_050_000, Rate: 1200},
+		{UpTo: 32_400_000, Rate: 1300},
+		{UpTo: 35_400_000, Rate: 1400},
+		{UpTo: 39_100_000, Rate: 1500},
+		{UpTo: 43_850_000, Rate: 1600},
+		{UpTo: 47_800_000, Rate: 1700},
+		{UpTo: 51_400_000, Rate: 1800},
+		{UpTo: 56_300_000, Rate: 1900},
+		{UpTo: 62_200_000, Rate: 2000},
+		{UpTo: 68_600_000, Rate: 2100},
+		{UpTo: 77_500_000, Rate: 2200},
+		{UpTo: 89_000_000, Rate: 2300},
+		{UpTo: 103_000_000, Rate: 2400},
+		{UpTo: 125_000_000, Rate: 2500},
+		{UpTo: 157_000_000, Rate: 2600},
+		{UpTo: 206_000_000, Rate: 2700},
+		{UpTo: 337_000_000, Rate: 2800},
+		{UpTo: 454_000_000, Rate: 2900},
+		{UpTo: 550_000_000, Rate: 3000},
+		{UpTo: 695_000_000, Rate: 3100},
+		{UpTo: 910_000_000, Rate: 3200},
+		{UpTo: 1_400_000_000, Rate: 3300},
+		{Rate: 3400},
+	}
+
+	terB = []Bracket{
+		{UpTo: 6_200_000, Rate: 0},
+		{UpTo: 6_500_000, Rate: 25},
+		{UpTo: 6_850_000, Rate: 50},
+		{UpTo: 7_300_000, Rate: 75},
+		{UpTo: 9_200_000, Rate: 100},
+		{UpTo: 10_750_000, Rate: 150},
+		{UpTo: 11_250_000, Rate: 200},
+		{UpTo: 11_600_000, Rate: 250},
+		{UpTo: 12_600_000, Rate: 300},
+		{UpTo: 13_600_000, Rate: 400},
+		{UpTo: 14_950_000, Rate: 500},
+		{UpTo: 16_400_000, Rate: 600},
+		{UpTo: 18_450_000, Rate: 700},
+		{UpTo: 21_850_000, Rate: 800},
+		{UpTo: 26_000_000, Rate: 900},
+		{UpTo: 27_700_000, Rate: 1000},
+		{UpTo: 29_350_000, Rate: 1100},
+		{UpTo: 31_450_000, Rate: 1200},
+		{UpTo: 33_950_000, Rate: 1300},
+		{UpTo: 37_100_000, Rate: 1400},
+		{UpTo: 41_100_000, Rate: 1500},
+		{UpTo: 45_800_000, Rate: 1600},
+		{UpTo: 49_500_000, Rate: 1700},
+		{UpTo: 53_800_000, Rate: 1800},
+		{UpTo: 58_500_000, Rate: 1900},
+		{UpTo: 64_000_000, Rate: 2000},
+		{UpTo: 71_000_000, Rate: 2100},
+		{UpTo: 80_000_000, Rate: 2200},
+		{UpTo: 93_000_000, Rate: 2300},
+		{UpTo: 109_000_000, Rate: 2400},
+		{UpTo: 129_000_000, Rate: 2500},
+		{UpTo: 163_000_000, Rate: 2600},
+		{UpTo: 211_000_000, Rate: 2700},
+		{UpTo: 374_000_000, Rate: 2800},
+		{UpTo: 459_000_000, Rate: 2900},
+		{UpTo: 555_000_000, Rate: 3000},
+		{UpTo: 704_000_000, Rate: 3100},
+		{UpTo: 957_000_000, Rate: 3200},
+		{UpTo: 1_405_000_000, Rate: 3300},
+		{Rate: 3400},
+	}
+
+	terC = []Bracket{
+		{UpTo: 6_600_000, Rate: 0},
+		{UpTo: 6_950_000, Rate: 25},
+		{UpTo: 7_350_000, Rate: 50},
+		{UpTo: 7_800_000, Rate: 75},
+		{UpTo: 8_850_000, Rate: 100},
+		{UpTo: 9_800_000, Rate: 125},
+		{UpTo: 10_950_000, Rate: 150},
+		{UpTo: 11_200_000, Rate: 175},
+		{UpTo: 12_050_000, Rate: 200},
+		{UpTo: 12_950_000, Rate: 300},
+		{UpTo: 14_150_000, Rate: 400},
+		{UpTo: 15_550_000, Rate: 500},
+		{UpTo: 17_050_000, Rate: 600},
+		{UpTo: 19_500_000, Rate: 700},
+		{UpTo: 22_700_000, Rate: 800},
+		{UpTo: 26_600_000, Rate: 900},
+		{UpTo: 28_100_000, Rate: 1000},
+		{UpTo: 30_100_000, Rate: 1100},
+		{UpTo: 32_600_000, Rate: 1200},
+		{UpTo: 35_400_000, Rate: 1300},
+		{UpTo: 38_900_000, Rate: 1400},
+		{UpTo: 43_000_000, Rate: 1500},
+		{UpTo: 47_400_000, Rate: 1600},
+		{UpTo: 51_200_000, Rate: 1700},
+		{UpTo: 55_800_000, Rate: 1800},
+		{UpTo: 60_400_000, Rate: 1900},
+		{UpTo: 66_700_000, Rate: 2000},
+		{UpTo: 74_500_000, Rate: 2100},
+		{UpTo: 83_200_000, Rate: 2200},
+		{UpTo: 95_600_000, Rate: 2300},
+		{UpTo: 110_000_000, Rate: 2400},
+		{UpTo: 134_000_000, Rate: 2500},
+		{UpTo: 169_000_000, Rate: 2600},
+		{UpTo: 221_000_000, Rate: 2700},
+		{UpTo: 390_000_000, Rate: 2800},
+		{UpTo: 463_000_000, Rate: 2900},
+		{UpTo: 561_000_000, Rate: 3000},
+		{UpTo: 709_000_000, Rate: 3100},
+		{UpTo: 965_000_000, Rate: 3200},
+		{UpTo: 1_419_000_000, Rate: 3300},
+		{Rate: 3400},
+	}
+)
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"ps-gogo-manajer/internal/payroll/dto"
+	"ps-gogo-manajer/internal/payroll/statutory"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CalculateMonthly works out the BPJS contributions, PPh 21 and take home
+// pay of a monthly gross income, outside of any payroll run.
+func (u *PayrollUsecase) CalculateMonthly(payload *dto.CalculateMonthlyPayload) (*dto.MonthlyCalculation, error) {
+	date := time.Now()
+	if payload.Period != "" {
+		var err error
+		date, err = time.Parse(PERIOD_LAYOUT, payload.Period)
+		if err != nil {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, "period is invalid")
+		}
+	}
+
+	ruleSet, err := ruleSetFor(payload.Version, date)
+	if err != nil {
+		return nil, err
+	}
+
+	riskClass := payload.JKKRiskClass
+	if riskClass == 0 {
+		riskClass = DEFAULT_JKK_RISK_CLASS
+	}
+
+	result := dto.MonthlyCalculation{
+		Version:       ruleSet.Version,
+		Gross:         payload.Gross,
+		TaxableIncome: payload.Gross,
+		TakeHomePay:   payload.Gross,
+	}
+
+	if payload.IsBPJSEnrolled == nil || *payload.IsBPJSEnrolled {
+		result.BPJS, err = ruleSet.BPJSContributions(payload.Gross, riskClass)
+		if err != nil {
+			return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		}
+		result.TaxableIncome += result.BPJS.TaxableBenefits()
+		result.TakeHomePay -= result.BPJS.HealthEmployee + result.BPJS.PensionContributions()
+	}
+
+	result.PPh21, err = ruleSet.MonthlyTax(statutory.PTKPStatus(payload.PTKPStatus), result.TaxableIncome)
+	if err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+	result.TakeHomePay -= result.PPh21.Tax
+
+	return &result, nil
+}
+
+// CalculateAnnual reconciles the PPh 21 of a tax year, outside of any
+// payroll run.
+func (u *PayrollUsecase) CalculateAnnual(payload *dto.CalculateAnnualPayload) (*dto.AnnualCalculation, error) {
+	date := time.Now()
+	if payload.Year != 0 {
+		date = time.Date(payload.Year, time.December, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	ruleSet, err := ruleSetFor(payload.Version, date)
+	if err != nil {
+		return nil, err
+	}
+
+	months := payload.Months
+	if months == 0 {
+		months = 12
+	}
+
+	annual, err := ruleSet.AnnualTax(statutory.AnnualInput{
+		Status:               statutory.PTKPStatus(payload.PTKPStatus),
+		Months:               months,
+		Gross:                payload.Gross,
+		PensionContributions: payload.PensionContributions,
+		Withheld:             payload.Withheld,
+	})
+	if err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return &dto.AnnualCalculation{
+		Version:   ruleSet.Version,
+		AnnualTax: *annual,
+	}, nil
+}
+
+func (u *PayrollUsecase) GetStatutoryVersions() []string {
+	return statutory.Versions()
+}
+
+// ruleSetFor returns the rules of the given version, or else those in force
+// on the date.
+func ruleSetFor(version string, date time.Time) (*statutory.RuleSet, error) {
+	var ruleSet *statutory.RuleSet
+	var err error
+	if version != "" {
+		ruleSet, err = statutory.ByVersion(version)
+	} else {
+		ruleSet, err = statutory.ForDate(date)
+	}
+	if err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return ruleSet, nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"ps-gogo-manajer/internal/payroll/dto"
+	"time"
+)
+
+const (
+	LINE_CODE_BASE_SALARY  = "BASE"
+	LINE_CODE_UNPAID_LEAVE = "UNPAID_LEAVE"
+
+	// UNPAID_LEAVE_DIVISOR is the number of working days a monthly base
+	// salary is divided by to get the pay of one day.
+	UNPAID_LEAVE_DIVISOR = 21
+)
+
+// Rule adds the lines it is responsible for to the payroll item of an
+// employee. Rules run in order, so a rule sees the lines of those before it.
+type Rule interface {
+	Apply(employee *dto.PayrollEmployee, period time.Time, item *dto.PayrollItem) error
+	// LineCodes lists the codes of the lines the rule adds, salary
+	// components may not use them.
+	LineCodes() []string
+}
+
+// DefaultRules are the rules payroll runs are calculated with. The statutory
+// rules go last as they are calculated on the earnings before them.
+func DefaultRules() []Rule {
+	return []Rule{
+		ComponentRule{},
+		UnpaidLeaveRule{Divisor: UNPAID_LEAVE_DIVISOR},
+		BPJSRule{},
+		IncomeTaxRule{},
+	}
+}
+
+// ComponentRule adds the base salary and the salary components.
+type ComponentRule struct{}
+
+func (ComponentRule) Apply(employee *dto.PayrollEmployee, period time.Time, item *dto.PayrollItem) error {
+	item.Lines = append(item.Lines, dto.PayrollLine{
+		Code:      LINE_CODE_BASE_SALARY,
+		Name:      "Base Salary",
+		Type:      dto.ComponentTypeEarning,
+		Amount:    employee.BaseSalary,
+		IsTaxable: true,
+	})
+
+	for _, component := range employee.Components {
+		item.Lines = append(item.Lines, dto.PayrollLine{
+			Code:      component.Code,
+			Name:      component.Name,
+			Type:      component.Type,
+			Amount:    component.Amount,
+			IsTaxable: component.IsTaxable,
+		})
+	}
+
+	return nil
+}
+
+func (ComponentRule) LineCodes() []string {
+	return []string{LINE_CODE_BASE_SALARY}
+}
+
+// UnpaidLeaveRule takes a day of base salary off the earnings for every
+// working day of approved unpaid leave in the period, so it lowers both the
+// gross and the taxable income.
+type UnpaidLeaveRule struct {
+	Divisor int
+}
+
+func (r UnpaidLeaveRule) Apply(employee *dto.PayrollEmployee, period time.Time, item *dto.PayrollItem) error {
+	if employee.UnpaidLeaveDays == 0 {
+		return nil
+	}
+
+	amount := employee.BaseSalary * int64(employee.UnpaidLeaveDays) / int64(r.Divisor)
+	if amount > employee.BaseSalary {
+		amount = employee.BaseSalary
+	}
+
+	item.Lines = append(item.Lines, dto.PayrollLine{
+		Code:      LINE_CODE_UNPAID_LEAVE,
+		Name:      "Unpaid Leave",
+		Type:      dto.ComponentTypeEarning,
+		Amount:    -amount,
+		IsTaxable: true,
+	})
+
+	return nil
+}
+
+func (UnpaidLeaveRule) LineCodes() []string {
+	return []string{LINE_CODE_UNPAID_LEAVE}
+}
@@ -0,0 +1,385 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"ps-gogo-manajer/internal/payroll/dto"
+	"ps-gogo-manajer/internal/payroll/repository"
+	"ps-gogo-manajer/internal/payroll/statutory"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	PERIOD_LAYOUT = "2006-01"
+
+	DEFAULT_PTKP_STATUS    = statutory.PTKPStatusTK0
+	DEFAULT_JKK_RISK_CLASS = 1
+)
+
+type PayrollUsecase struct {
+	payrollRepo repository.PayrollRepository
+	rules       []Rule
+}
+
+func NewPayrollUsecase(payrollRepo repository.PayrollRepository, rules []Rule) *PayrollUsecase {
+	return &PayrollUsecase{
+		payrollRepo: payrollRepo,
+		rules:       rules,
+	}
+}
+
+func (u *PayrollUsecase) SetSalaryStructure(ctx context.Context, userID int, payload *dto.SetSalaryStructurePayload) (*dto.SalaryStructure, error) {
+	for _, component := range payload.Components {
+		for _, rule := range u.rules {
+			for _, code := range rule.LineCodes() {
+				if component.Code == code {
+					return nil, errors.Wrapf(customErrors.ErrBadRequest, "component code %s is reserved", code)
+				}
+			}
+		}
+	}
+
+	if payload.PTKPStatus == "" {
+		payload.PTKPStatus = string(DEFAULT_PTKP_STATUS)
+	}
+	if payload.IsBPJSEnrolled == nil {
+		isBPJSEnrolled := true
+		payload.IsBPJSEnrolled = &isBPJSEnrolled
+	}
+	if payload.JKKRiskClass == 0 {
+		payload.JKKRiskClass = DEFAULT_JKK_RISK_CLASS
+	}
+
+	if err := u.validateSalaryRange(ctx, userID, payload); err != nil {
+		return nil, err
+	}
+
+	if err := u.payrollRepo.SetSalaryStructure(ctx, userID, payload); err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+		}
+		return nil, err
+	}
+
+	return u.payrollRepo.GetSalaryStructure(ctx, userID, payload.IdentityNumber)
+}
+
+// validateSalaryRange keeps the base salary within the range of the
+// employee's job title. Employees off the catalog have no range.
+func (u *PayrollUsecase) validateSalaryRange(ctx context.Context, userID int, payload *dto.SetSalaryStructurePayload) error {
+	name, salaryMin, salaryMax, err := u.payrollRepo.GetJobTitleSalaryRange(ctx, userID, payload.IdentityNumber)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if salaryMin != nil && payload.BaseSalary < *salaryMin {
+		return errors.Wrapf(customErrors.ErrBadRequest, "base salary is below the minimum of %d for %s", *salaryMin, name)
+	}
+
+	if salaryMax != nil && payload.BaseSalary > *salaryMax {
+		return errors.Wrapf(customErrors.ErrBadRequest, "base salary is above the maximum of %d for %s", *salaryMax, name)
+	}
+
+	return nil
+}
+
+func (u *PayrollUsecase) GetSalaryStructure(ctx context.Context, userID int, identityNumber string) (*dto.SalaryStructure, error) {
+	structure, err := u.payrollRepo.GetSalaryStructure(ctx, userID, identityNumber)
+	if err == nil {
+		return structure, nil
+	}
+
+	if !errors.Is(err, customErrors.ErrNotFound) {
+		return nil, err
+	}
+
+	isExists, err := u.payrollRepo.CheckIfEmployeeExists(ctx, userID, identityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+	}
+
+	return nil, errors.Wrap(customErrors.ErrNotFound, "employee has no salary structure")
+}
+
+// PreviewPayroll calculates the payroll of a period without saving it.
+func (u *PayrollUsecase) PreviewPayroll(ctx context.Context, userID int, payload *dto.PayrollPeriodPayload) (*dto.PayrollRun, error) {
+	period, _ := time.Parse(PERIOD_LAYOUT, payload.Period)
+	items, err := u.calculate(ctx, userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	run := dto.PayrollRun{
+		Period:        payload.Period,
+		Status:        dto.RunStatusDraft,
+		EmployeeCount: len(items),
+		CalculatedAt:  time.Now(),
+		Items:         items,
+	}
+	for _, item := range items {
+		run.Gross += item.Gross
+		run.TotalDeductions += item.TotalDeductions
+		run.Net += item.Net
+	}
+
+	return &run, nil
+}
+
+func (u *PayrollUsecase) CreatePayrollRun(ctx context.Context, userID int, payload *dto.PayrollPeriodPayload) (*dto.PayrollRun, error) {
+	period, _ := time.Parse(PERIOD_LAYOUT, payload.Period)
+	isExists, err := u.payrollRepo.CheckIfPayrollRunExists(ctx, userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	if isExists {
+		return nil, errors.Wrap(customErrors.ErrConflict, "payroll run for the period already exists")
+	}
+
+	items, err := u.calculate(ctx, userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	payrollRunID, err := u.payrollRepo.CreatePayrollRun(ctx, userID, period, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetPayrollRun(ctx, userID, payrollRunID)
+}
+
+// RecalculatePayrollRun takes a new snapshot of the employees and salaries of
+// a draft run.
+func (u *PayrollUsecase) RecalculatePayrollRun(ctx context.Context, userID int, payrollRunID int) (*dto.PayrollRun, error) {
+	run, err := u.getPayrollRun(ctx, userID, payrollRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	if run.Status != dto.RunStatusDraft {
+		return nil, errors.Wrap(customErrors.ErrConflict, "payroll run is locked")
+	}
+
+	period, _ := time.Parse(PERIOD_LAYOUT, run.Period)
+	items, err := u.calculate(ctx, userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.payrollRepo.ReplacePayrollItems(ctx, userID, payrollRunID, items); err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrConflict, "payroll run is locked")
+		}
+		return nil, err
+	}
+
+	return u.GetPayrollRun(ctx, userID, payrollRunID)
+}
+
+// calculate runs the rules over every employee to be paid for the period.
+func (u *PayrollUsecase) calculate(ctx context.Context, userID int, period time.Time) ([]dto.PayrollItem, error) {
+	employees, err := u.payrollRepo.GetPayrollEmployees(ctx, userID, period, period.AddDate(0, 1, -1), LINE_CODE_INCOME_TAX, LINE_CODE_INCOME_TAX_REFUND)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.PayrollItem, 0, len(employees))
+	for i := range employees {
+		employee := &employees[i]
+		item := dto.PayrollItem{
+			EmployeeId:     employee.EmployeeId,
+			IdentityNumber: employee.IdentityNumber,
+			Name:           employee.Name,
+			DepartmentName: employee.DepartmentName,
+			JobTitle:       employee.JobTitle,
+			BaseSalary:     employee.BaseSalary,
+			Lines:          make([]dto.PayrollLine, 0),
+		}
+
+		for _, rule := range u.rules {
+			if err := rule.Apply(employee, period, &item); err != nil {
+				if errors.Is(err, statutory.ErrNoRuleSet) {
+					return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+				}
+				return nil, errors.Wrapf(err, "failed to calculate payroll of %s", employee.IdentityNumber)
+			}
+		}
+
+		item.Gross = item.Total(dto.ComponentTypeEarning)
+		item.TotalDeductions = item.Total(dto.ComponentTypeDeduction)
+		item.Net = item.Gross - item.TotalDeductions
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (u *PayrollUsecase) GetListPayrollRun(ctx context.Context, userID int, params *dto.GetPayrollRunParams) ([]dto.PayrollRun, error) {
+	return u.payrollRepo.GetListPayrollRun(ctx, userID, params)
+}
+
+func (u *PayrollUsecase) GetPayrollRun(ctx context.Context, userID int, payrollRunID int) (*dto.PayrollRun, error) {
+	run, err := u.getPayrollRun(ctx, userID, payrollRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	run.Items, err = u.payrollRepo.GetPayrollItems(ctx, payrollRunID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (u *PayrollUsecase) GetPayrollItem(ctx context.Context, userID int, params *dto.PayrollItemPathParam) (*dto.PayrollItem, error) {
+	payslip, err := u.GetPayslip(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payslip.Item, nil
+}
+
+// GetPayslip returns the payroll item of an employee together with the run
+// it belongs to.
+func (u *PayrollUsecase) GetPayslip(ctx context.Context, userID int, params *dto.PayrollItemPathParam) (*dto.Payslip, error) {
+	run, err := u.getPayrollRun(ctx, userID, params.PayrollRunId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.payrollRepo.GetPayrollItems(ctx, params.PayrollRunId, params.IdentityNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "employee is not in the payroll run")
+	}
+
+	return &dto.Payslip{
+		Period: run.Period,
+		Status: run.Status,
+		Item:   items[0],
+	}, nil
+}
+
+func (u *PayrollUsecase) LockPayrollRun(ctx context.Context, userID int, payrollRunID int) (*dto.PayrollRun, error) {
+	return u.setPayrollRunStatus(ctx, userID, payrollRunID, dto.RunStatusDraft, dto.RunStatusLocked)
+}
+
+func (u *PayrollUsecase) ReopenPayrollRun(ctx context.Context, userID int, payrollRunID int) (*dto.PayrollRun, error) {
+	return u.setPayrollRunStatus(ctx, userID, payrollRunID, dto.RunStatusLocked, dto.RunStatusDraft)
+}
+
+func (u *PayrollUsecase) setPayrollRunStatus(ctx context.Context, userID int, payrollRunID int, fromStatus dto.RunStatus, toStatus dto.RunStatus) (*dto.PayrollRun, error) {
+	if _, err := u.getPayrollRun(ctx, userID, payrollRunID); err != nil {
+		return nil, err
+	}
+
+	if err := u.payrollRepo.SetPayrollRunStatus(ctx, userID, payrollRunID, fromStatus, toStatus); err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrapf(customErrors.ErrConflict, "payroll run is not %s", fromStatus)
+		}
+		return nil, err
+	}
+
+	return u.GetPayrollRun(ctx, userID, payrollRunID)
+}
+
+func (u *PayrollUsecase) DeletePayrollRun(ctx context.Context, userID int, payrollRunID int) error {
+	if _, err := u.getPayrollRun(ctx, userID, payrollRunID); err != nil {
+		return err
+	}
+
+	isDeleted, err := u.payrollRepo.DeletePayrollRun(ctx, userID, payrollRunID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return errors.Wrap(customErrors.ErrConflict, "payroll run is locked")
+	}
+
+	return nil
+}
+
+// ExportPayrollRun returns the file name and the CSV records of a run, one
+// row per employee with a column for every line code in the run.
+func (u *PayrollUsecase) ExportPayrollRun(ctx context.Context, userID int, payrollRunID int) (string, [][]string, error) {
+	run, err := u.GetPayrollRun(ctx, userID, payrollRunID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	codes := make([]string, 0)
+	names := make(map[string]string)
+	lineTypes := []dto.ComponentType{
+		dto.ComponentTypeEarning,
+		dto.ComponentTypeDeduction,
+		dto.ComponentTypeEmployerContribution,
+	}
+	for _, lineType := range lineTypes {
+		for _, item := range run.Items {
+			for _, line := range item.Lines {
+				if _, ok := names[line.Code]; ok || line.Type != lineType {
+					continue
+				}
+				names[line.Code] = line.Name
+				codes = append(codes, line.Code)
+			}
+		}
+	}
+
+	header := []string{"Identity Number", "Name", "Department", "Job Title"}
+	for _, code := range codes {
+		header = append(header, names[code])
+	}
+	header = append(header, "Gross", "Total Deductions", "Net")
+
+	records := [][]string{header}
+	for _, item := range run.Items {
+		amounts := make(map[string]int64, len(item.Lines))
+		for _, line := range item.Lines {
+			amounts[line.Code] += line.Amount
+		}
+
+		record := []string{item.IdentityNumber, item.Name, item.DepartmentName, item.JobTitle}
+		for _, code := range codes {
+			record = append(record, strconv.FormatInt(amounts[code], 10))
+		}
+		record = append(record,
+			strconv.FormatInt(item.Gross, 10),
+			strconv.FormatInt(item.TotalDeductions, 10),
+			strconv.FormatInt(item.Net, 10),
+		)
+		records = append(records, record)
+	}
+
+	return fmt.Sprintf("payroll-%s.csv", run.Period), records, nil
+}
+
+func (u *PayrollUsecase) getPayrollRun(ctx context.Context, userID int, payrollRunID int) (*dto.PayrollRun, error) {
+	run, err := u.payrollRepo.GetPayrollRun(ctx, userID, payrollRunID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "payroll run not found")
+		}
+		return nil, err
+	}
+
+	return run, nil
+}
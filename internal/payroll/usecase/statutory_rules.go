@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"ps-gogo-manajer/internal/payroll/dto"
+	"ps-gogo-manajer/internal/payroll/statutory"
+	"time"
+)
+
+const (
+	LINE_CODE_BPJS_HEALTH_EMPLOYEE = "BPJS_KES"
+	LINE_CODE_BPJS_JHT_EMPLOYEE    = "BPJS_JHT"
+	LINE_CODE_BPJS_JP_EMPLOYEE     = "BPJS_JP"
+	LINE_CODE_BPJS_HEALTH_EMPLOYER = "BPJS_KES_COMPANY"
+	LINE_CODE_BPJS_JHT_EMPLOYER    = "BPJS_JHT_COMPANY"
+	LINE_CODE_BPJS_JP_EMPLOYER     = "BPJS_JP_COMPANY"
+	LINE_CODE_BPJS_JKK             = "BPJS_JKK"
+	LINE_CODE_BPJS_JKM             = "BPJS_JKM"
+	LINE_CODE_INCOME_TAX           = "PPH21"
+	LINE_CODE_INCOME_TAX_REFUND    = "PPH21_REFUND"
+)
+
+// BPJSRule adds the BPJS contributions of enrolled employees on their
+// earnings so far, which are base salary and fixed allowances.
+type BPJSRule struct{}
+
+func (BPJSRule) Apply(employee *dto.PayrollEmployee, period time.Time, item *dto.PayrollItem) error {
+	if !employee.IsBPJSEnrolled {
+		return nil
+	}
+
+	ruleSet, err := statutory.ForDate(period)
+	if err != nil {
+		return err
+	}
+
+	contributions, err := ruleSet.BPJSContributions(item.Total(dto.ComponentTypeEarning), employee.JKKRiskClass)
+	if err != nil {
+		return err
+	}
+
+	item.Lines = append(item.Lines,
+		statutoryLine(LINE_CODE_BPJS_HEALTH_EMPLOYEE, "BPJS Kesehatan", dto.ComponentTypeDeduction, contributions.HealthEmployee, false),
+		statutoryLine(LINE_CODE_BPJS_JHT_EMPLOYEE, "BPJS JHT", dto.ComponentTypeDeduction, contributions.JHTEmployee, true),
+		statutoryLine(LINE_CODE_BPJS_JP_EMPLOYEE, "BPJS JP", dto.ComponentTypeDeduction, contributions.JPEmployee, true),
+		statutoryLine(LINE_CODE_BPJS_HEALTH_EMPLOYER, "BPJS Kesehatan (Company)", dto.ComponentTypeEmployerContribution, contributions.HealthEmployer, true),
+		statutoryLine(LINE_CODE_BPJS_JKK, "BPJS JKK (Company)", dto.ComponentTypeEmployerContribution, contributions.JKK, true),
+		statutoryLine(LINE_CODE_BPJS_JKM, "BPJS JKM (Company)", dto.ComponentTypeEmployerContribution, contributions.JKM, true),
+		statutoryLine(LINE_CODE_BPJS_JHT_EMPLOYER, "BPJS JHT (Company)", dto.ComponentTypeEmployerContribution, contributions.JHTEmployer, false),
+		statutoryLine(LINE_CODE_BPJS_JP_EMPLOYER, "BPJS JP (Company)", dto.ComponentTypeEmployerContribution, contributions.JPEmployer, false),
+	)
+
+	return nil
+}
+
+func (BPJSRule) LineCodes() []string {
+	return []string{
+		LINE_CODE_BPJS_HEALTH_EMPLOYEE,
+		LINE_CODE_BPJS_JHT_EMPLOYEE,
+		LINE_CODE_BPJS_JP_EMPLOYEE,
+		LINE_CODE_BPJS_HEALTH_EMPLOYER,
+		LINE_CODE_BPJS_JHT_EMPLOYER,
+		LINE_CODE_BPJS_JP_EMPLOYER,
+		LINE_CODE_BPJS_JKK,
+		LINE_CODE_BPJS_JKM,
+	}
+}
+
+// IncomeTaxRule withholds PPh 21 with the effective monthly rate, and in the
+// last month of the tax year, December or the month the employee leaves,
+// reconciles the year with the progressive rates. Tax withheld in excess is
+// refunded as an earning.
+type IncomeTaxRule struct{}
+
+func (IncomeTaxRule) Apply(employee *dto.PayrollEmployee, period time.Time, item *dto.PayrollItem) error {
+	ruleSet, err := statutory.ForDate(period)
+	if err != nil {
+		return err
+	}
+
+	status := statutory.PTKPStatus(employee.PTKPStatus)
+	var tax int64
+	if period.Month() == time.December || employee.IsLeaving {
+		annual, err := ruleSet.AnnualTax(statutory.AnnualInput{
+			Status:               status,
+			Months:               employee.YearToDate.Months + 1,
+			Gross:                employee.YearToDate.TaxableIncome + item.TaxableIncome(),
+			PensionContributions: employee.YearToDate.TaxDeductible + item.TaxDeductible(),
+			Withheld:             employee.YearToDate.IncomeTaxWithheld,
+		})
+		if err != nil {
+			return err
+		}
+		tax = annual.Due
+	} else {
+		monthly, err := ruleSet.MonthlyTax(status, item.TaxableIncome())
+		if err != nil {
+			return err
+		}
+		tax = monthly.Tax
+	}
+
+	switch {
+	case tax > 0:
+		item.Lines = append(item.Lines, statutoryLine(LINE_CODE_INCOME_TAX, "PPh 21", dto.ComponentTypeDeduction, tax, false))
+	case tax < 0:
+		item.Lines = append(item.Lines, statutoryLine(LINE_CODE_INCOME_TAX_REFUND, "PPh 21 Refund", dto.ComponentTypeEarning, -tax, false))
+	}
+
+	return nil
+}
+
+func (IncomeTaxRule) LineCodes() []string {
+	return []string{LINE_CODE_INCOME_TAX, LINE_CODE_INCOME_TAX_REFUND}
+}
+
+func statutoryLine(code string, name string, lineType dto.ComponentType, amount int64, isTaxable bool) dto.PayrollLine {
+	return dto.PayrollLine{
+		Code:      code,
+		Name:      name,
+		Type:      lineType,
+		Amount:    amount,
+		IsTaxable: isTaxable,
+	}
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "time"
+
+// Position is a job in a department with a budgeted number of seats. Every
+// employee linked to the position who is not terminated fills a seat.
+type Position struct {
+	PositionId     string    `json:"positionId"`
+	Title          string    `json:"title"`
+	Grade          string    `json:"grade"`
+	DepartmentId   string    `json:"departmentId"`
+	DepartmentName string    `json:"departmentName"`
+	BudgetedCount  int       `json:"budgetedCount"`
+	SalaryMin      *int64    `json:"salaryMin"`
+	SalaryMax      *int64    `json:"salaryMax"`
+	FilledCount    int       `json:"filledCount"`
+	VacantCount    int       `json:"vacantCount"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type CreatePositionPayload struct {
+	Title         string `json:"title" validate:"required,min=1,max=255"`
+	Grade         string `json:"grade" validate:"omitempty,max=33"`
+	DepartmentId  string `json:"departmentId" validate:"required,number"`
+	BudgetedCount int    `json:"budgetedCount" validate:"min=1,max=10000"`
+	SalaryMin     *int64 `json:"salaryMin" validate:"omitempty,min=0"`
+	SalaryMax     *int64 `json:"salaryMax" validate:"omitempty,min=0"`
+}
+
+// PatchPositionPayload changes the fields that are set. A budget of zero
+// freezes the position without removing the employees in it.
+type PatchPositionPayload struct {
+	Title         *string `json:"title" validate:"omitempty,min=1,max=255"`
+	Grade         *string `json:"grade" validate:"omitempty,max=33"`
+	BudgetedCount *int    `json:"budgetedCount" validate:"omitempty,min=0,max=10000"`
+	SalaryMin     *int64  `json:"salaryMin" validate:"omitempty,min=0"`
+	SalaryMax     *int64  `json:"salaryMax" validate:"omitempty,min=0"`
+}
+
+type GetPositionParams struct {
+	DepartmentId string `query:"departmentId" validate:"omitempty,number"`
+	VacantOnly   bool   `query:"vacantOnly"`
+}
+
+type PositionPathParam struct {
+	PositionId int `param:"positionId" validate:"required,min=1"`
+}
+
+// DepartmentHeadcount compares the approved headcount of a department, the
+// seats budgeted over its positions, with the employees in it.
+type DepartmentHeadcount struct {
+	DepartmentId   string `json:"departmentId"`
+	DepartmentName string `json:"departmentName"`
+	Budgeted       int    `json:"budgeted"`
+	Headcount      int    `json:"headcount"`
+	Filled         int    `json:"filled"`
+	Vacant         int    `json:"vacant"`
+	IsOverBudget   bool   `json:"isOverBudget"`
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/position/dto"
+	"ps-gogo-manajer/internal/position/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type PositionHandler struct {
+	positionUsecase usecase.PositionUsecase
+	validator       *validator.Validate
+}
+
+func NewPositionHandler(positionUsecase usecase.PositionUsecase, validator *validator.Validate) *PositionHandler {
+	return &PositionHandler{
+		positionUsecase: positionUsecase,
+		validator:       validator,
+	}
+}
+
+// bind binds the request into payload and validates it, wrapping any failure
+// as a bad request.
+func (h PositionHandler) bind(ctx echo.Context, payload any) error {
+	if err := ctx.Bind(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		return errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (h PositionHandler) bindPathParam(ctx echo.Context) (*dto.PositionPathParam, error) {
+	var pathParam dto.PositionPathParam
+	if err := (&echo.DefaultBinder{}).BindPathParams(ctx, &pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	if err := h.validator.Struct(pathParam); err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, err.Error())
+	}
+
+	return &pathParam, nil
+}
+
+func (h PositionHandler) CreatePosition(ctx echo.Context) error {
+	var payload dto.CreatePositionPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	position, err := h.positionUsecase.CreatePosition(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusCreated, position)
+}
+
+func (h PositionHandler) GetListPosition(ctx echo.Context) error {
+	var params dto.GetPositionParams
+	if err := h.bind(ctx, &params); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	positions, err := h.positionUsecase.GetListPosition(ctx.Request().Context(), userData.Id, &params)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, positions)
+}
+
+func (h PositionHandler) GetPosition(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	position, err := h.positionUsecase.GetPosition(ctx.Request().Context(), userData.Id, pathParam.PositionId)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, position)
+}
+
+func (h PositionHandler) UpdatePosition(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	var payload dto.PatchPositionPayload
+	if err := h.bind(ctx, &payload); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	position, err := h.positionUsecase.UpdatePosition(ctx.Request().Context(), userData.Id, pathParam.PositionId, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, position)
+}
+
+func (h PositionHandler) DeletePosition(ctx echo.Context) error {
+	pathParam, err := h.bindPathParam(ctx)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	if err := h.positionUsecase.DeletePosition(ctx.Request().Context(), userData.Id, pathParam.PositionId); err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
+
+func (h PositionHandler) GetHeadcount(ctx echo.Context) error {
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	headcounts, err := h.positionUsecase.GetHeadcount(ctx.Request().Context(), userData.Id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, headcounts)
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/position/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type PositionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPositionRepository(pool *pgxpool.Pool) *PositionRepository {
+	return &PositionRepository{pool: pool}
+}
+
+const (
+	// filledSeats counts the employees holding a seat of the position.
+	filledSeats = `
+		SELECT COUNT(*) count
+		FROM employees
+		WHERE
+			employees.position_id = positions.id
+			AND employees.deleted_at IS NULL
+			AND employees.status <> 'terminated'`
+	positionColumns = `
+		positions.id,
+		positions.title,
+		positions.grade,
+		positions.department_id,
+		departments.name,
+		positions.budgeted_count,
+		positions.salary_min,
+		positions.salary_max,
+		filled.count,
+		positions.created_at,
+		positions.updated_at`
+	positionTables = `
+	FROM positions
+	JOIN departments ON departments.id = positions.department_id
+	CROSS JOIN LATERAL (` + filledSeats + `
+	) filled`
+
+	queryCreatePosition = `
+	INSERT INTO positions(user_id, department_id, title, grade, budgeted_count, salary_min, salary_max)
+	VALUES (@userID, @departmentID, @title, NULLIF(@grade, ''), @budgetedCount, @salaryMin, @salaryMax)
+	RETURNING id;`
+	queryGetPosition = `
+	SELECT` + positionColumns + positionTables + `
+	WHERE
+		positions.user_id = @userID
+		AND positions.id = @positionID
+		AND departments.deleted_at IS NULL;`
+	queryGetListPosition = `
+	SELECT` + positionColumns + positionTables + `
+	WHERE
+		positions.user_id = @userID
+		AND departments.deleted_at IS NULL
+		AND (NULLIF(@departmentID, '') IS NULL OR positions.department_id = NULLIF(@departmentID, '')::bigint)
+		AND (NOT @vacantOnly::boolean OR filled.count < positions.budgeted_count)
+	ORDER BY departments.name, positions.title, positions.id;`
+	queryUpdatePosition = `
+	UPDATE positions
+	SET
+		title = COALESCE(@title, title),
+		grade = COALESCE(@grade, grade),
+		budgeted_count = COALESCE(@budgetedCount, budgeted_count),
+		salary_min = COALESCE(@salaryMin, salary_min),
+		salary_max = COALESCE(@salaryMax, salary_max),
+		updated_at = NOW()
+	WHERE
+		user_id = @userID
+		AND id = @positionID
+	RETURNING id;`
+	queryDeletePosition = `
+	DELETE FROM positions
+	WHERE
+		user_id = @userID
+		AND id = @positionID;`
+	queryCheckIfDepartmentExists = `
+	SELECT EXISTS (
+		SELECT id
+		FROM departments
+		WHERE
+			user_id = @userID
+			AND id = @departmentID::bigint
+			AND deleted_at IS NULL
+			AND archived_at IS NULL
+	) is_exists;`
+	// Only departments with positions have an approved headcount, so the
+	// report leaves out the others.
+	queryGetHeadcount = `
+	WITH seats AS (
+		SELECT
+			positions.department_id,
+			SUM(positions.budgeted_count)::int budgeted,
+			SUM(GREATEST(positions.budgeted_count - filled.count, 0))::int vacant
+		FROM positions
+		CROSS JOIN LATERAL (` + filledSeats + `
+		) filled
+		WHERE positions.user_id = @userID
+		GROUP BY positions.department_id
+	),
+	staff AS (
+		SELECT
+			department_id,
+			COUNT(*) headcount,
+			COUNT(position_id) filled
+		FROM employees
+		WHERE
+			user_id = @userID
+			AND deleted_at IS NULL
+			AND status <> 'terminated'
+		GROUP BY department_id
+	)
+	SELECT
+		departments.id,
+		departments.name,
+		seats.budgeted,
+		COALESCE(staff.headcount, 0),
+		COALESCE(staff.filled, 0),
+		seats.vacant
+	FROM seats
+	JOIN departments ON departments.id = seats.department_id
+	LEFT JOIN staff ON staff.department_id = seats.department_id
+	WHERE departments.deleted_at IS NULL
+	ORDER BY departments.name, departments.id;`
+)
+
+func scanPosition(row pgx.Row) (*dto.Position, error) {
+	var position dto.Position
+	grade := new(pgtype.Text)
+
+	err := row.Scan(
+		&position.PositionId,
+		&position.Title,
+		grade,
+		&position.DepartmentId,
+		&position.DepartmentName,
+		&position.BudgetedCount,
+		&position.SalaryMin,
+		&position.SalaryMax,
+		&position.FilledCount,
+		&position.CreatedAt,
+		&position.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	position.Grade = grade.String
+	position.VacantCount = max(position.BudgetedCount-position.FilledCount, 0)
+	return &position, nil
+}
+
+func (r *PositionRepository) CreatePosition(ctx context.Context, userID int, payload *dto.CreatePositionPayload) (*dto.Position, error) {
+	var positionID int
+	args := pgx.NamedArgs{
+		"userID":        userID,
+		"departmentID":  payload.DepartmentId,
+		"title":         payload.Title,
+		"grade":         payload.Grade,
+		"budgetedCount": payload.BudgetedCount,
+		"salaryMin":     payload.SalaryMin,
+		"salaryMax":     payload.SalaryMax,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCreatePosition, args).Scan(&positionID); err != nil {
+		return nil, errors.Wrap(err, "failed to create position")
+	}
+
+	return r.GetPosition(ctx, userID, positionID)
+}
+
+func (r *PositionRepository) GetPosition(ctx context.Context, userID int, positionID int) (*dto.Position, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"positionID": positionID,
+	}
+
+	position, err := scanPosition(r.pool.QueryRow(ctx, queryGetPosition, args))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get position")
+	}
+
+	return position, nil
+}
+
+func (r *PositionRepository) GetListPosition(ctx context.Context, userID int, params *dto.GetPositionParams) ([]dto.Position, error) {
+	positions := make([]dto.Position, 0)
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"departmentID": params.DepartmentId,
+		"vacantOnly":   params.VacantOnly,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetListPosition, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get list position")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		position, err := scanPosition(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		positions = append(positions, *position)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get list position")
+	}
+
+	return positions, nil
+}
+
+func (r *PositionRepository) UpdatePosition(ctx context.Context, userID int, positionID int, payload *dto.PatchPositionPayload) (*dto.Position, error) {
+	args := pgx.NamedArgs{
+		"userID":        userID,
+		"positionID":    positionID,
+		"title":         payload.Title,
+		"grade":         payload.Grade,
+		"budgetedCount": payload.BudgetedCount,
+		"salaryMin":     payload.SalaryMin,
+		"salaryMax":     payload.SalaryMax,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryUpdatePosition, args).Scan(&positionID); err != nil {
+		return nil, errors.Wrap(err, "failed to update position")
+	}
+
+	return r.GetPosition(ctx, userID, positionID)
+}
+
+func (r *PositionRepository) DeletePosition(ctx context.Context, userID int, positionID int) (bool, error) {
+	args := pgx.NamedArgs{
+		"userID":     userID,
+		"positionID": positionID,
+	}
+
+	tag, err := r.pool.Exec(ctx, queryDeletePosition, args)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete position")
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *PositionRepository) CheckIfDepartmentExists(ctx context.Context, userID int, departmentID string) (bool, error) {
+	var isExists bool
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"departmentID": departmentID,
+	}
+
+	if err := r.pool.QueryRow(ctx, queryCheckIfDepartmentExists, args).Scan(&isExists); err != nil {
+		return false, errors.Wrap(err, "failed to check is department exists")
+	}
+
+	return isExists, nil
+}
+
+func (r *PositionRepository) GetHeadcount(ctx context.Context, userID int) ([]dto.DepartmentHeadcount, error) {
+	headcounts := make([]dto.DepartmentHeadcount, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetHeadcount, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get headcount")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var headcount dto.DepartmentHeadcount
+		err := rows.Scan(
+			&headcount.DepartmentId,
+			&headcount.DepartmentName,
+			&headcount.Budgeted,
+			&headcount.Headcount,
+			&headcount.Filled,
+			&headcount.Vacant,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		headcount.IsOverBudget = headcount.Headcount > headcount.Budgeted
+		headcounts = append(headcounts, headcount)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to get headcount")
+	}
+
+	return headcounts, nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/position/dto"
+	"ps-gogo-manajer/internal/position/repository"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+
+	"github.com/pkg/errors"
+)
+
+type PositionUsecase struct {
+	positionRepo repository.PositionRepository
+}
+
+func NewPositionUsecase(positionRepo repository.PositionRepository) *PositionUsecase {
+	return &PositionUsecase{
+		positionRepo: positionRepo,
+	}
+}
+
+func (u *PositionUsecase) CreatePosition(ctx context.Context, userID int, payload *dto.CreatePositionPayload) (*dto.Position, error) {
+	if err := validateSalaryBand(payload.SalaryMin, payload.SalaryMax); err != nil {
+		return nil, err
+	}
+
+	isDepartmentExists, err := u.positionRepo.CheckIfDepartmentExists(ctx, userID, payload.DepartmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDepartmentExists {
+		return nil, errors.Wrap(customErrors.ErrNotFound, "department id for this user not found")
+	}
+
+	return u.positionRepo.CreatePosition(ctx, userID, payload)
+}
+
+func (u *PositionUsecase) GetListPosition(ctx context.Context, userID int, params *dto.GetPositionParams) ([]dto.Position, error) {
+	return u.positionRepo.GetListPosition(ctx, userID, params)
+}
+
+func (u *PositionUsecase) GetPosition(ctx context.Context, userID int, positionID int) (*dto.Position, error) {
+	position, err := u.positionRepo.GetPosition(ctx, userID, positionID)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "position not found")
+		}
+		return nil, err
+	}
+
+	return position, nil
+}
+
+func (u *PositionUsecase) UpdatePosition(ctx context.Context, userID int, positionID int, payload *dto.PatchPositionPayload) (*dto.Position, error) {
+	position, err := u.GetPosition(ctx, userID, positionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// * The band is checked as it will be stored, with the unchanged bound
+	salaryMin, salaryMax := position.SalaryMin, position.SalaryMax
+	if payload.SalaryMin != nil {
+		salaryMin = payload.SalaryMin
+	}
+	if payload.SalaryMax != nil {
+		salaryMax = payload.SalaryMax
+	}
+	if err := validateSalaryBand(salaryMin, salaryMax); err != nil {
+		return nil, err
+	}
+
+	position, err = u.positionRepo.UpdatePosition(ctx, userID, positionID, payload)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "position not found")
+		}
+		return nil, err
+	}
+
+	return position, nil
+}
+
+// DeletePosition removes a position nobody holds anymore.
+func (u *PositionUsecase) DeletePosition(ctx context.Context, userID int, positionID int) error {
+	position, err := u.GetPosition(ctx, userID, positionID)
+	if err != nil {
+		return err
+	}
+
+	if position.FilledCount > 0 {
+		return errors.Wrap(customErrors.ErrConflict, "still containing employee")
+	}
+
+	isDeleted, err := u.positionRepo.DeletePosition(ctx, userID, positionID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return errors.Wrap(customErrors.ErrNotFound, "position not found")
+	}
+
+	return nil
+}
+
+func (u *PositionUsecase) GetHeadcount(ctx context.Context, userID int) ([]dto.DepartmentHeadcount, error) {
+	return u.positionRepo.GetHeadcount(ctx, userID)
+}
+
+func validateSalaryBand(salaryMin *int64, salaryMax *int64) error {
+	if salaryMin != nil && salaryMax != nil && *salaryMin > *salaryMax {
+		return errors.Wrap(customErrors.ErrBadRequest, "salaryMin must not be greater than salaryMax")
+	}
+
+	return nil
+}
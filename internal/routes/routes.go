@@ -2,25 +2,47 @@ package routes
 
 import (
 	"net/http"
+	attendanceHandler "ps-gogo-manajer/internal/attendance/handler"
+	checklistHandler "ps-gogo-manajer/internal/checklist/handler"
+	costCenterHandler "ps-gogo-manajer/internal/costcenter/handler"
+	customFieldHandler "ps-gogo-manajer/internal/customfield/handler"
 	departmentHandler "ps-gogo-manajer/internal/department/handler"
+	docTemplateHandler "ps-gogo-manajer/internal/doctemplate/handler"
+	documentHandler "ps-gogo-manajer/internal/document/handler"
 	employeeHandler "ps-gogo-manajer/internal/employee/handler"
 	fileHandler "ps-gogo-manajer/internal/files/handler"
+	jobTitleHandler "ps-gogo-manajer/internal/jobtitle/handler"
+	leaveHandler "ps-gogo-manajer/internal/leave/handler"
+	locationHandler "ps-gogo-manajer/internal/location/handler"
+	payrollHandler "ps-gogo-manajer/internal/payroll/handler"
+	positionHandler "ps-gogo-manajer/internal/position/handler"
+	trashHandler "ps-gogo-manajer/internal/trash/handler"
 	userHandler "ps-gogo-manajer/internal/user/handler"
 	"ps-gogo-manajer/pkg/response"
 
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-
 	"github.com/labstack/echo/v4"
 )
 
 type RouteConfig struct {
-	App               *echo.Echo
-	S3Client          *s3.Client
-	FileHandler       *fileHandler.FileHandler
-	EmployeeHandler   *employeeHandler.EmployeeHandler
-	UserHandler       *userHandler.UserHandler
-	AuthMiddleware    echo.MiddlewareFunc
-	DepartmentHandler *departmentHandler.DepartmentHandler
+	App                   *echo.Echo
+	FileHandler           *fileHandler.FileHandler
+	EmployeeHandler       *employeeHandler.EmployeeHandler
+	UserHandler           *userHandler.UserHandler
+	AuthMiddleware        echo.MiddlewareFunc
+	ManagerAuthMiddleware echo.MiddlewareFunc
+	DepartmentHandler     *departmentHandler.DepartmentHandler
+	TrashHandler          *trashHandler.TrashHandler
+	CustomFieldHandler    *customFieldHandler.CustomFieldHandler
+	ChecklistHandler      *checklistHandler.ChecklistHandler
+	DocumentHandler       *documentHandler.DocumentHandler
+	DocTemplateHandler    *docTemplateHandler.DocumentTemplateHandler
+	LeaveHandler          *leaveHandler.LeaveHandler
+	AttendanceHandler     *attendanceHandler.AttendanceHandler
+	PayrollHandler        *payrollHandler.PayrollHandler
+	PositionHandler       *positionHandler.PositionHandler
+	JobTitleHandler       *jobTitleHandler.JobTitleHandler
+	LocationHandler       *locationHandler.LocationHandler
+	CostCenterHandler     *costCenterHandler.CostCenterHandler
 }
 
 func (r *RouteConfig) SetupRoutes() {
@@ -45,24 +67,61 @@ func (r *RouteConfig) setupAuthRoutes() {
 	r.setupUserRoute(v1)
 	r.setupFileRoutes(v1)
 	r.setupDepartmentRoute(v1)
+	r.setupTrashRoute(v1)
+	r.setupCustomFieldRoute(v1)
+	r.setupChecklistRoute(v1)
+	r.setupDocumentRoute(v1)
+	r.setupDocumentTemplateRoute(v1)
+	r.setupLeaveRoute(v1)
+	r.setupAttendanceRoute(v1)
+	r.setupPayrollRoute(v1)
+	r.setupPositionRoute(v1)
+	r.setupJobTitleRoute(v1)
+	r.setupLocationRoute(v1)
+	r.setupCostCenterRoute(v1)
 }
 
 func (r *RouteConfig) setupEmployeeRoute(api *echo.Group) {
-	employee := api.Group("/employee", r.AuthMiddleware)
+	employee := api.Group("/employee", r.ManagerAuthMiddleware)
 	employee.GET("", r.EmployeeHandler.GetListEmployee)
 	employee.POST("", r.EmployeeHandler.CreateEmployee)
+	employee.GET("/search", r.EmployeeHandler.SearchEmployee)
 	employee.PATCH("/:identityNumber", r.EmployeeHandler.UpdateEmployee)
 	employee.DELETE("/:identityNumber", r.EmployeeHandler.DeleteEmployee)
+	employee.POST("/:identityNumber/restore", r.EmployeeHandler.RestoreEmployee)
+	employee.GET("/:identityNumber/history", r.EmployeeHandler.GetEmployeeHistory)
+	employee.POST("/:identityNumber/history/:version/revert", r.EmployeeHandler.RevertEmployee)
+	employee.GET("/:identityNumber/reports", r.EmployeeHandler.GetReports)
+	employee.DELETE("/:identityNumber/manager", r.EmployeeHandler.RemoveManager)
+	employee.POST("/:identityNumber/status", r.EmployeeHandler.ChangeStatus)
+	employee.GET("/:identityNumber/status-history", r.EmployeeHandler.GetStatusHistory)
+	employee.POST("/:identityNumber/transfer", r.EmployeeHandler.TransferEmployee)
+	employee.GET("/:identityNumber/department-history", r.EmployeeHandler.GetDepartmentHistory)
+	employee.GET("/:identityNumber/department", r.EmployeeHandler.GetDepartmentAsOf)
+
+	api.GET("/org-chart", r.EmployeeHandler.GetOrgChart, r.ManagerAuthMiddleware)
 }
 
 func (r *RouteConfig) setupUserRoute(api *echo.Group) {
 	user := api.Group("/user", r.AuthMiddleware)
 	user.GET("", r.UserHandler.GetUser)
 	user.PATCH("", r.UserHandler.UpdateUser)
+
+	managerLogin := api.Group("/employee/:identityNumber/manager-login", r.AuthMiddleware)
+
+	managerLogin.PUT("", r.UserHandler.SetManagerLogin)
+	managerLogin.DELETE("", r.UserHandler.DeleteManagerLogin)
 }
 
 func (r *RouteConfig) setupFileRoutes(api *echo.Group) {
 	api.POST("/file", r.FileHandler.UploadFile, r.AuthMiddleware)
+	api.POST("/file/upload", r.FileHandler.CreateUpload, r.AuthMiddleware)
+	api.POST("/file/upload/complete", r.FileHandler.CompleteUpload, r.AuthMiddleware)
+	// * The presigned upload of the local storage, the key of the pending
+	// upload authorizes it
+	api.PUT("/file/upload/*", r.FileHandler.ReceiveUpload)
+	// * Public files are linked from anywhere, so they are served without auth
+	api.GET("/file/*", r.FileHandler.ServeFile)
 }
 
 func (r *RouteConfig) setupDepartmentRoute(api *echo.Group) {
@@ -70,6 +129,191 @@ func (r *RouteConfig) setupDepartmentRoute(api *echo.Group) {
 
 	department.GET("", r.DepartmentHandler.GetListDepartment)
 	department.POST("", r.DepartmentHandler.CreateDepartment)
+	department.GET("/:departmentId", r.DepartmentHandler.GetDepartment)
 	department.PATCH("/:departmentId", r.DepartmentHandler.UpdateDepartment)
 	department.DELETE("/:departmentId", r.DepartmentHandler.DeleteDepartment)
+	department.POST("/:departmentId/restore", r.DepartmentHandler.RestoreDepartment)
+	department.POST("/:departmentId/archive", r.DepartmentHandler.ArchiveDepartment)
+	department.POST("/:departmentId/unarchive", r.DepartmentHandler.UnarchiveDepartment)
+	department.POST("/:departmentId/move", r.DepartmentHandler.MoveDepartment)
+	department.GET("/:departmentId/subtree", r.DepartmentHandler.GetDepartmentSubtree)
+	department.POST("/:departmentId/merge", r.DepartmentHandler.MergeDepartment)
+	department.POST("/:departmentId/split", r.DepartmentHandler.SplitDepartment)
+	department.GET("/:departmentId/head", r.DepartmentHandler.GetDepartmentHeads)
+	department.POST("/:departmentId/head", r.DepartmentHandler.AssignDepartmentHead)
+	department.DELETE("/:departmentId/head/:identityNumber", r.DepartmentHandler.RemoveDepartmentHead)
+}
+
+func (r *RouteConfig) setupTrashRoute(api *echo.Group) {
+	api.GET("/trash", r.TrashHandler.GetTrash, r.AuthMiddleware)
+}
+
+func (r *RouteConfig) setupCustomFieldRoute(api *echo.Group) {
+	customField := api.Group("/custom-field", r.AuthMiddleware)
+
+	customField.GET("", r.CustomFieldHandler.GetListCustomField)
+	customField.POST("", r.CustomFieldHandler.CreateCustomField)
+	customField.PATCH("/:customFieldId", r.CustomFieldHandler.UpdateCustomField)
+	customField.DELETE("/:customFieldId", r.CustomFieldHandler.DeleteCustomField)
+}
+
+func (r *RouteConfig) setupChecklistRoute(api *echo.Group) {
+	template := api.Group("/checklist-template", r.AuthMiddleware)
+
+	template.GET("", r.ChecklistHandler.GetListTemplate)
+	template.POST("", r.ChecklistHandler.CreateTemplate)
+	template.PATCH("/:templateId", r.ChecklistHandler.UpdateTemplate)
+	template.DELETE("/:templateId", r.ChecklistHandler.DeleteTemplate)
+
+	checklist := api.Group("/checklist", r.AuthMiddleware)
+
+	checklist.GET("/overdue", r.ChecklistHandler.GetOverdueTasks)
+	checklist.POST("/:checklistId/task/:taskId/complete", r.ChecklistHandler.CompleteTask)
+
+	api.GET("/employee/:identityNumber/checklist", r.ChecklistHandler.GetEmployeeChecklists, r.AuthMiddleware)
+}
+
+func (r *RouteConfig) setupDocumentRoute(api *echo.Group) {
+	document := api.Group("/employee/:identityNumber/document", r.AuthMiddleware)
+
+	document.GET("", r.DocumentHandler.GetListDocument)
+	document.POST("", r.DocumentHandler.UploadDocument)
+	document.GET("/:documentId/download", r.DocumentHandler.DownloadDocument)
+	document.DELETE("/:documentId", r.DocumentHandler.DeleteDocument)
+
+	api.GET("/document/expiring", r.DocumentHandler.GetExpiringDocument, r.AuthMiddleware)
+}
+
+func (r *RouteConfig) setupDocumentTemplateRoute(api *echo.Group) {
+	template := api.Group("/document-template", r.AuthMiddleware)
+
+	template.GET("", r.DocTemplateHandler.GetListTemplate)
+	template.POST("", r.DocTemplateHandler.CreateTemplate)
+	template.GET("/merge-fields", r.DocTemplateHandler.GetMergeFields)
+	template.GET("/:templateId", r.DocTemplateHandler.GetTemplate)
+	template.PATCH("/:templateId", r.DocTemplateHandler.UpdateTemplate)
+	template.DELETE("/:templateId", r.DocTemplateHandler.DeleteTemplate)
+
+	api.POST("/employee/:identityNumber/document/generate", r.DocTemplateHandler.GenerateDocument, r.AuthMiddleware)
+}
+
+func (r *RouteConfig) setupLeaveRoute(api *echo.Group) {
+	leaveType := api.Group("/leave-type", r.AuthMiddleware)
+
+	leaveType.GET("", r.LeaveHandler.GetListLeaveType)
+	leaveType.POST("", r.LeaveHandler.CreateLeaveType)
+	leaveType.PATCH("/:leaveTypeId", r.LeaveHandler.UpdateLeaveType)
+	leaveType.DELETE("/:leaveTypeId", r.LeaveHandler.DeleteLeaveType)
+
+	holiday := api.Group("/holiday", r.AuthMiddleware)
+
+	holiday.GET("", r.LeaveHandler.GetListHoliday)
+	holiday.POST("", r.LeaveHandler.CreateHoliday)
+	holiday.DELETE("/:holidayId", r.LeaveHandler.DeleteHoliday)
+
+	leave := api.Group("/leave", r.AuthMiddleware)
+
+	leave.GET("", r.LeaveHandler.GetListLeaveRequest)
+	leave.POST("/:leaveRequestId/cancel", r.LeaveHandler.CancelLeaveRequest)
+
+	// * Managers review the requests of their reports from their own login
+	api.POST("/leave/:leaveRequestId/approve", r.LeaveHandler.ApproveLeaveRequest, r.ManagerAuthMiddleware)
+	api.POST("/leave/:leaveRequestId/reject", r.LeaveHandler.RejectLeaveRequest, r.ManagerAuthMiddleware)
+
+	employeeLeave := api.Group("/employee/:identityNumber", r.AuthMiddleware)
+
+	employeeLeave.GET("/leave", r.LeaveHandler.GetListLeaveRequest)
+	employeeLeave.POST("/leave", r.LeaveHandler.CreateLeaveRequest)
+	employeeLeave.GET("/leave-balance", r.LeaveHandler.GetLeaveBalance)
+	employeeLeave.PUT("/leave-entitlement", r.LeaveHandler.SetEntitlement)
+
+	api.GET("/department/:departmentId/leave-calendar", r.LeaveHandler.GetTeamCalendar, r.AuthMiddleware)
+}
+
+func (r *RouteConfig) setupAttendanceRoute(api *echo.Group) {
+	workSchedule := api.Group("/work-schedule", r.AuthMiddleware)
+
+	workSchedule.GET("", r.AttendanceHandler.GetListWorkSchedule)
+	workSchedule.POST("", r.AttendanceHandler.CreateWorkSchedule)
+	workSchedule.PATCH("/:workScheduleId", r.AttendanceHandler.UpdateWorkSchedule)
+	workSchedule.DELETE("/:workScheduleId", r.AttendanceHandler.DeleteWorkSchedule)
+
+	employeeAttendance := api.Group("/employee/:identityNumber", r.AuthMiddleware)
+
+	employeeAttendance.PUT("/work-schedule", r.AttendanceHandler.AssignWorkSchedule)
+	employeeAttendance.POST("/clock-in", r.AttendanceHandler.ClockIn)
+	employeeAttendance.POST("/clock-out", r.AttendanceHandler.ClockOut)
+	employeeAttendance.GET("/attendance", r.AttendanceHandler.GetAttendance)
+
+	api.GET("/department/:departmentId/attendance-report", r.AttendanceHandler.GetDepartmentReport, r.AuthMiddleware)
+}
+
+func (r *RouteConfig) setupPayrollRoute(api *echo.Group) {
+	payrollRun := api.Group("/payroll-run", r.AuthMiddleware)
+
+	payrollRun.GET("", r.PayrollHandler.GetListPayrollRun)
+	payrollRun.POST("", r.PayrollHandler.CreatePayrollRun)
+	payrollRun.GET("/preview", r.PayrollHandler.PreviewPayroll)
+	payrollRun.GET("/:payrollRunId", r.PayrollHandler.GetPayrollRun)
+	payrollRun.DELETE("/:payrollRunId", r.PayrollHandler.DeletePayrollRun)
+	payrollRun.POST("/:payrollRunId/recalculate", r.PayrollHandler.RecalculatePayrollRun)
+	payrollRun.POST("/:payrollRunId/lock", r.PayrollHandler.LockPayrollRun)
+	payrollRun.POST("/:payrollRunId/reopen", r.PayrollHandler.ReopenPayrollRun)
+	payrollRun.GET("/:payrollRunId/export", r.PayrollHandler.ExportPayrollRun)
+	payrollRun.GET("/:payrollRunId/employee/:identityNumber", r.PayrollHandler.GetPayrollItem)
+
+	employeeSalary := api.Group("/employee/:identityNumber", r.AuthMiddleware)
+
+	employeeSalary.GET("/salary", r.PayrollHandler.GetSalaryStructure)
+	employeeSalary.PUT("/salary", r.PayrollHandler.SetSalaryStructure)
+
+	calculator := api.Group("/payroll-calculator", r.AuthMiddleware)
+
+	calculator.GET("/versions", r.PayrollHandler.GetStatutoryVersions)
+	calculator.POST("/monthly", r.PayrollHandler.CalculateMonthly)
+	calculator.POST("/annual", r.PayrollHandler.CalculateAnnual)
+}
+
+func (r *RouteConfig) setupPositionRoute(api *echo.Group) {
+	position := api.Group("/position", r.AuthMiddleware)
+
+	position.GET("", r.PositionHandler.GetListPosition)
+	position.POST("", r.PositionHandler.CreatePosition)
+	position.GET("/headcount", r.PositionHandler.GetHeadcount)
+	position.GET("/:positionId", r.PositionHandler.GetPosition)
+	position.PATCH("/:positionId", r.PositionHandler.UpdatePosition)
+	position.DELETE("/:positionId", r.PositionHandler.DeletePosition)
+}
+
+func (r *RouteConfig) setupJobTitleRoute(api *echo.Group) {
+	jobTitle := api.Group("/job-title", r.AuthMiddleware)
+
+	jobTitle.GET("", r.JobTitleHandler.GetListJobTitle)
+	jobTitle.POST("", r.JobTitleHandler.CreateJobTitle)
+	jobTitle.GET("/:jobTitleId", r.JobTitleHandler.GetJobTitle)
+	jobTitle.PATCH("/:jobTitleId", r.JobTitleHandler.UpdateJobTitle)
+	jobTitle.DELETE("/:jobTitleId", r.JobTitleHandler.DeleteJobTitle)
+	jobTitle.GET("/:jobTitleId/employee", r.JobTitleHandler.GetJobTitleEmployees)
+}
+
+func (r *RouteConfig) setupLocationRoute(api *echo.Group) {
+	location := api.Group("/location", r.AuthMiddleware)
+
+	location.GET("", r.LocationHandler.GetListLocation)
+	location.POST("", r.LocationHandler.CreateLocation)
+	location.GET("/breakdown", r.LocationHandler.GetLocationBreakdown)
+	location.GET("/:locationId", r.LocationHandler.GetLocation)
+	location.PATCH("/:locationId", r.LocationHandler.UpdateLocation)
+	location.DELETE("/:locationId", r.LocationHandler.DeleteLocation)
+}
+
+func (r *RouteConfig) setupCostCenterRoute(api *echo.Group) {
+	costCenter := api.Group("/cost-center", r.AuthMiddleware)
+
+	costCenter.GET("", r.CostCenterHandler.GetListCostCenter)
+	costCenter.POST("", r.CostCenterHandler.CreateCostCenter)
+	costCenter.GET("/breakdown", r.CostCenterHandler.GetCostCenterBreakdown)
+	costCenter.GET("/:costCenterId", r.CostCenterHandler.GetCostCenter)
+	costCenter.PATCH("/:costCenterId", r.CostCenterHandler.UpdateCostCenter)
+	costCenter.DELETE("/:costCenterId", r.CostCenterHandler.DeleteCostCenter)
 }
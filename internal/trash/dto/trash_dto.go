@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type TrashedEmployee struct {
+	Name             string    `json:"name"`
+	IdentityNumber   string    `json:"identityNumber"`
+	Gender           string    `json:"gender"`
+	DepartmentId     string    `json:"departmentId"`
+	EmployeeImageUri string    `json:"employeeImageUri"`
+	DeletedAt        time.Time `json:"deletedAt"`
+	PurgeAt          time.Time `json:"purgeAt"`
+}
+
+type TrashedDepartment struct {
+	DepartmentId string    `json:"departmentId"`
+	Name         string    `json:"name"`
+	DeletedAt    time.Time `json:"deletedAt"`
+	PurgeAt      time.Time `json:"purgeAt"`
+}
+
+type Trash struct {
+	Employees   []TrashedEmployee   `json:"employees"`
+	Departments []TrashedDepartment `json:"departments"`
+}
+
+type GetTrashParams struct {
+	Limit  int
+	Offset int
+	Type   string `query:"type" validate:"omitempty,oneof=employee department"`
+}
+
+type PurgeResult struct {
+	Employees   int64
+	Departments int64
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"ps-gogo-manajer/internal/trash/dto"
+	"ps-gogo-manajer/internal/trash/usecase"
+	customErrors "ps-gogo-manajer/pkg/custom-errors"
+	customValidators "ps-gogo-manajer/pkg/custom-validators"
+	"ps-gogo-manajer/pkg/jwt"
+	"ps-gogo-manajer/pkg/response"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type TrashHandler struct {
+	trashUsecase usecase.TrashUsecase
+	validator    *validator.Validate
+}
+
+const (
+	DEFAULT_LIMIT  = 5
+	DEFAULT_OFFSET = 0
+)
+
+func NewTrashHandler(trashUsecase usecase.TrashUsecase, validator *validator.Validate) *TrashHandler {
+	return &TrashHandler{
+		trashUsecase: trashUsecase,
+		validator:    validator,
+	}
+}
+
+func (h TrashHandler) GetTrash(ctx echo.Context) error {
+	limitStr := ctx.QueryParam("limit")
+	offsetStr := ctx.QueryParam("offset")
+
+	payload := dto.GetTrashParams{
+		Limit:  customValidators.ParseLimitOffset(limitStr, DEFAULT_LIMIT),
+		Offset: customValidators.ParseLimitOffset(offsetStr, DEFAULT_OFFSET),
+	}
+
+	if err := ctx.Bind(&payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	trash, err := h.trashUsecase.GetTrash(ctx.Request().Context(), userData.Id, &payload)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, trash)
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/trash/dto"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type TrashRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTrashRepository(pool *pgxpool.Pool) *TrashRepository {
+	return &TrashRepository{pool: pool}
+}
+
+const (
+	queryGetTrashedEmployees = `
+	SELECT
+		name,
+		identity_number,
+		gender,
+		department_id,
+		employee_image_uri,
+		deleted_at
+	FROM employees
+	WHERE
+		user_id = @userID
+		AND deleted_at IS NOT NULL
+	ORDER BY deleted_at DESC, id DESC
+	OFFSET @offset
+	LIMIT @limit;`
+	queryGetTrashedDepartments = `
+	SELECT
+		id,
+		name,
+		deleted_at
+	FROM departments
+	WHERE
+		user_id = @userID
+		AND deleted_at IS NOT NULL
+	ORDER BY deleted_at DESC, id DESC
+	OFFSET @offset
+	LIMIT @limit;`
+	queryPurgeEmployees = `
+	DELETE FROM employees
+	WHERE
+		deleted_at IS NOT NULL
+		AND deleted_at < @before;`
+	queryPurgeDepartments = `
+	DELETE FROM departments
+	WHERE
+		deleted_at IS NOT NULL
+		AND deleted_at < @before
+		AND NOT EXISTS (
+			SELECT 1
+			FROM employees
+			WHERE employees.department_id = departments.id
+		);`
+)
+
+func (r *TrashRepository) GetTrashedEmployees(ctx context.Context, userID int, payload *dto.GetTrashParams) ([]dto.TrashedEmployee, error) {
+	employees := make([]dto.TrashedEmployee, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"limit":  payload.Limit,
+		"offset": payload.Offset,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetTrashedEmployees, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get trashed employees")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		employee := dto.TrashedEmployee{}
+		imgUri := new(pgtype.Text)
+
+		err := rows.Scan(
+			&employee.Name,
+			&employee.IdentityNumber,
+			&employee.Gender,
+			&employee.DepartmentId,
+			imgUri,
+			&employee.DeletedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+
+		employee.EmployeeImageUri = imgUri.String
+		employees = append(employees, employee)
+	}
+
+	return employees, nil
+}
+
+func (r *TrashRepository) GetTrashedDepartments(ctx context.Context, userID int, payload *dto.GetTrashParams) ([]dto.TrashedDepartment, error) {
+	departments := make([]dto.TrashedDepartment, 0)
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"limit":  payload.Limit,
+		"offset": payload.Offset,
+	}
+
+	rows, err := r.pool.Query(ctx, queryGetTrashedDepartments, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get trashed departments")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		department := dto.TrashedDepartment{}
+		err := rows.Scan(
+			&department.DepartmentId,
+			&department.Name,
+			&department.DeletedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sql response")
+		}
+		departments = append(departments, department)
+	}
+
+	return departments, nil
+}
+
+// PurgeExpired permanently removes every row that was trashed before the
+// given time. Employees go first so their departments can follow; a trashed
+// department that still has employees referencing it is kept until those
+// employees are purged as well.
+func (r *TrashRepository) PurgeExpired(ctx context.Context, before time.Time) (*dto.PurgeResult, error) {
+	var result dto.PurgeResult
+	args := pgx.NamedArgs{
+		"before": before,
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, queryPurgeEmployees, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to purge employees")
+	}
+	result.Employees = tag.RowsAffected()
+
+	tag, err = tx.Exec(ctx, queryPurgeDepartments, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to purge departments")
+	}
+	result.Departments = tag.RowsAffected()
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to commit purge")
+	}
+
+	return &result, nil
+}
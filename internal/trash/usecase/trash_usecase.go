@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/trash/dto"
+	"ps-gogo-manajer/internal/trash/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type TrashUsecase struct {
+	trashRepo repository.TrashRepository
+	retention time.Duration
+	log       *logrus.Logger
+}
+
+func NewTrashUsecase(trashRepo repository.TrashRepository, retentionDays int, log *logrus.Logger) *TrashUsecase {
+	return &TrashUsecase{
+		trashRepo: trashRepo,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		log:       log,
+	}
+}
+
+func (u *TrashUsecase) GetTrash(ctx context.Context, userID int, payload *dto.GetTrashParams) (*dto.Trash, error) {
+	trash := dto.Trash{
+		Employees:   make([]dto.TrashedEmployee, 0),
+		Departments: make([]dto.TrashedDepartment, 0),
+	}
+
+	if payload.Type == "" || payload.Type == "employee" {
+		employees, err := u.trashRepo.GetTrashedEmployees(ctx, userID, payload)
+		if err != nil {
+			return nil, err
+		}
+		for i := range employees {
+			employees[i].PurgeAt = employees[i].DeletedAt.Add(u.retention)
+		}
+		trash.Employees = employees
+	}
+
+	if payload.Type == "" || payload.Type == "department" {
+		departments, err := u.trashRepo.GetTrashedDepartments(ctx, userID, payload)
+		if err != nil {
+			return nil, err
+		}
+		for i := range departments {
+			departments[i].PurgeAt = departments[i].DeletedAt.Add(u.retention)
+		}
+		trash.Departments = departments
+	}
+
+	return &trash, nil
+}
+
+// PurgeExpired is run by the scheduler and permanently deletes everything
+// that has been in the trash for longer than the retention period.
+func (u *TrashUsecase) PurgeExpired(ctx context.Context) error {
+	result, err := u.trashRepo.PurgeExpired(ctx, time.Now().Add(-u.retention))
+	if err != nil {
+		return err
+	}
+
+	if result.Employees > 0 || result.Departments > 0 {
+		u.log.WithFields(logrus.Fields{
+			"employees":   result.Employees,
+			"departments": result.Departments,
+		}).Info("purged expired trash")
+	}
+
+	return nil
+}
@@ -3,7 +3,7 @@ package dto
 type AuthRequest struct {
 	Password string `json:"password" validate:"required,min=8,max=32"`
 	Email    string `json:"email" validate:"required,email,min=1,max=255"`
-	Action   string `json:"action" validate:"required,oneof=create login"`
+	Action   string `json:"action" validate:"required,oneof=create login manager"`
 }
 
 type AuthResponse struct {
@@ -26,3 +26,14 @@ type UpdateUserRequest struct {
 	CompanyName     *string `json:"companyName" validate:"required,min=4,max=52"`
 	CompanyImageUri *string `json:"companyImageUri" validate:"required"`
 }
+
+type ManagerLoginRequest struct {
+	IdentityNumber string `param:"identityNumber" validate:"required"`
+	Email          string `json:"email" validate:"required,email,min=1,max=255"`
+	Password       string `json:"password" validate:"required,min=8,max=32"`
+}
+
+type ManagerLoginResponse struct {
+	IdentityNumber string `json:"identityNumber"`
+	Email          string `json:"email"`
+}
@@ -55,6 +55,11 @@ func (c *UserHandler) AuthenticateUser(ctx echo.Context) error {
 		statusCode = http.StatusOK
 	}
 
+	if request.Action == "manager" {
+		token, err = c.UseCase.ManagerLogin(ctx.Request().Context(), request)
+		statusCode = http.StatusOK
+	}
+
 	if err != nil {
 		return ctx.JSON(response.WriteErrorResponse(err))
 	}
@@ -127,3 +132,38 @@ func (c *UserHandler) UpdateUser(ctx echo.Context) error {
 		CompanyImageUri: helper.DerefString(user.CompanyImageUri, ""),
 	})
 }
+
+func (c *UserHandler) SetManagerLogin(ctx echo.Context) error {
+	var request = new(dto.ManagerLoginRequest)
+
+	if err := ctx.Bind(request); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		err = errors.Wrap(customErrors.ErrBadRequest, err.Error())
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	login, err := c.UseCase.SetManagerLogin(ctx.Request().Context(), request, userData.Id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, login)
+}
+
+func (c *UserHandler) DeleteManagerLogin(ctx echo.Context) error {
+	userData := ctx.Get("user").(*jwt.JwtClaim)
+	err := c.UseCase.DeleteManagerLogin(ctx.Request().Context(), ctx.Param("identityNumber"), userData.Id)
+	if err != nil {
+		return ctx.JSON(response.WriteErrorResponse(err))
+	}
+
+	return ctx.JSON(http.StatusOK, response.BaseResponse{
+		Status:  http.StatusText(http.StatusOK),
+		Message: "deleted",
+	})
+}
@@ -9,3 +9,12 @@ type User struct {
 	CompanyName     *string
 	CompanyImageUri *string
 }
+
+// ManagerLogin holds the credentials a department head signs in with.
+type ManagerLogin struct {
+	EmployeeID     int64
+	UserID         int
+	Email          string
+	HashedPassword string
+	IsHead         bool
+}
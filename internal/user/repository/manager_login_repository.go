@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"ps-gogo-manajer/internal/user/model"
+)
+
+const upsertManagerLogin = `-- name: UpsertManagerLogin :one
+INSERT INTO manager_logins (
+  employee_id,
+  email,
+  hashed_password
+)
+SELECT id, $3, $4
+FROM employees
+WHERE user_id = $1 AND identity_number = $2 AND deleted_at IS NULL
+ON CONFLICT (employee_id) DO UPDATE
+SET
+  email = EXCLUDED.email,
+  hashed_password = EXCLUDED.hashed_password,
+  updated_at = NOW()
+RETURNING email
+`
+
+type UpsertManagerLoginParams struct {
+	UserID         int
+	IdentityNumber string
+	Email          string
+	HashedPassword string
+}
+
+func (r *UserRepository) UpsertManagerLogin(ctx context.Context, arg UpsertManagerLoginParams) (string, error) {
+	row := r.pool.QueryRow(ctx, upsertManagerLogin,
+		arg.UserID,
+		arg.IdentityNumber,
+		arg.Email,
+		arg.HashedPassword,
+	)
+	var email string
+	err := row.Scan(&email)
+	return email, err
+}
+
+const deleteManagerLogin = `-- name: DeleteManagerLogin :execrows
+DELETE FROM manager_logins
+USING employees
+WHERE
+  employees.id = manager_logins.employee_id
+  AND employees.user_id = $1
+  AND employees.identity_number = $2
+  AND employees.deleted_at IS NULL
+`
+
+func (r *UserRepository) DeleteManagerLogin(ctx context.Context, userID int, identityNumber string) (int64, error) {
+	result, err := r.pool.Exec(ctx, deleteManagerLogin, userID, identityNumber)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getManagerLoginFromEmail = `-- name: GetManagerLoginFromEmail :one
+SELECT
+  manager_logins.employee_id,
+  employees.user_id,
+  manager_logins.email,
+  manager_logins.hashed_password,
+  EXISTS (
+    SELECT 1
+    FROM department_heads
+    JOIN departments ON departments.id = department_heads.department_id
+    WHERE department_heads.employee_id = employees.id AND departments.deleted_at IS NULL
+  ) is_head
+FROM manager_logins
+JOIN employees ON employees.id = manager_logins.employee_id
+WHERE
+  manager_logins.email = $1
+  AND employees.deleted_at IS NULL
+  AND employees.status <> 'terminated'
+LIMIT 1
+`
+
+func (r *UserRepository) GetManagerLoginFromEmail(ctx context.Context, email string) (model.ManagerLogin, error) {
+	row := r.pool.QueryRow(ctx, getManagerLoginFromEmail, email)
+	var i model.ManagerLogin
+	err := row.Scan(
+		&i.EmployeeID,
+		&i.UserID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.IsHead,
+	)
+	return i, err
+}
@@ -76,6 +76,36 @@ func (c *UserUseCase) Login(ctx context.Context, request *dto.AuthRequest) (*str
 	return &token, nil
 }
 
+// ManagerLogin signs a department head in. The token only reaches the
+// employees of the departments the head manages.
+func (c *UserUseCase) ManagerLogin(ctx context.Context, request *dto.AuthRequest) (*string, error) {
+
+	login, err := c.userRepo.GetManagerLoginFromEmail(ctx, request.Email)
+
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "Manager not found")
+		}
+		return nil, errors.Wrap(err, "failed to get manager")
+	}
+
+	err = bcrypt.ComparePassword(request.Password, login.HashedPassword)
+	if err != nil {
+		return nil, errors.Wrap(customErrors.ErrBadRequest, "password is wrong")
+	}
+
+	if !login.IsHead {
+		return nil, errors.Wrap(customErrors.ErrForbidden, "employee is not a head of any department")
+	}
+
+	token, err := jwt.CreateManagerToken(login.UserID, login.Email, login.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
 func (c *UserUseCase) GetUser(ctx context.Context, userid int) (*model.User, error) {
 
 	user, err := c.userRepo.GetUser(ctx, userid)
@@ -113,3 +143,48 @@ func (c *UserUseCase) UpdateUser(ctx context.Context, request *dto.UpdateUserReq
 
 	return &user, nil
 }
+
+// SetManagerLogin sets the email and password an employee signs in with as
+// a manager, replacing any credentials set before.
+func (c *UserUseCase) SetManagerLogin(ctx context.Context, request *dto.ManagerLoginRequest, userid int) (*dto.ManagerLoginResponse, error) {
+	hashedPassword, err := bcrypt.HashPassword(request.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := repository.UpsertManagerLoginParams{
+		UserID:         userid,
+		IdentityNumber: request.IdentityNumber,
+		Email:          request.Email,
+		HashedPassword: hashedPassword,
+	}
+
+	email, err := c.userRepo.UpsertManagerLogin(ctx, arg)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, errors.Wrap(customErrors.ErrNotFound, "employee not found")
+		}
+		if repository.ErrorCode(err) == repository.UniqueViolation {
+			return nil, errors.Wrap(customErrors.ErrConflict, "email is exist")
+		}
+		return nil, errors.Wrap(err, "failed to set manager login")
+	}
+
+	return &dto.ManagerLoginResponse{
+		IdentityNumber: request.IdentityNumber,
+		Email:          email,
+	}, nil
+}
+
+func (c *UserUseCase) DeleteManagerLogin(ctx context.Context, identityNumber string, userid int) error {
+	deleted, err := c.userRepo.DeleteManagerLogin(ctx, userid, identityNumber)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete manager login")
+	}
+
+	if deleted == 0 {
+		return errors.Wrap(customErrors.ErrNotFound, "manager login not found")
+	}
+
+	return nil
+}
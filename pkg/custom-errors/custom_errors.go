@@ -10,4 +10,5 @@ var (
 	ErrConflict     = errors.New("conflict")
 	ErrBadRequest   = errors.New("bad request")
 	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
 )
@@ -34,19 +34,24 @@ func ParseGender(genderStr string) (string, bool) {
 }
 
 func ParseDepartmentID(id string) (int, bool) {
-	if id == "" {
+	return ParseID(id)
+}
+
+// ParseID parses an optional id filter of a query string, 0 when empty.
+func ParseID(val string) (int, bool) {
+	if val == "" {
 		return 0, true
 	}
-	departmentID, err := strconv.Atoi(id)
+	id, err := strconv.Atoi(val)
 	if err != nil {
 		return 0, false
 	}
 
-	if departmentID < 1 {
+	if id < 1 {
 		return 0, false
 	}
 
-	return departmentID, true
+	return id, true
 }
 
 func ParseURI(uri string) (string, bool) {
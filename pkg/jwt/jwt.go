@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"context"
 	"os"
 	"time"
 
@@ -11,18 +12,39 @@ import (
 type JwtClaim struct {
 	Id    int    `json:"id"`
 	Email string `json:"email"`
+	// EmployeeId is only set for manager logins. Id is then the user the
+	// manager's employee belongs to.
+	EmployeeId int64 `json:"employeeId,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsManager reports whether the token belongs to a department head rather
+// than to the user owning the account.
+func (c *JwtClaim) IsManager() bool {
+	return c.EmployeeId != 0
+}
+
 func CreateToken(id int, email string) (string, error) {
-	secret := []byte(os.Getenv("JWT_SECRET"))
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &JwtClaim{
+	return signToken(&JwtClaim{
 		Id:    id,
 		Email: email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(8 * time.Hour)),
-		},
 	})
+}
+
+func CreateManagerToken(id int, email string, employeeID int64) (string, error) {
+	return signToken(&JwtClaim{
+		Id:         id,
+		Email:      email,
+		EmployeeId: employeeID,
+	})
+}
+
+func signToken(claim *JwtClaim) (string, error) {
+	secret := []byte(os.Getenv("JWT_SECRET"))
+	claim.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(8 * time.Hour)),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
 
 	ss, err := token.SignedString(secret)
 	if err != nil {
@@ -53,3 +75,16 @@ func ClaimToken(token string) (*JwtClaim, error) {
 		return nil, errors.New("Invalid token")
 	}
 }
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the claim, so code that only gets
+// the request context can tell who is asking.
+func NewContext(ctx context.Context, claim *JwtClaim) context.Context {
+	return context.WithValue(ctx, contextKey{}, claim)
+}
+
+func FromContext(ctx context.Context) (*JwtClaim, bool) {
+	claim, ok := ctx.Value(contextKey{}).(*JwtClaim)
+	return claim, ok
+}
@@ -0,0 +1,164 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Column is a sortable column. Expr is the SQL expression that is sorted on
+// and Type the SQL type the cursor value is cast back to when comparing.
+type Column struct {
+	Expr string
+	Type string
+}
+
+type Sort struct {
+	Key    string
+	Column Column
+	Desc   bool
+}
+
+// Cursor points at the row a page starts after (or before, when Backward).
+// It is handed to clients as an opaque base64 string.
+type Cursor struct {
+	Sort     string `json:"s"`
+	Value    string `json:"v"`
+	ID       int64  `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+type Page[T any] struct {
+	Data       []T     `json:"data"`
+	NextCursor *string `json:"nextCursor"`
+	PrevCursor *string `json:"prevCursor"`
+	Total      *int    `json:"total,omitempty"`
+}
+
+// ParseSort parses a sort parameter such as "name" or "-name" (descending)
+// against a whitelist of columns. An empty value falls back to defaultKey.
+func ParseSort(val string, columns map[string]Column, defaultKey string) (*Sort, bool) {
+	if val == "" {
+		val = defaultKey
+	}
+
+	desc := strings.HasPrefix(val, "-")
+	key := strings.TrimPrefix(val, "-")
+
+	column, ok := columns[key]
+	if !ok {
+		return nil, false
+	}
+
+	return &Sort{
+		Key:    val,
+		Column: column,
+		Desc:   desc,
+	}, true
+}
+
+// OrderBy returns the ORDER BY list for the sort, using idExpr as the
+// tie-breaker so the order is total. Backward pages are read in reverse.
+func (s *Sort) OrderBy(idExpr string, backward bool) string {
+	dir := "ASC"
+	if s.Desc != backward {
+		dir = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s, %s %s", s.Column.Expr, dir, idExpr, dir)
+}
+
+// KeysetCondition returns the row comparison that selects rows after the
+// cursor, bound to the @cursorValue and @cursorID named arguments.
+func (s *Sort) KeysetCondition(idExpr string, backward bool) string {
+	op := ">"
+	if s.Desc != backward {
+		op = "<"
+	}
+
+	return fmt.Sprintf("(%s, %s) %s (@cursorValue::%s, @cursorID::bigint)", s.Column.Expr, idExpr, op, s.Column.Type)
+}
+
+// Query fills the /*sortValue*/, /*keyset*/ and /*orderBy*/ placeholders of
+// a list query. Without a cursor the keyset condition is simply TRUE.
+func (s *Sort) Query(query string, idExpr string, cursor *Cursor) string {
+	backward := cursor != nil && cursor.Backward
+	condition := "TRUE"
+	if cursor != nil {
+		condition = s.KeysetCondition(idExpr, backward)
+	}
+
+	return strings.NewReplacer(
+		"/*sortValue*/", s.Column.Expr,
+		"/*keyset*/", condition,
+		"/*orderBy*/", s.OrderBy(idExpr, backward),
+	).Replace(query)
+}
+
+func EncodeCursor(cursor Cursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(val string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(val)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, errors.Wrap(err, "malformed cursor")
+	}
+
+	return &cursor, nil
+}
+
+// Row is what a repository reads for each record of a keyset page: the
+// record itself plus the sort value and id the cursors are built from.
+type Row[T any] struct {
+	Item      T
+	SortValue string
+	ID        int64
+}
+
+// NewPage trims the limit+1 rows fetched for a keyset page to the page size
+// and builds the next and previous cursors around it.
+func NewPage[T any](rows []Row[T], limit int, sort *Sort, cursor *Cursor) *Page[T] {
+	backward := cursor != nil && cursor.Backward
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	// * Backward pages are read in reverse order
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	page := Page[T]{Data: make([]T, 0, len(rows))}
+	for _, row := range rows {
+		page.Data = append(page.Data, row.Item)
+	}
+
+	if len(rows) == 0 {
+		return &page
+	}
+
+	first, last := rows[0], rows[len(rows)-1]
+	if (backward && hasMore) || (!backward && cursor != nil) {
+		prev := EncodeCursor(Cursor{Sort: sort.Key, Value: first.SortValue, ID: first.ID, Backward: true})
+		page.PrevCursor = &prev
+	}
+	if (!backward && hasMore) || backward {
+		next := EncodeCursor(Cursor{Sort: sort.Key, Value: last.SortValue, ID: last.ID})
+		page.NextCursor = &next
+	}
+
+	return &page
+}
@@ -0,0 +1,257 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+var testColumns = map[string]Column{
+	"name":      {Expr: "employees.name", Type: "text"},
+	"createdAt": {Expr: "employees.created_at", Type: "timestamptz"},
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{name: "forward", cursor: Cursor{Sort: "name", Value: "Budi", ID: 42}},
+		{name: "backward", cursor: Cursor{Sort: "-createdAt", Value: "2024-03-01T08:00:00Z", ID: 7, Backward: true}},
+		{name: "empty value", cursor: Cursor{Sort: "name", ID: 1}},
+		{name: "special characters", cursor: Cursor{Sort: "name", Value: "Siti \"Nur\" / Ayu, 田中", ID: 9007199254740993}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decoded, err := DecodeCursor(EncodeCursor(test.cursor))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *decoded != test.cursor {
+				t.Errorf("got %+v, want %+v", *decoded, test.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty", value: ""},
+		{name: "not base64", value: "!!not-base64!!"},
+		{name: "padded base64", value: base64.URLEncoding.EncodeToString([]byte(`{"s":"nam"}`))},
+		{name: "not json", value: base64.RawURLEncoding.EncodeToString([]byte("name:Budi"))},
+		{name: "wrong type", value: base64.RawURLEncoding.EncodeToString([]byte(`{"s":"name","id":"42"}`))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if cursor, err := DecodeCursor(test.value); err == nil {
+				t.Errorf("got cursor %+v, want an error", *cursor)
+			}
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		value string
+		key   string
+		expr  string
+		desc  bool
+		ok    bool
+	}{
+		{value: "", key: "name", expr: "employees.name", ok: true},
+		{value: "name", key: "name", expr: "employees.name", ok: true},
+		{value: "-createdAt", key: "-createdAt", expr: "employees.created_at", desc: true, ok: true},
+		{value: "salary"},
+		{value: "--name"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			sort, ok := ParseSort(test.value, testColumns, "name")
+			if ok != test.ok {
+				t.Fatalf("got ok %v, want %v", ok, test.ok)
+			}
+			if !ok {
+				return
+			}
+			if sort.Key != test.key || sort.Column.Expr != test.expr || sort.Desc != test.desc {
+				t.Errorf("got %+v, want key %s, expr %s, desc %v", *sort, test.key, test.expr, test.desc)
+			}
+		})
+	}
+}
+
+func TestSortQuery(t *testing.T) {
+	const query = "SELECT /*sortValue*/, id FROM employees WHERE /*keyset*/ ORDER BY /*orderBy*/"
+
+	tests := []struct {
+		name   string
+		sort   string
+		cursor *Cursor
+		want   string
+	}{
+		{
+			name: "first page",
+			sort: "name",
+			want: "SELECT employees.name, id FROM employees WHERE TRUE ORDER BY employees.name ASC, employees.id ASC",
+		},
+		{
+			name:   "next page",
+			sort:   "name",
+			cursor: &Cursor{Sort: "name", Value: "Budi", ID: 42},
+			want:   "SELECT employees.name, id FROM employees WHERE (employees.name, employees.id) > (@cursorValue::text, @cursorID::bigint) ORDER BY employees.name ASC, employees.id ASC",
+		},
+		{
+			name:   "previous page",
+			sort:   "name",
+			cursor: &Cursor{Sort: "name", Value: "Budi", ID: 42, Backward: true},
+			want:   "SELECT employees.name, id FROM employees WHERE (employees.name, employees.id) < (@cursorValue::text, @cursorID::bigint) ORDER BY employees.name DESC, employees.id DESC",
+		},
+		{
+			name: "descending first page",
+			sort: "-createdAt",
+			want: "SELECT employees.created_at, id FROM employees WHERE TRUE ORDER BY employees.created_at DESC, employees.id DESC",
+		},
+		{
+			name:   "descending next page",
+			sort:   "-createdAt",
+			cursor: &Cursor{Sort: "-createdAt", Value: "2024-03-01T08:00:00Z", ID: 7},
+			want:   "SELECT employees.created_at, id FROM employees WHERE (employees.created_at, employees.id) < (@cursorValue::timestamptz, @cursorID::bigint) ORDER BY employees.created_at DESC, employees.id DESC",
+		},
+		{
+			name:   "descending previous page",
+			sort:   "-createdAt",
+			cursor: &Cursor{Sort: "-createdAt", Value: "2024-03-01T08:00:00Z", ID: 7, Backward: true},
+			want:   "SELECT employees.created_at, id FROM employees WHERE (employees.created_at, employees.id) > (@cursorValue::timestamptz, @cursorID::bigint) ORDER BY employees.created_at ASC, employees.id ASC",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sort, ok := ParseSort(test.sort, testColumns, "name")
+			if !ok {
+				t.Fatalf("unknown sort %s", test.sort)
+			}
+
+			if got := sort.Query(query, "employees.id", test.cursor); got != test.want {
+				t.Errorf("got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func testRows(ids ...int64) []Row[int64] {
+	rows := make([]Row[int64], 0, len(ids))
+	for _, id := range ids {
+		// * Every row shares the sort value, so only the id tells them apart
+		rows = append(rows, Row[int64]{Item: id, SortValue: "Budi", ID: id})
+	}
+	return rows
+}
+
+func TestNewPage(t *testing.T) {
+	sort, _ := ParseSort("name", testColumns, "name")
+
+	tests := []struct {
+		name   string
+		rows   []Row[int64]
+		cursor *Cursor
+		data   []int64
+		next   *Cursor
+		prev   *Cursor
+	}{
+		{
+			name: "empty",
+			data: []int64{},
+		},
+		{
+			name: "single page",
+			rows: testRows(1, 2, 3),
+			data: []int64{1, 2, 3},
+		},
+		{
+			name: "first page with more",
+			rows: testRows(1, 2, 3, 4),
+			data: []int64{1, 2, 3},
+			next: &Cursor{Sort: "name", Value: "Budi", ID: 3},
+		},
+		{
+			name:   "middle page",
+			rows:   testRows(4, 5, 6, 7),
+			cursor: &Cursor{Sort: "name", Value: "Budi", ID: 3},
+			data:   []int64{4, 5, 6},
+			next:   &Cursor{Sort: "name", Value: "Budi", ID: 6},
+			prev:   &Cursor{Sort: "name", Value: "Budi", ID: 4, Backward: true},
+		},
+		{
+			name:   "last page exactly full",
+			rows:   testRows(7, 8, 9),
+			cursor: &Cursor{Sort: "name", Value: "Budi", ID: 6},
+			data:   []int64{7, 8, 9},
+			prev:   &Cursor{Sort: "name", Value: "Budi", ID: 7, Backward: true},
+		},
+		{
+			name:   "last page short",
+			rows:   testRows(10),
+			cursor: &Cursor{Sort: "name", Value: "Budi", ID: 9},
+			data:   []int64{10},
+			prev:   &Cursor{Sort: "name", Value: "Budi", ID: 10, Backward: true},
+		},
+		{
+			name:   "previous page with more",
+			rows:   testRows(6, 5, 4, 3),
+			cursor: &Cursor{Sort: "name", Value: "Budi", ID: 7, Backward: true},
+			data:   []int64{4, 5, 6},
+			next:   &Cursor{Sort: "name", Value: "Budi", ID: 6},
+			prev:   &Cursor{Sort: "name", Value: "Budi", ID: 4, Backward: true},
+		},
+		{
+			name:   "previous page back at the start",
+			rows:   testRows(3, 2, 1),
+			cursor: &Cursor{Sort: "name", Value: "Budi", ID: 4, Backward: true},
+			data:   []int64{1, 2, 3},
+			next:   &Cursor{Sort: "name", Value: "Budi", ID: 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page := NewPage(test.rows, 3, sort, test.cursor)
+
+			if !reflect.DeepEqual(page.Data, test.data) {
+				t.Errorf("got data %v, want %v", page.Data, test.data)
+			}
+			assertCursor(t, "next", page.NextCursor, test.next)
+			assertCursor(t, "prev", page.PrevCursor, test.prev)
+		})
+	}
+}
+
+func assertCursor(t *testing.T, name string, got *string, want *Cursor) {
+	t.Helper()
+
+	if want == nil {
+		if got != nil {
+			t.Errorf("got %s cursor %s, want none", name, *got)
+		}
+		return
+	}
+	if got == nil {
+		t.Errorf("got no %s cursor, want %+v", name, *want)
+		return
+	}
+
+	cursor, err := DecodeCursor(*got)
+	if err != nil {
+		t.Fatalf("%s cursor: %v", name, err)
+	}
+	if *cursor != *want {
+		t.Errorf("got %s cursor %+v, want %+v", name, *cursor, *want)
+	}
+}
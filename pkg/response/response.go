@@ -37,6 +37,11 @@ func WriteErrorResponse(err error) (int, BaseResponse) {
 			Status:  http.StatusText(http.StatusUnauthorized),
 			Message: msg,
 		}
+	case customErrors.ErrForbidden:
+		return http.StatusForbidden, BaseResponse{
+			Status:  http.StatusText(http.StatusForbidden),
+			Message: msg,
+		}
 	default:
 		return http.StatusInternalServerError, BaseResponse{
 			Status:  http.StatusText(http.StatusInternalServerError),